@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/format"
+	"github.com/prometheus-community/windows_exporter/internal/httphandler"
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Output formats of the collect command.
+const (
+	collectFormatJSON        = "json"
+	collectFormatPrometheus  = "prom"
+	collectFormatOpenMetrics = "openmetrics"
+)
+
+// collectCommand holds the collect command, that prints a single collection to stdout.
+type collectCommand struct {
+	cmd     *kingpin.CmdClause
+	format  *string
+	timeout *time.Duration
+}
+
+// collectOutput is the output of the collect command in the JSON format.
+type collectOutput struct {
+	Collectors []collector.CollectorStatus `json:"collectors"`
+	Metrics    []format.MetricFamily       `json:"metrics"`
+}
+
+func newCollectCommand(app *kingpin.Application) *collectCommand {
+	cmd := app.Command("collect", "Run a single collection of the enabled collectors, print it to stdout and exit. The exit code is non-zero, if any collector failed.")
+
+	return &collectCommand{
+		cmd: cmd,
+		format: cmd.Flag(
+			"format",
+			"Output format. One of [json, prom, openmetrics]. For prom and openmetrics, the status of the collectors is printed to stderr.",
+		).Default(collectFormatPrometheus).Enum(collectFormatJSON, collectFormatPrometheus, collectFormatOpenMetrics),
+		timeout: cmd.Flag(
+			"timeout",
+			"Timeout of the collection.",
+		).Default("30s").Duration(),
+	}
+}
+
+// run builds the enabled collectors, runs a single collection and prints it to stdout.
+// It returns 1, if any collector failed.
+func (c *collectCommand) run(ctx context.Context, logger *slog.Logger, flags *flagConfig) int {
+	metricFamilies, err := gatherOnce(ctx, logger, flags, *c.timeout)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to collect metrics",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	statuses := enabledCollectorStatuses(flags.collectors.Status())
+
+	if err = c.write(os.Stdout, os.Stderr, metricFamilies, statuses); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to write metrics",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	if failed := failedCollectors(statuses); len(failed) > 0 {
+		logger.LogAttrs(ctx, slog.LevelError, "collectors failed",
+			slog.Any("collectors", failed),
+		)
+
+		return 1
+	}
+
+	return 0
+}
+
+// write writes the metrics in the output format to stdout. The JSON format includes the status of the collectors,
+// otherwise it is written to stderr, so stdout holds valid exposition format.
+func (c *collectCommand) write(stdout, stderr io.Writer, metricFamilies []*dto.MetricFamily, statuses []collector.CollectorStatus) error {
+	if *c.format == collectFormatJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(collectOutput{Collectors: statuses, Metrics: format.ToJSON(metricFamilies)}); err != nil {
+			return fmt.Errorf("failed to encode metrics: %w", err)
+		}
+
+		return nil
+	}
+
+	expositionFormat := expfmt.NewFormat(expfmt.TypeTextPlain)
+	if *c.format == collectFormatOpenMetrics {
+		expositionFormat = expfmt.NewFormat(expfmt.TypeOpenMetrics)
+	}
+
+	encoder := expfmt.NewEncoder(stdout, expositionFormat)
+
+	for _, mf := range metricFamilies {
+		if err := encoder.Encode(mf); err != nil {
+			return fmt.Errorf("failed to encode metrics: %w", err)
+		}
+	}
+
+	if closer, ok := encoder.(expfmt.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("failed to encode metrics: %w", err)
+		}
+	}
+
+	table := tabwriter.NewWriter(stderr, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "COLLECTOR\tSTATUS\tDURATION\tMETRICS\tERROR")
+
+	for _, status := range statuses {
+		lastError := status.LastError
+		if status.BuildError != "" {
+			lastError = status.BuildError
+		}
+
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%.3fs\t%d\t%s\n", status.Name, status.LastScrapeStatus,
+			status.LastScrapeDuration, status.MetricCount, lastError)
+	}
+
+	return table.Flush()
+}
+
+// gatherOnce builds the enabled collectors, gathers the metrics once the same way as a scrape of the metrics endpoint
+// and closes the collectors. Failed collectors don't return an error, they are reported by [collector.Collection.Status].
+func gatherOnce(ctx context.Context, logger *slog.Logger, flags *flagConfig, timeout time.Duration) ([]*dto.MetricFamily, error) {
+	handlerOptions, err := flags.handlerOptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metric relabel configs: %w", err)
+	}
+
+	if _, err = flags.enableCollectors(); err != nil {
+		return nil, fmt.Errorf("couldn't enable collectors: %w", err)
+	}
+
+	defer func() {
+		if err := flags.collectors.Close(); err != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "failed to close collectors",
+				slog.Any("err", err),
+			)
+		}
+	}()
+
+	if err = flags.collectors.Build(ctx, logger); err != nil {
+		return nil, fmt.Errorf("couldn't initialize collectors: %w", err)
+	}
+
+	metricsHandler := httphandler.New(logger, flags.collectors, handlerOptions)
+
+	// Gather returns the metrics that could be gathered, even on error.
+	metricFamilies, err := metricsHandler.Gather(timeout)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "error gathering metrics",
+			slog.Any("err", err),
+		)
+	}
+
+	if len(metricFamilies) == 0 {
+		return nil, errors.New("no metrics gathered")
+	}
+
+	return metricFamilies, nil
+}
+
+// enabledCollectorStatuses returns the statuses of the enabled collectors.
+func enabledCollectorStatuses(statuses []collector.CollectorStatus) []collector.CollectorStatus {
+	enabled := make([]collector.CollectorStatus, 0, len(statuses))
+
+	for _, status := range statuses {
+		if status.Enabled {
+			enabled = append(enabled, status)
+		}
+	}
+
+	return enabled
+}
+
+// failedCollectors returns the names of the enabled collectors, that are not built or whose last collection did not succeed.
+func failedCollectors(statuses []collector.CollectorStatus) []string {
+	var failed []string
+
+	for _, status := range statuses {
+		if status.Enabled && (status.BuildState != "ready" || status.LastScrapeStatus != "success") {
+			failed = append(failed, status.Name)
+		}
+	}
+
+	return failed
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/config"
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+)
+
+// configCommand holds the config commands, that work on the configuration without running the exporter.
+type configCommand struct {
+	cmd   *kingpin.CmdClause
+	show  *kingpin.CmdClause
+	check *kingpin.CmdClause
+}
+
+func newConfigCommand(app *kingpin.Application) *configCommand {
+	cmd := app.Command("config", "Work on the configuration without running the exporter.")
+
+	return &configCommand{
+		cmd:   cmd,
+		check: cmd.Command("check", "Validate --config.file and the files of --config.dir, print the problems with file and line and exit. The exit code is non-zero, if the configuration is invalid."),
+		show:  cmd.Command("show", "Print the effective configuration merged from --config.file and --config.dir, or fetched from --config.url, to stdout and exit. Placeholders of local files are expanded and secrets are redacted. CLI flags are not included."),
+	}
+}
+
+// runShow prints the effective configuration to stdout.
+// The configuration was already loaded and validated by [config.Parse].
+func (c *configCommand) runShow(ctx context.Context, logger *slog.Logger, flags *flagConfig, remoteConfig *config.Remote) int {
+	var (
+		data []byte
+		err  error
+	)
+
+	if remoteConfig != nil {
+		data = remoteConfig.Data()
+	} else {
+		data, err = config.MergeConfigFiles(*flags.configFile, *flags.configDir)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "failed to merge configuration files",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+	}
+
+	// Placeholders are expanded, except in the configuration of --config.url. Values read from files and credentials are redacted.
+	if data, err = config.RedactedConfig(data, remoteConfig == nil); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to expand configuration",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	if _, err = os.Stdout.Write(data); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to write configuration",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	return 0
+}
+
+// runCheck validates the configuration files and prints the problems to stdout.
+// It runs before the configuration is loaded, because loading stops at the first error.
+func (c *configCommand) runCheck(args []string) int {
+	configFile, configDir := config.ParseConfigFile(args), config.ParseConfigDir(args)
+
+	if configFile == "" && configDir == "" {
+		_, _ = fmt.Fprintln(os.Stderr, "no configuration to check, use --config.file or --config.dir")
+
+		return 1
+	}
+
+	diagnostics, err := config.Check(configFile, configDir, collector.Available())
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+
+	// The remaining checks, e.g., conflicts between the files and the values of the flags, require a valid configuration.
+	if len(diagnostics) == 0 {
+		app, _ := newApp()
+
+		if _, _, err = config.Parse(app, args, nil); err != nil {
+			diagnostics = append(diagnostics, config.Diagnostic{
+				File:    cmp.Or(configDir, configFile),
+				Message: err.Error(),
+			})
+		}
+	}
+
+	for _, diagnostic := range diagnostics {
+		_, _ = fmt.Fprintln(os.Stdout, diagnostic)
+	}
+
+	if len(diagnostics) > 0 {
+		return 1
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, "configuration is valid")
+
+	return 0
+}
+
+// printResolvedConfig prints the resolved configuration of the enabled collectors as JSON to stdout.
+func printResolvedConfig(ctx context.Context, logger *slog.Logger, flags *flagConfig) int {
+	if _, err := flags.enableCollectors(); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "couldn't enable collectors",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(flags.resolved.Config(flags.collectors.Enabled())); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to write configuration",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	return 0
+}
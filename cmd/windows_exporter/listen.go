@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/prometheus/exporter-toolkit/web"
+	"golang.org/x/sys/windows"
+)
+
+// unixSocketPrefix marks listen addresses that are paths of AF_UNIX sockets.
+const unixSocketPrefix = "unix://"
+
+// listen returns the listeners of the listen addresses. Addresses with the unix:// prefix are
+// AF_UNIX sockets, whose DACL is set from the SDDL string, if not empty. All other addresses are TCP addresses.
+func listen(addresses []string, unixSocketSDDL string) ([]net.Listener, error) {
+	if len(addresses) == 0 {
+		return nil, web.ErrNoListeners
+	}
+
+	var dacl *windows.ACL
+
+	if unixSocketSDDL != "" {
+		sd, err := windows.SecurityDescriptorFromString(unixSocketSDDL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse unix socket SDDL %q: %w", unixSocketSDDL, err)
+		}
+
+		dacl, _, err = sd.DACL()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get DACL of unix socket SDDL %q: %w", unixSocketSDDL, err)
+		}
+	}
+
+	listeners := make([]net.Listener, 0, len(addresses))
+
+	for _, address := range addresses {
+		var (
+			listener net.Listener
+			err      error
+		)
+
+		if path, ok := strings.CutPrefix(address, unixSocketPrefix); ok {
+			listener, err = listenUnix(path, dacl)
+		} else {
+			listener, err = net.Listen("tcp", address)
+		}
+
+		if err != nil {
+			for _, l := range listeners {
+				_ = l.Close()
+			}
+
+			return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+		}
+
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+// listenUnix listens on the AF_UNIX socket path. A stale socket of a previous run is removed.
+// The socket file is removed, once the listener is closed.
+// The DACL is applied after the socket is bound, AF_UNIX sockets can't be created with a security descriptor.
+// Until then, the socket inherits the permissions of the parent directory, which must be restricted already.
+func listenUnix(path string, dacl *windows.ACL) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if dacl == nil {
+		return listener, nil
+	}
+
+	err = windows.SetNamedSecurityInfo(path, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION,
+		nil, nil, dacl, nil,
+	)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to set permissions of socket: %w", err), listener.Close())
+	}
+
+	return listener, nil
+}
@@ -40,8 +40,12 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/httphandler"
 	"github.com/prometheus-community/windows_exporter/internal/log"
 	"github.com/prometheus-community/windows_exporter/internal/log/flag"
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	"github.com/prometheus-community/windows_exporter/internal/relabel"
 	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus-community/windows_exporter/pkg/collector"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/version"
 	"github.com/prometheus/exporter-toolkit/web"
 	webflag "github.com/prometheus/exporter-toolkit/web/kingpinflag"
@@ -66,50 +70,119 @@ func main() {
 	<-serviceManagerFinishedCh
 }
 
-func run(ctx context.Context, args []string) int {
-	startTime := time.Now()
+// flagConfig holds the flags of the exporter.
+type flagConfig struct {
+	configFile             *string
+	configDir              *string
+	configURL              *string
+	configWatchInterval    *time.Duration
+	configPrint            *bool
+	webConfig              *web.FlagConfig
+	unixSocketSDDL         *string
+	metricsPath            *string
+	disableExporterMetrics *bool
+	enabledCollectors      *string
+	disabledCollectors     *string
+	timeoutMargin          *float64
+	metricRelabelConfigs   *string
+	debugEnabled           *bool
+	enableLifecycle        *bool
+	enableConfig           *bool
+	processPriority        *string
+	memoryLimit            *int64
+	logConfig              *log.Config
+	pushBuffer             *pushBufferFlags
+	remoteWrite            *remoteWriteFlags
+	otlp                   *otlpFlags
+	pushgateway            *pushgatewayCommand
+	collect                *collectCommand
+	config                 *configCommand
+	collectors             *collector.Collection
+	// resolved holds the resolved values of the flags, set by config.Parse.
+	resolved *config.Resolved
+}
 
+// newApp returns the kingpin application with all flags of the exporter, including the collector flags.
+func newApp() (*kingpin.Application, *flagConfig) {
 	app := kingpin.New("windows_exporter", "A metrics collector for Windows.")
 
-	var (
-		configFile = app.Flag(
+	flags := &flagConfig{
+		configFile: app.Flag(
 			"config.file",
 			"YAML configuration file to use. Values set in this file will be overridden by CLI flags.",
-		).String()
-		webConfig   = webflag.AddFlags(app, ":9182")
-		metricsPath = app.Flag(
+		).String(),
+		configDir: app.Flag(
+			"config.dir",
+			"Directory of YAML configuration files (*.yml, *.yaml), merged in lexical order on top of --config.file. Lists are merged, conflicting values are an error.",
+		).String(),
+		configURL: app.Flag(
+			"config.url",
+			"HTTP(S) URL of the YAML configuration to use instead of --config.file. The last good configuration is cached on disk and used, if the URL can't be fetched.",
+		).String(),
+		configWatchInterval: app.Flag(
+			"config.watch-interval",
+			"Interval to check the configuration files or URL for changes and reload it. 0 disables the watcher. The configuration can be reloaded via POST /-/reload as well, if --web.enable-lifecycle is set.",
+		).Default("0s").Duration(),
+		configPrint: app.Flag(
+			"config.print",
+			"Print the resolved configuration with the source of each value (default, file or flag) as JSON to stdout and exit. Only the flags of the enabled collectors are printed, secrets are redacted.",
+		).Bool(),
+		webConfig: webflag.AddFlags(app, ":9182"),
+		unixSocketSDDL: app.Flag(
+			"web.unix-socket.sddl",
+			"Security descriptor in SDDL format applied to the sockets of unix:// listen addresses, e.g. D:P(A;;GA;;;SY)(A;;GA;;;BA). By default, the sockets inherit the permissions of their directory. The descriptor is applied after the socket is created, so the directory must be restricted already.",
+		).Default("").String(),
+		enableLifecycle: app.Flag(
+			"web.enable-lifecycle",
+			"Enable reloading the configuration via POST /-/reload.",
+		).Default("false").Bool(),
+		enableConfig: app.Flag(
+			"web.enable-config",
+			"Enable showing the resolved configuration via GET /config. Secrets are redacted.",
+		).Default("false").Bool(),
+		metricsPath: app.Flag(
 			"telemetry.path",
 			"URL path for surfacing collected metrics.",
-		).Default("/metrics").String()
-		disableExporterMetrics = app.Flag(
+		).Default("/metrics").String(),
+		disableExporterMetrics: app.Flag(
 			"web.disable-exporter-metrics",
 			"Exclude metrics about the exporter itself (promhttp_*, process_*, go_*).",
-		).Bool()
-		enabledCollectors = app.Flag(
+		).Bool(),
+		enabledCollectors: app.Flag(
 			"collectors.enabled",
 			"Comma-separated list of collectors to use. Use '[defaults]' as a placeholder for all the collectors enabled by default.").
-			Default(collector.DefaultCollectors).String()
-		disabledCollectors = app.Flag(
+			Default(collector.DefaultCollectors).String(),
+		disabledCollectors: app.Flag(
 			"collectors.disabled",
 			"Comma-separated list of collectors to exclude. Can be used to disable collector from the defaults.").
-			Default("").String()
-		timeoutMargin = app.Flag(
+			Default("").String(),
+		timeoutMargin: app.Flag(
 			"scrape.timeout-margin",
 			"Seconds to subtract from the timeout allowed by the client. Tune to allow for overhead or high loads.",
-		).Default("0.5").Float64()
-		debugEnabled = app.Flag(
+		).Default("0.5").Float64(),
+		metricRelabelConfigs: app.Flag(
+			"scrape.metric-relabel-configs",
+			"YAML list of relabel configs applied to all metrics before exposition. Compatible with metric_relabel_configs of Prometheus.",
+		).Default("").String(),
+		debugEnabled: app.Flag(
 			"debug.enabled",
 			"If true, windows_exporter will expose debug endpoints under /debug/pprof.",
-		).Default("false").Bool()
-		processPriority = app.Flag(
+		).Default("false").Bool(),
+		processPriority: app.Flag(
 			"process.priority",
 			"Priority of the exporter process. Higher priorities may improve exporter responsiveness during periods of system load. Can be one of [\"realtime\", \"high\", \"abovenormal\", \"normal\", \"belownormal\", \"low\"]",
-		).Default("normal").String()
-		memoryLimit = app.Flag(
+		).Default("normal").String(),
+		memoryLimit: app.Flag(
 			"process.memory-limit",
 			"Limit memory usage in bytes. This is a soft-limit and not guaranteed. 0 means no limit. Read more at https://pkg.go.dev/runtime/debug#SetMemoryLimit .",
-		).Default("200000000").Int64()
-	)
+		).Default("200000000").Int64(),
+	}
+
+	// The cache file is parsed manually by config.ParseConfigURL, before the configuration is fetched.
+	app.Flag(
+		"config.url.cache-file",
+		"File to cache the last good configuration fetched from --config.url. Defaults to config_url_cache.yml next to the executable.",
+	).Default("").String()
 
 	logFile := &log.AllowedFile{}
 
@@ -118,16 +191,96 @@ func run(ctx context.Context, args []string) int {
 		_ = logFile.Set("eventlog")
 	}
 
-	logConfig := &log.Config{File: logFile}
-	flag.AddFlags(app, logConfig)
+	flags.logConfig = &log.Config{File: logFile}
+	flag.AddFlags(app, flags.logConfig)
+
+	flags.pushBuffer = newPushBufferFlags(app)
+	flags.remoteWrite = newRemoteWriteFlags(app)
+	flags.otlp = newOTLPFlags(app)
+
+	app.Command("serve", "Serve the metrics over HTTP. This is the default command.").Default()
+	flags.pushgateway = newPushgatewayCommand(app)
+	flags.collect = newCollectCommand(app)
+	flags.config = newConfigCommand(app)
 
 	app.Version(version.Print("windows_exporter"))
 	app.HelpFlag.Short('h')
 
 	// Initialize collectors before loading and parsing CLI arguments
-	collectors := collector.NewWithFlags(app)
+	flags.collectors = collector.NewWithFlags(app)
+
+	return app, flags
+}
+
+// enableCollectors enables the configured collectors and returns the list of enabled collectors.
+func (f *flagConfig) enableCollectors() ([]string, error) {
+	enabledCollectorList := expandEnabledCollectors(*f.enabledCollectors)
+	if err := f.collectors.Enable(enabledCollectorList); err != nil {
+		return nil, err
+	}
+
+	if *f.disabledCollectors != "" {
+		f.collectors.Disable(slices.Compact(strings.Split(*f.disabledCollectors, ",")))
+	}
 
-	if err := config.Parse(app, args); err != nil {
+	return enabledCollectorList, nil
+}
+
+// handlerOptions returns the options of the metrics handler.
+func (f *flagConfig) handlerOptions(extraCollectors ...prometheus.Collector) (*httphandler.Options, error) {
+	relabelConfigs, err := relabel.ParseConfigs(*f.metricRelabelConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &httphandler.Options{
+		DisableExporterMetrics: *f.disableExporterMetrics,
+		TimeoutMargin:          *f.timeoutMargin,
+		MetricRelabelConfigs:   relabelConfigs,
+		Collectors:             extraCollectors,
+		Config:                 f.resolved,
+	}, nil
+}
+
+func run(ctx context.Context, args []string) int {
+	startTime := time.Now()
+
+	app, flags := newApp()
+
+	// The config check command reports all problems of the configuration, instead of stopping at the first one on load.
+	if pc, err := app.ParseContext(args); err == nil && pc.SelectedCommand == flags.config.check {
+		return flags.config.runCheck(args)
+	}
+
+	var (
+		remoteConfig *config.Remote
+		fetchErr     error
+		err          error
+	)
+
+	if configURL, cacheFile := config.ParseConfigURL(args); configURL != "" {
+		remoteConfig, err = config.NewRemote(configURL, cacheFile)
+		if err != nil {
+			//nolint:sloglint // we do not have an logger yet
+			slog.LogAttrs(ctx, slog.LevelError, "Failed to load configuration",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+
+		if _, fetchErr = remoteConfig.Fetch(ctx); fetchErr != nil && !remoteConfig.UsingCache() {
+			//nolint:sloglint // we do not have an logger yet
+			slog.LogAttrs(ctx, slog.LevelError, "Failed to load configuration",
+				slog.Any("err", fetchErr),
+			)
+
+			return 1
+		}
+	}
+
+	command, resolved, err := config.Parse(app, args, remoteConfig)
+	if err != nil {
 		//nolint:sloglint // we do not have an logger yet
 		slog.LogAttrs(ctx, slog.LevelError, "Failed to load configuration",
 			slog.Any("err", err),
@@ -136,9 +289,16 @@ func run(ctx context.Context, args []string) int {
 		return 1
 	}
 
-	debug.SetMemoryLimit(*memoryLimit)
+	flags.resolved = resolved
 
-	logger, err := log.New(logConfig)
+	debug.SetMemoryLimit(*flags.memoryLimit)
+
+	// The collect and config show commands and --config.print print to stdout.
+	if (command == flags.collect.cmd.FullCommand() || command == flags.config.show.FullCommand() || *flags.configPrint) && flags.logConfig.File.String() == "stdout" {
+		_ = flags.logConfig.File.Set("stderr")
+	}
+
+	logger, err := log.New(flags.logConfig)
 	if err != nil {
 		logger.LogAttrs(ctx, slog.LevelError, "failed to create logger",
 			slog.Any("err", err),
@@ -149,33 +309,112 @@ func run(ctx context.Context, args []string) int {
 
 	logger.LogAttrs(ctx, slog.LevelDebug, "logging has Started")
 
-	if configFile != nil && *configFile != "" {
-		logger.LogAttrs(ctx, slog.LevelInfo, "using configuration file: "+*configFile)
+	if *flags.configFile != "" {
+		logger.LogAttrs(ctx, slog.LevelInfo, "using configuration file: "+*flags.configFile)
 	}
 
-	if err = setPriorityWindows(ctx, logger, os.Getpid(), *processPriority); err != nil {
-		logger.LogAttrs(ctx, slog.LevelError, "failed to set process priority",
+	if *flags.configDir != "" {
+		logger.LogAttrs(ctx, slog.LevelInfo, "using configuration directory: "+*flags.configDir)
+	}
+
+	if remoteConfig != nil {
+		logger.LogAttrs(ctx, slog.LevelInfo, "using configuration URL: "+*flags.configURL)
+
+		if fetchErr != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "failed to fetch configuration, using the cached configuration",
+				slog.Any("err", fetchErr),
+			)
+		}
+	}
+
+	if *flags.configPrint {
+		return printResolvedConfig(ctx, logger, flags)
+	}
+
+	switch command {
+	case flags.pushgateway.cmd.FullCommand():
+		return flags.pushgateway.run(ctx, logger, flags)
+	case flags.collect.cmd.FullCommand():
+		return flags.collect.run(ctx, logger, flags)
+	case flags.config.show.FullCommand():
+		return flags.config.runShow(ctx, logger, flags, remoteConfig)
+	}
+
+	var metricsHandler *httphandler.MetricsHTTPHandler
+
+	reloadHandler := httphandler.NewReloadHandler(logger, func(ctx context.Context) error {
+		return reloadConfig(ctx, logger, args, remoteConfig, metricsHandler)
+	})
+
+	exporterCollectors := []prometheus.Collector{reloadHandler}
+	if remoteConfig != nil {
+		exporterCollectors = append(exporterCollectors, remoteConfig)
+	}
+
+	gather := func(timeout time.Duration) ([]*dto.MetricFamily, error) {
+		return metricsHandler.Gather(timeout)
+	}
+
+	var pushers []*push.Pusher
+
+	remoteWritePusher, err := flags.remoteWrite.newPusher(logger, gather, flags.pushBuffer)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to configure remote write",
 			slog.Any("err", err),
 		)
 
 		return 1
 	}
 
-	enabledCollectorList := expandEnabledCollectors(*enabledCollectors)
-	if err := collectors.Enable(enabledCollectorList); err != nil {
-		logger.LogAttrs(ctx, slog.LevelError, "couldn't enable collectors",
+	if remoteWritePusher != nil {
+		pushers = append(pushers, remoteWritePusher)
+	}
+
+	otlpPusher, err := flags.otlp.newPusher(logger, gather, flags.pushBuffer, startTime)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to configure OTLP export",
 			slog.Any("err", err),
 		)
 
 		return 1
 	}
 
-	if *disabledCollectors != "" {
-		collectors.Disable(slices.Compact(strings.Split(*disabledCollectors, ",")))
+	if otlpPusher != nil {
+		pushers = append(pushers, otlpPusher)
+	}
+
+	for _, pusher := range pushers {
+		exporterCollectors = append(exporterCollectors, pusher)
+	}
+
+	handlerOptions, err := flags.handlerOptions(exporterCollectors...)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to parse metric relabel configs",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	if err = setPriorityWindows(ctx, logger, os.Getpid(), *flags.processPriority); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to set process priority",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	enabledCollectorList, err := flags.enableCollectors()
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "couldn't enable collectors",
+			slog.Any("err", err),
+		)
+
+		return 1
 	}
 
 	// Initialize collectors before loading
-	if err = collectors.Build(ctx, logger); err != nil {
+	if err = flags.collectors.Build(ctx, logger); err != nil {
 		for _, err := range utils.SplitError(err) {
 			logger.LogAttrs(ctx, slog.LevelError, "couldn't initialize collector",
 				slog.Any("err", err),
@@ -189,15 +428,36 @@ func run(ctx context.Context, args []string) int {
 
 	logger.InfoContext(ctx, "Enabled collectors: "+strings.Join(enabledCollectorList, ", "))
 
+	metricsHandler = httphandler.New(logger, flags.collectors, handlerOptions)
+
+	for _, pusher := range pushers {
+		go pusher.Run(ctx)
+	}
+
+	if *flags.configWatchInterval > 0 && (*flags.configFile != "" || *flags.configDir != "") {
+		go watchConfigFiles(ctx, logger, *flags.configFile, *flags.configDir, *flags.configWatchInterval, reloadHandler)
+	}
+
+	if *flags.configWatchInterval > 0 && remoteConfig != nil {
+		go watchConfigURL(ctx, logger, remoteConfig, *flags.configWatchInterval, reloadHandler)
+	}
+
 	mux := http.NewServeMux()
 	mux.Handle("GET /health", httphandler.NewHealthHandler())
 	mux.Handle("GET /version", httphandler.NewVersionHandler())
-	mux.Handle("GET "+*metricsPath, httphandler.New(logger, collectors, &httphandler.Options{
-		DisableExporterMetrics: *disableExporterMetrics,
-		TimeoutMargin:          *timeoutMargin,
-	}))
+	mux.Handle("GET /api/v1/collectors", httphandler.NewCollectorsHandler(metricsHandler))
+	mux.Handle("GET "+*flags.metricsPath, metricsHandler)
+	mux.Handle("GET "+strings.TrimSuffix(*flags.metricsPath, "/")+"/metadata", httphandler.NewMetadataHandler(metricsHandler))
+
+	if *flags.enableLifecycle {
+		mux.Handle("POST /-/reload", fetchBeforeReload(logger, remoteConfig, reloadHandler))
+	}
+
+	if *flags.enableConfig {
+		mux.Handle("GET /config", httphandler.NewConfigHandler(metricsHandler))
+	}
 
-	if *debugEnabled {
+	if *flags.debugEnabled {
 		mux.HandleFunc("GET /debug/pprof/", pprof.Index)
 		mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
 		mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
@@ -222,10 +482,19 @@ func run(ctx context.Context, args []string) int {
 		Handler:           mux,
 	}
 
+	listeners, err := listen(*flags.webConfig.WebListenAddresses, *flags.unixSocketSDDL)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "Failed to start windows_exporter",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
 	errCh := make(chan error, 1)
 
 	go func() {
-		if err := web.ListenAndServe(server, webConfig, logger); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		if err := web.ServeMultiple(listeners, server, flags.webConfig, logger); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			errCh <- err
 		}
 
@@ -19,17 +19,22 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus-community/windows_exporter/internal/config"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sys/windows"
 )
@@ -152,15 +157,21 @@ func captureOutput(tb testing.TB, f func()) string {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
+	// The pipe is read concurrently, f blocks once the pipe buffer is full, e.g. on the output of the collect command.
+	outCh := make(chan []byte, 1)
+
+	go func() {
+		out, _ := io.ReadAll(r)
+		outCh <- out
+	}()
+
 	f()
 
 	os.Stdout = orig
 
 	_ = w.Close()
 
-	out, _ := io.ReadAll(r)
-
-	return string(out)
+	return string(<-outCh)
 }
 
 func waitUntilListening(tb testing.TB, network, address string) error {
@@ -197,3 +208,265 @@ func waitUntilListening(tb testing.TB, network, address string) error {
 
 	return fmt.Errorf("listener not listening: %w", err)
 }
+
+func TestRunReload(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`{"web":{"listen-address":"127.0.0.1:8085"},"collectors":{"enabled":"os"}}`), 0o600))
+
+	exitCodeCh := make(chan int)
+
+	go func() {
+		exitCodeCh <- run(ctx, []string{"--config.file=" + configFile, "--web.enable-lifecycle"})
+	}()
+
+	t.Cleanup(func() {
+		select {
+		case exitCode := <-exitCodeCh:
+			require.Equal(t, 0, exitCode)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for exit code")
+		}
+	})
+
+	require.NoError(t, waitUntilListening(t, "tcp", "127.0.0.1:8085"))
+
+	reload := func() int {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://127.0.0.1:8085/-/reload", nil)
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		return resp.StatusCode
+	}
+
+	metrics := func() string {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1:8085/metrics", nil)
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		return string(body)
+	}
+
+	require.NoError(t, os.WriteFile(configFile, []byte(`{"web":{"listen-address":"127.0.0.1:8085"},"collectors":{"enabled":"os,memory"}}`), 0o600))
+	require.Equal(t, http.StatusOK, reload())
+
+	body := metrics()
+	require.Contains(t, body, `windows_exporter_collector_success{collector="memory"} 1`)
+	require.Contains(t, body, "windows_exporter_config_last_reload_successful 1")
+
+	require.NoError(t, os.WriteFile(configFile, []byte(`{"collectors":{"enabled":"unknown"}}`), 0o600))
+	require.Equal(t, http.StatusInternalServerError, reload())
+
+	body = metrics()
+	require.Contains(t, body, `windows_exporter_collector_success{collector="memory"} 1`)
+	require.Contains(t, body, "windows_exporter_config_last_reload_successful 0")
+
+	cancel()
+}
+
+func TestRunConfigURL(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"web":{"listen-address":"127.0.0.1:8087"},"collectors":{"enabled":"os"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cacheFile := filepath.Join(t.TempDir(), "cache.yml")
+
+	exitCodeCh := make(chan int)
+
+	go func() {
+		exitCodeCh <- run(ctx, []string{"--config.url=" + server.URL, "--config.url.cache-file=" + cacheFile})
+	}()
+
+	t.Cleanup(func() {
+		select {
+		case exitCode := <-exitCodeCh:
+			require.Equal(t, 0, exitCode)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for exit code")
+		}
+	})
+
+	require.NoError(t, waitUntilListening(t, "tcp", "127.0.0.1:8087"))
+	require.FileExists(t, cacheFile)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1:8087/metrics", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Contains(t, string(body), "windows_exporter_config_url_last_fetch_successful 1")
+	require.Contains(t, string(body), `windows_exporter_collector_success{collector="os"} 1`)
+
+	cancel()
+}
+
+func TestRunUnixSocket(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	socket := filepath.Join(t.TempDir(), "metrics.sock")
+
+	exitCodeCh := make(chan int)
+
+	go func() {
+		exitCodeCh <- run(ctx, []string{
+			"--web.listen-address=unix://" + socket,
+			"--web.unix-socket.sddl=D:P(A;;GA;;;WD)",
+			"--collectors.enabled=os",
+		})
+	}()
+
+	t.Cleanup(func() {
+		select {
+		case exitCode := <-exitCodeCh:
+			require.Equal(t, 0, exitCode)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for exit code")
+		}
+
+		require.NoFileExists(t, socket)
+	})
+
+	require.Eventually(t, func() bool {
+		_, err := os.Lstat(socket)
+
+		return err == nil
+	}, 2*time.Second, 50*time.Millisecond)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/metrics", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Contains(t, string(body), "# HELP windows_exporter_build_info")
+
+	cancel()
+}
+
+func TestRunPush(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		method string
+		path   string
+		body   string
+	)
+
+	pushgateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		data, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(data)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(pushgateway.Close)
+
+	exitCode := run(t.Context(), []string{
+		"push",
+		"--pushgateway.url=" + pushgateway.URL,
+		"--pushgateway.grouping=instance=build-agent-1",
+		"--collectors.enabled=os",
+	})
+	require.Equal(t, 0, exitCode)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Equal(t, http.MethodPut, method)
+	require.Equal(t, "/metrics/job/windows_exporter/instance/build-agent-1", path)
+	require.NotEmpty(t, body)
+
+	exitCode = run(t.Context(), []string{
+		"push",
+		"--pushgateway.url=http://127.0.0.1:1",
+		"--collectors.enabled=os",
+	})
+	require.Equal(t, 1, exitCode)
+}
+
+// TestRunCollect is not run in parallel, because it captures stdout.
+func TestRunCollect(t *testing.T) {
+	var exitCode int
+
+	stdout := captureOutput(t, func() {
+		exitCode = run(t.Context(), []string{"collect", "--collectors.enabled=os", "--format=json"})
+	})
+	require.Equal(t, 0, exitCode)
+
+	var output struct {
+		Collectors []struct {
+			Name             string `json:"name"`
+			LastScrapeStatus string `json:"last_scrape_status"`
+		} `json:"collectors"`
+		Metrics []struct {
+			Name string `json:"name"`
+		} `json:"metrics"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(stdout), &output), stdout)
+	require.Len(t, output.Collectors, 1)
+	require.Equal(t, "os", output.Collectors[0].Name)
+	require.Equal(t, "success", output.Collectors[0].LastScrapeStatus)
+	require.NotEmpty(t, output.Metrics)
+}
+
+func TestRunConfigPrint(t *testing.T) {
+	var exitCode int
+
+	stdout := captureOutput(t, func() {
+		exitCode = run(t.Context(), []string{"--collectors.enabled=os,service", "--collector.service.include=^wuauserv$", "--config.print"})
+	})
+	require.Equal(t, 0, exitCode)
+
+	var output config.ResolvedConfig
+
+	require.NoError(t, json.Unmarshal([]byte(stdout), &output), stdout)
+	require.Contains(t, output.Flags, config.Value{Name: "collectors.enabled", Value: "os,service", Source: config.SourceFlag})
+	require.Contains(t, output.Flags, config.Value{Name: "log.level", Value: "info", Source: config.SourceDefault})
+	require.Contains(t, output.Collectors["service"], config.Value{Name: "collector.service.include", Value: "^wuauserv$", Source: config.SourceFlag})
+	require.Contains(t, output.Collectors, "os")
+	require.NotContains(t, output.Collectors, "cpu")
+}
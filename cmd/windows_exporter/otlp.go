@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	"github.com/prometheus-community/windows_exporter/internal/push/otlp"
+)
+
+// OTLP protocols, named like the values of OTEL_EXPORTER_OTLP_PROTOCOL.
+const (
+	otlpProtocolHTTP = "http/protobuf"
+	otlpProtocolGRPC = "grpc"
+)
+
+// otlpFlags holds the flags of the OTLP export.
+type otlpFlags struct {
+	endpoint      *string
+	protocol      *string
+	interval      *time.Duration
+	timeout       *time.Duration
+	queueCapacity *int
+	auth          *push.HTTPAuth
+}
+
+func newOTLPFlags(app *kingpin.Application) *otlpFlags {
+	return &otlpFlags{
+		endpoint: app.Flag(
+			"otlp.endpoint",
+			"Endpoint of an OTLP receiver, e.g., http://localhost:4318/v1/metrics for OTLP/HTTP or http://localhost:4317 for OTLP/gRPC. If set, the metrics are exported in the interval of --otlp.interval.",
+		).Default("").String(),
+		protocol: app.Flag(
+			"otlp.protocol",
+			"Protocol of the OTLP receiver. One of [http/protobuf, grpc].",
+		).Default(otlpProtocolHTTP).Enum(otlpProtocolHTTP, otlpProtocolGRPC),
+		interval: app.Flag(
+			"otlp.interval",
+			"Interval to collect and export the metrics. The collection is limited to this interval.",
+		).Default("30s").Duration(),
+		timeout: app.Flag(
+			"otlp.timeout",
+			"Timeout of an OTLP export request.",
+		).Default("10s").Duration(),
+		queueCapacity: app.Flag(
+			"otlp.queue-capacity",
+			"Maximum number of collections buffered in memory while the receiver is unreachable. The oldest collections are dropped, once the queue is full. Ignored, if --push.buffer.directory is set.",
+		).Default("100").Int(),
+		auth: push.NewHTTPAuthWithFlags(app, "otlp"),
+	}
+}
+
+// newPusher returns the OTLP pusher, or nil if no endpoint is configured.
+// Cumulative sums start at startTime.
+func (f *otlpFlags) newPusher(logger *slog.Logger, gather push.GatherFunc, buffer *pushBufferFlags, startTime time.Time) (*push.Pusher, error) {
+	if *f.endpoint == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	if *f.interval <= 0 {
+		return nil, errors.New("--otlp.interval must be greater than zero")
+	}
+
+	var client push.Client
+
+	switch *f.protocol {
+	case otlpProtocolGRPC:
+		grpcClient, err := otlp.NewGRPCClient(*f.endpoint, *f.auth, *f.timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		client = grpcClient
+	default:
+		httpClient, err := push.NewHTTPClient("otlp", *f.auth, *f.timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		client = otlp.NewHTTPClient(*f.endpoint, httpClient)
+	}
+
+	queue, err := buffer.newQueue("otlp", *f.queueCapacity)
+	if err != nil {
+		return nil, err
+	}
+
+	return push.New(
+		logger,
+		"otlp",
+		gather,
+		otlp.NewEncoder(startTime),
+		client,
+		queue,
+		push.Options{Interval: *f.interval},
+	), nil
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/alecthomas/units"
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	"github.com/prometheus-community/windows_exporter/internal/push/remotewrite"
+)
+
+// pushBufferFlags holds the flags of the buffer shared by all push exporters.
+type pushBufferFlags struct {
+	directory *string
+	maxSize   *units.Base2Bytes
+	maxAge    *time.Duration
+}
+
+func newPushBufferFlags(app *kingpin.Application) *pushBufferFlags {
+	return &pushBufferFlags{
+		directory: app.Flag(
+			"push.buffer.directory",
+			"Directory of the on-disk buffer of the push exporters. Each exporter uses a subdirectory. If empty, the payloads are buffered in memory and lost on restart.",
+		).Default("").String(),
+		maxSize: app.Flag(
+			"push.buffer.max-size",
+			"Maximum size of the on-disk buffer of each push exporter. The oldest payloads are dropped, once the buffer is full. 0 means no limit.",
+		).Default("512MB").Bytes(),
+		maxAge: app.Flag(
+			"push.buffer.max-age",
+			"Maximum age of the payloads in the on-disk buffer. Older payloads are dropped. 0 means no limit.",
+		).Default("24h").Duration(),
+	}
+}
+
+// newQueue returns the queue of the push exporter with the given name. Without buffer directory,
+// an in-memory queue with the given capacity is returned.
+func (f *pushBufferFlags) newQueue(name string, capacity int) (push.Queue, error) {
+	if *f.directory == "" {
+		return push.NewMemoryQueue(capacity), nil
+	}
+
+	queue, err := push.NewDiskQueue(filepath.Join(*f.directory, name), int64(*f.maxSize), *f.maxAge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open push buffer: %w", err)
+	}
+
+	return queue, nil
+}
+
+// remoteWriteFlags holds the flags of the remote write push mode.
+type remoteWriteFlags struct {
+	url           *string
+	interval      *time.Duration
+	timeout       *time.Duration
+	queueCapacity *int
+	auth          *push.HTTPAuth
+}
+
+func newRemoteWriteFlags(app *kingpin.Application) *remoteWriteFlags {
+	return &remoteWriteFlags{
+		url: app.Flag(
+			"push.remote-write.url",
+			"URL of a Prometheus remote write receiver. If set, the metrics are pushed in the interval of --push.remote-write.interval.",
+		).Default("").String(),
+		interval: app.Flag(
+			"push.remote-write.interval",
+			"Interval to collect and push the metrics. The collection is limited to this interval.",
+		).Default("30s").Duration(),
+		timeout: app.Flag(
+			"push.remote-write.timeout",
+			"Timeout of a remote write request.",
+		).Default("30s").Duration(),
+		queueCapacity: app.Flag(
+			"push.remote-write.queue-capacity",
+			"Maximum number of collections buffered in memory while the receiver is unreachable. The oldest collections are dropped, once the queue is full. Ignored, if --push.buffer.directory is set.",
+		).Default("100").Int(),
+		auth: push.NewHTTPAuthWithFlags(app, "push.remote-write"),
+	}
+}
+
+// newPusher returns the remote write pusher, or nil if no URL is configured.
+func (f *remoteWriteFlags) newPusher(logger *slog.Logger, gather push.GatherFunc, buffer *pushBufferFlags) (*push.Pusher, error) {
+	if *f.url == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	if *f.interval <= 0 {
+		return nil, errors.New("--push.remote-write.interval must be greater than zero")
+	}
+
+	httpClient, err := push.NewHTTPClient("remote_write", *f.auth, *f.timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := buffer.newQueue("remote_write", *f.queueCapacity)
+	if err != nil {
+		return nil, err
+	}
+
+	return push.New(
+		logger,
+		"remote_write",
+		gather,
+		remotewrite.Encode,
+		remotewrite.NewClient(*f.url, httpClient),
+		queue,
+		push.Options{Interval: *f.interval},
+	), nil
+}
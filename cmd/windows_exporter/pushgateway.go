@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	"github.com/prometheus/client_golang/prometheus"
+	pushgateway "github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// pushgatewayCommand holds the push command, that pushes a single collection to a Pushgateway.
+type pushgatewayCommand struct {
+	cmd      *kingpin.CmdClause
+	url      *string
+	job      *string
+	grouping *map[string]string
+	timeout  *time.Duration
+	auth     *push.HTTPAuth
+}
+
+func newPushgatewayCommand(app *kingpin.Application) *pushgatewayCommand {
+	cmd := app.Command("push", "Run a single collection of the enabled collectors, push it to a Pushgateway and exit. The exit code is non-zero, if any collector failed.")
+
+	return &pushgatewayCommand{
+		cmd: cmd,
+		url: cmd.Flag(
+			"pushgateway.url",
+			"URL of the Pushgateway, e.g., http://pushgateway:9091.",
+		).Required().String(),
+		job: cmd.Flag(
+			"pushgateway.job",
+			"Value of the job label of the pushed metrics.",
+		).Default("windows_exporter").String(),
+		grouping: cmd.Flag(
+			"pushgateway.grouping",
+			"Grouping label of the pushed metrics in the form name=value. Can be repeated.",
+		).StringMap(),
+		timeout: cmd.Flag(
+			"pushgateway.timeout",
+			"Timeout of the collection and of the push request.",
+		).Default("30s").Duration(),
+		auth: push.NewHTTPAuthWithFlags(cmd, "pushgateway"),
+	}
+}
+
+// run builds the enabled collectors, runs a single collection and pushes the metrics to the Pushgateway.
+// The existing metrics of the grouping key are replaced. It returns 1, if the push or any collector failed.
+func (c *pushgatewayCommand) run(ctx context.Context, logger *slog.Logger, flags *flagConfig) int {
+	httpClient, err := push.NewHTTPClient("pushgateway", *c.auth, *c.timeout)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to configure the Pushgateway client",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	metricFamilies, err := gatherOnce(ctx, logger, flags, *c.timeout)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to collect metrics",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	pusher := pushgateway.New(*c.url, *c.job).
+		Gatherer(prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+			return metricFamilies, nil
+		})).
+		Client(httpClient)
+
+	for _, name := range slices.Sorted(maps.Keys(*c.grouping)) {
+		pusher = pusher.Grouping(name, (*c.grouping)[name])
+	}
+
+	pushCtx, cancel := context.WithTimeout(ctx, *c.timeout)
+	defer cancel()
+
+	if err = pusher.PushContext(pushCtx); err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "failed to push metrics to the Pushgateway",
+			slog.Any("err", err),
+		)
+
+		return 1
+	}
+
+	logger.LogAttrs(ctx, slog.LevelInfo, "pushed metrics to the Pushgateway",
+		slog.String("url", *c.url),
+		slog.Int("metric_families", len(metricFamilies)),
+	)
+
+	if failed := failedCollectors(flags.collectors.Status()); len(failed) > 0 {
+		logger.LogAttrs(ctx, slog.LevelError, "collectors failed",
+			slog.Any("collectors", failed),
+		)
+
+		return 1
+	}
+
+	return 0
+}
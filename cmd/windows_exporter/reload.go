@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/config"
+	"github.com/prometheus-community/windows_exporter/internal/httphandler"
+)
+
+// reloadConfig parses the configuration again and builds a new collection next to the current one.
+// If the build succeeds, the collection of the metrics handler is replaced and the previous one is closed.
+// Flags that affect the process or the web server, e.g. web.listen-address, require a restart.
+// The configuration URL is not fetched, the last fetched configuration is used, see [fetchBeforeReload].
+func reloadConfig(ctx context.Context, logger *slog.Logger, args []string, remoteConfig *config.Remote, metricsHandler *httphandler.MetricsHTTPHandler) error {
+	app, flags := newApp()
+
+	_, resolved, err := config.Parse(app, args, remoteConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	flags.resolved = resolved
+
+	handlerOptions, err := flags.handlerOptions()
+	if err != nil {
+		return fmt.Errorf("failed to parse metric relabel configs: %w", err)
+	}
+
+	if _, err = flags.enableCollectors(); err != nil {
+		return fmt.Errorf("couldn't enable collectors: %w", err)
+	}
+
+	if err = flags.collectors.Build(ctx, logger); err != nil {
+		if closeErr := flags.collectors.Close(); closeErr != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "failed to close collectors of the failed reload",
+				slog.Any("err", closeErr),
+			)
+		}
+
+		return fmt.Errorf("couldn't initialize collectors: %w", err)
+	}
+
+	previous := metricsHandler.Reload(flags.collectors, handlerOptions)
+
+	if err = previous.Close(); err != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "failed to close previous collectors",
+			slog.Any("err", err),
+		)
+	}
+
+	return nil
+}
+
+// fetchBeforeReload returns the handler of POST /-/reload. It fetches the configuration URL, if any, before
+// the reload. The watcher of the URL fetches the configuration itself before it triggers a reload.
+func fetchBeforeReload(logger *slog.Logger, remoteConfig *config.Remote, reloadHandler *httphandler.ReloadHandler) http.Handler {
+	if remoteConfig == nil {
+		return reloadHandler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := remoteConfig.Fetch(r.Context()); err != nil {
+			logger.LogAttrs(r.Context(), slog.LevelWarn, "failed to fetch configuration, using the last good configuration",
+				slog.Any("err", err),
+			)
+		}
+
+		reloadHandler.ServeHTTP(w, r)
+	})
+}
+
+// watchConfigFiles reloads the configuration, if the modification time or the size of the configuration file
+// or of a file in the configuration directory changes, or if a file is added to or removed from the directory.
+func watchConfigFiles(ctx context.Context, logger *slog.Logger, configFile, configDir string, interval time.Duration, reloadHandler *httphandler.ReloadHandler) {
+	lastStats, lastErr := statConfigFiles(configFile, configDir)
+	if lastErr != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "failed to stat configuration files",
+			slog.Any("err", lastErr),
+		)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats, err := statConfigFiles(configFile, configDir)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "failed to stat configuration files",
+				slog.Any("err", err),
+			)
+
+			continue
+		}
+
+		if lastErr == nil && slices.EqualFunc(stats, lastStats, fileStat.equal) {
+			continue
+		}
+
+		lastStats, lastErr = stats, nil
+
+		logger.LogAttrs(ctx, slog.LevelInfo, "configuration files changed, reloading configuration")
+
+		// Errors are logged and exposed by the reload handler.
+		_ = reloadHandler.Reload(ctx)
+	}
+}
+
+// fileStat identifies the version of a configuration file.
+type fileStat struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+func (s fileStat) equal(other fileStat) bool {
+	return s.path == other.path && s.modTime.Equal(other.modTime) && s.size == other.size
+}
+
+// statConfigFiles returns the stats of the configuration file and the YAML files of the configuration directory.
+func statConfigFiles(configFile, configDir string) ([]fileStat, error) {
+	var stats []fileStat
+
+	if configFile != "" {
+		stat, err := os.Stat(configFile)
+		if err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, fileStat{path: configFile, modTime: stat.ModTime(), size: stat.Size()})
+	}
+
+	if configDir == "" {
+		return stats, nil
+	}
+
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if ext := strings.ToLower(filepath.Ext(entry.Name())); entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, fileStat{path: filepath.Join(configDir, entry.Name()), modTime: info.ModTime(), size: info.Size()})
+	}
+
+	return stats, nil
+}
+
+// watchConfigURL fetches the configuration URL in the given interval and reloads the configuration, if it changed.
+// If the fetch fails, the last good configuration is kept.
+func watchConfigURL(ctx context.Context, logger *slog.Logger, remoteConfig *config.Remote, interval time.Duration, reloadHandler *httphandler.ReloadHandler) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := remoteConfig.Fetch(ctx)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "failed to fetch configuration, keeping the last good configuration",
+				slog.Any("err", err),
+			)
+		}
+
+		if !changed {
+			continue
+		}
+
+		logger.LogAttrs(ctx, slog.LevelInfo, "configuration URL changed, reloading configuration")
+
+		// Errors are logged and exposed by the reload handler.
+		_ = reloadHandler.Reload(ctx)
+	}
+}
@@ -37,6 +37,8 @@ type Config struct{}
 var ConfigDefaults = Config{}
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -133,374 +135,374 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.addressBookOperationsTotal = prometheus.NewDesc(
+	c.addressBookOperationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "address_book_operations_total"),
 		"",
 		[]string{"operation"},
 		nil,
 	)
-	c.addressBookClientSessions = prometheus.NewDesc(
+	c.addressBookClientSessions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "address_book_client_sessions"),
 		"",
 		nil,
 		nil,
 	)
-	c.approximateHighestDistinguishedNameTag = prometheus.NewDesc(
+	c.approximateHighestDistinguishedNameTag = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "approximate_highest_distinguished_name_tag"),
 		"",
 		nil,
 		nil,
 	)
-	c.atqEstimatedDelaySeconds = prometheus.NewDesc(
+	c.atqEstimatedDelaySeconds = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "atq_estimated_delay_seconds"),
 		"",
 		nil,
 		nil,
 	)
-	c.atqOutstandingRequests = prometheus.NewDesc(
+	c.atqOutstandingRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "atq_outstanding_requests"),
 		"",
 		nil,
 		nil,
 	)
-	c.atqAverageRequestLatency = prometheus.NewDesc(
+	c.atqAverageRequestLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "atq_average_request_latency"),
 		"",
 		nil,
 		nil,
 	)
-	c.atqCurrentThreads = prometheus.NewDesc(
+	c.atqCurrentThreads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "atq_current_threads"),
 		"",
 		[]string{"service"},
 		nil,
 	)
-	c.searchesTotal = prometheus.NewDesc(
+	c.searchesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "searches_total"),
 		"",
 		[]string{"scope"},
 		nil,
 	)
-	c.databaseOperationsTotal = prometheus.NewDesc(
+	c.databaseOperationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "database_operations_total"),
 		"",
 		[]string{"operation"},
 		nil,
 	)
-	c.bindsTotal = prometheus.NewDesc(
+	c.bindsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "binds_total"),
 		"",
 		[]string{"bind_method"},
 		nil,
 	)
-	c.replicationHighestUsn = prometheus.NewDesc(
+	c.replicationHighestUsn = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_highest_usn"),
 		"",
 		[]string{"state"},
 		nil,
 	)
-	c.intraSiteReplicationDataBytesTotal = prometheus.NewDesc(
+	c.intraSiteReplicationDataBytesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_data_intrasite_bytes_total"),
 		"",
 		[]string{"direction"},
 		nil,
 	)
-	c.interSiteReplicationDataBytesTotal = prometheus.NewDesc(
+	c.interSiteReplicationDataBytesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_data_intersite_bytes_total"),
 		"",
 		[]string{"direction"},
 		nil,
 	)
-	c.replicationInboundSyncObjectsRemaining = prometheus.NewDesc(
+	c.replicationInboundSyncObjectsRemaining = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_sync_objects_remaining"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationInboundLinkValueUpdatesRemaining = prometheus.NewDesc(
+	c.replicationInboundLinkValueUpdatesRemaining = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_link_value_updates_remaining"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationInboundObjectsUpdatedTotal = prometheus.NewDesc(
+	c.replicationInboundObjectsUpdatedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_objects_updated_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationInboundObjectsFilteredTotal = prometheus.NewDesc(
+	c.replicationInboundObjectsFilteredTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_objects_filtered_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationInboundPropertiesUpdatedTotal = prometheus.NewDesc(
+	c.replicationInboundPropertiesUpdatedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_properties_updated_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationInboundPropertiesFilteredTotal = prometheus.NewDesc(
+	c.replicationInboundPropertiesFilteredTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_inbound_properties_filtered_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationPendingOperations = prometheus.NewDesc(
+	c.replicationPendingOperations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_pending_operations"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationPendingSynchronizations = prometheus.NewDesc(
+	c.replicationPendingSynchronizations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_pending_synchronizations"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationSyncRequestsTotal = prometheus.NewDesc(
+	c.replicationSyncRequestsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_sync_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationSyncRequestsSuccessTotal = prometheus.NewDesc(
+	c.replicationSyncRequestsSuccessTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_sync_requests_success_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.replicationSyncRequestsSchemaMismatchFailureTotal = prometheus.NewDesc(
+	c.replicationSyncRequestsSchemaMismatchFailureTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "replication_sync_requests_schema_mismatch_failure_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.nameTranslationsTotal = prometheus.NewDesc(
+	c.nameTranslationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "name_translations_total"),
 		"",
 		[]string{"target_name"},
 		nil,
 	)
-	c.changeMonitorsRegistered = prometheus.NewDesc(
+	c.changeMonitorsRegistered = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "change_monitors_registered"),
 		"",
 		nil,
 		nil,
 	)
-	c.changeMonitorUpdatesPending = prometheus.NewDesc(
+	c.changeMonitorUpdatesPending = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "change_monitor_updates_pending"),
 		"",
 		nil,
 		nil,
 	)
-	c.nameCacheHitsTotal = prometheus.NewDesc(
+	c.nameCacheHitsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "name_cache_hits_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.nameCacheLookupsTotal = prometheus.NewDesc(
+	c.nameCacheLookupsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "name_cache_lookups_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.directoryOperationsTotal = prometheus.NewDesc(
+	c.directoryOperationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "directory_operations_total"),
 		"",
 		[]string{"operation", "origin"},
 		nil,
 	)
-	c.directorySearchSubOperationsTotal = prometheus.NewDesc(
+	c.directorySearchSubOperationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "directory_search_suboperations_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.securityDescriptorPropagationEventsTotal = prometheus.NewDesc(
+	c.securityDescriptorPropagationEventsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "security_descriptor_propagation_events_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.securityDescriptorPropagationEventsQueued = prometheus.NewDesc(
+	c.securityDescriptorPropagationEventsQueued = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "security_descriptor_propagation_events_queued"),
 		"",
 		nil,
 		nil,
 	)
-	c.securityDescriptorPropagationAccessWaitTotalSeconds = prometheus.NewDesc(
+	c.securityDescriptorPropagationAccessWaitTotalSeconds = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "security_descriptor_propagation_access_wait_total_seconds"),
 		"",
 		nil,
 		nil,
 	)
-	c.securityDescriptorPropagationItemsQueuedTotal = prometheus.NewDesc(
+	c.securityDescriptorPropagationItemsQueuedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "security_descriptor_propagation_items_queued_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.directoryServiceThreads = prometheus.NewDesc(
+	c.directoryServiceThreads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "directory_service_threads"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapClosedConnectionsTotal = prometheus.NewDesc(
+	c.ldapClosedConnectionsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_closed_connections_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapOpenedConnectionsTotal = prometheus.NewDesc(
+	c.ldapOpenedConnectionsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_opened_connections_total"),
 		"",
 		[]string{"type"},
 		nil,
 	)
-	c.ldapActiveThreads = prometheus.NewDesc(
+	c.ldapActiveThreads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_active_threads"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapLastBindTimeSeconds = prometheus.NewDesc(
+	c.ldapLastBindTimeSeconds = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_last_bind_time_seconds"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapSearchesTotal = prometheus.NewDesc(
+	c.ldapSearchesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_searches_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapUdpOperationsTotal = prometheus.NewDesc(
+	c.ldapUdpOperationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_udp_operations_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapWritesTotal = prometheus.NewDesc(
+	c.ldapWritesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_writes_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.ldapClientSessions = prometheus.NewDesc(
+	c.ldapClientSessions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_client_sessions"),
 		"This is the number of sessions opened by LDAP clients at the time the data is taken. This is helpful in determining LDAP client activity and if the DC is able to handle the load. Of course, spikes during normal periods of authentication — such as first thing in the morning — are not necessarily a problem, but long sustained periods of high values indicate an overworked DC.",
 		nil,
 		nil,
 	)
-	c.linkValuesCleanedTotal = prometheus.NewDesc(
+	c.linkValuesCleanedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "link_values_cleaned_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.phantomObjectsCleanedTotal = prometheus.NewDesc(
+	c.phantomObjectsCleanedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "phantom_objects_cleaned_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.phantomObjectsVisitedTotal = prometheus.NewDesc(
+	c.phantomObjectsVisitedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "phantom_objects_visited_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samGroupMembershipEvaluationsTotal = prometheus.NewDesc(
+	c.samGroupMembershipEvaluationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_membership_evaluations_total"),
 		"",
 		[]string{"group_type"},
 		nil,
 	)
-	c.samGroupMembershipGlobalCatalogEvaluationsTotal = prometheus.NewDesc(
+	c.samGroupMembershipGlobalCatalogEvaluationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_membership_global_catalog_evaluations_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samGroupMembershipEvaluationsNonTransitiveTotal = prometheus.NewDesc(
+	c.samGroupMembershipEvaluationsNonTransitiveTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_membership_evaluations_nontransitive_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samGroupMembershipEvaluationsTransitiveTotal = prometheus.NewDesc(
+	c.samGroupMembershipEvaluationsTransitiveTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_membership_evaluations_transitive_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samGroupEvaluationLatency = prometheus.NewDesc(
+	c.samGroupEvaluationLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_evaluation_latency"),
 		"The mean latency of the last 100 group evaluations performed for authentication",
 		[]string{"evaluation_type"},
 		nil,
 	)
-	c.samComputerCreationRequestsTotal = prometheus.NewDesc(
+	c.samComputerCreationRequestsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_computer_creation_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samComputerCreationSuccessfulRequestsTotal = prometheus.NewDesc(
+	c.samComputerCreationSuccessfulRequestsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_computer_creation_successful_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samUserCreationRequestsTotal = prometheus.NewDesc(
+	c.samUserCreationRequestsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_user_creation_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samUserCreationSuccessfulRequestsTotal = prometheus.NewDesc(
+	c.samUserCreationSuccessfulRequestsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_user_creation_successful_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samQueryDisplayRequestsTotal = prometheus.NewDesc(
+	c.samQueryDisplayRequestsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_query_display_requests_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samEnumerationsTotal = prometheus.NewDesc(
+	c.samEnumerationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_enumerations_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samMembershipChangesTotal = prometheus.NewDesc(
+	c.samMembershipChangesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_membership_changes_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.samPasswordChangesTotal = prometheus.NewDesc(
+	c.samPasswordChangesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_password_changes_total"),
 		"",
 		nil,
 		nil,
 	)
 
-	c.tombstonesObjectsCollectedTotal = prometheus.NewDesc(
+	c.tombstonesObjectsCollectedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "tombstoned_objects_collected_total"),
 		"",
 		nil,
 		nil,
 	)
-	c.tombstonesObjectsVisitedTotal = prometheus.NewDesc(
+	c.tombstonesObjectsVisitedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "tombstoned_objects_visited_total"),
 		"",
 		nil,
@@ -38,6 +38,8 @@ type Config struct{}
 var ConfigDefaults = Config{}
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -85,79 +87,79 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.requestsPerSecond = prometheus.NewDesc(
+	c.requestsPerSecond = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "requests_total"),
 		"Total certificate requests processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.requestProcessingTime = prometheus.NewDesc(
+	c.requestProcessingTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "request_processing_time_seconds"),
 		"Last time elapsed for certificate requests",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.retrievalsPerSecond = prometheus.NewDesc(
+	c.retrievalsPerSecond = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "retrievals_total"),
 		"Total certificate retrieval requests processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.retrievalProcessingTime = prometheus.NewDesc(
+	c.retrievalProcessingTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "retrievals_processing_time_seconds"),
 		"Last time elapsed for certificate retrieval request",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.failedRequestsPerSecond = prometheus.NewDesc(
+	c.failedRequestsPerSecond = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "failed_requests_total"),
 		"Total failed certificate requests processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.issuedRequestsPerSecond = prometheus.NewDesc(
+	c.issuedRequestsPerSecond = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "issued_requests_total"),
 		"Total issued certificate requests processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.pendingRequestsPerSecond = prometheus.NewDesc(
+	c.pendingRequestsPerSecond = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pending_requests_total"),
 		"Total pending certificate requests processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.requestCryptographicSigningTime = prometheus.NewDesc(
+	c.requestCryptographicSigningTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "request_cryptographic_signing_time_seconds"),
 		"Last time elapsed for signing operation request",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.requestPolicyModuleProcessingTime = prometheus.NewDesc(
+	c.requestPolicyModuleProcessingTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "request_policy_module_processing_time_seconds"),
 		"Last time elapsed for policy module processing request",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.challengeResponsesPerSecond = prometheus.NewDesc(
+	c.challengeResponsesPerSecond = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "challenge_responses_total"),
 		"Total certificate challenge responses processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.challengeResponseProcessingTime = prometheus.NewDesc(
+	c.challengeResponseProcessingTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "challenge_response_processing_time_seconds"),
 		"Last time elapsed for challenge response",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.signedCertificateTimestampListsPerSecond = prometheus.NewDesc(
+	c.signedCertificateTimestampListsPerSecond = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "signed_certificate_timestamp_lists_total"),
 		"Total Signed Certificate Timestamp Lists processed",
 		[]string{"cert_template"},
 		nil,
 	)
-	c.signedCertificateTimestampListProcessingTime = prometheus.NewDesc(
+	c.signedCertificateTimestampListProcessingTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "signed_certificate_timestamp_list_processing_time_seconds"),
 		"Last time elapsed for Signed Certificate Timestamp List",
 		[]string{"cert_template"},
@@ -38,6 +38,8 @@ type Config struct{}
 var ConfigDefaults = Config{}
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -115,259 +117,259 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.adLoginConnectionFailures = prometheus.NewDesc(
+	c.adLoginConnectionFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ad_login_connection_failures_total"),
 		"Total number of connection failures to an Active Directory domain controller",
 		nil,
 		nil,
 	)
-	c.certificateAuthentications = prometheus.NewDesc(
+	c.certificateAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "certificate_authentications_total"),
 		"Total number of User Certificate authentications",
 		nil,
 		nil,
 	)
-	c.deviceAuthentications = prometheus.NewDesc(
+	c.deviceAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "device_authentications_total"),
 		"Total number of Device authentications",
 		nil,
 		nil,
 	)
-	c.extranetAccountLockouts = prometheus.NewDesc(
+	c.extranetAccountLockouts = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "extranet_account_lockouts_total"),
 		"Total number of Extranet Account Lockouts",
 		nil,
 		nil,
 	)
-	c.federatedAuthentications = prometheus.NewDesc(
+	c.federatedAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "federated_authentications_total"),
 		"Total number of authentications from a federated source",
 		nil,
 		nil,
 	)
-	c.passportAuthentications = prometheus.NewDesc(
+	c.passportAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "passport_authentications_total"),
 		"Total number of Microsoft Passport SSO authentications",
 		nil,
 		nil,
 	)
-	c.passiveRequests = prometheus.NewDesc(
+	c.passiveRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "passive_requests_total"),
 		"Total number of passive (browser-based) requests",
 		nil,
 		nil,
 	)
-	c.passwordChangeFailed = prometheus.NewDesc(
+	c.passwordChangeFailed = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "password_change_failed_total"),
 		"Total number of failed password changes",
 		nil,
 		nil,
 	)
-	c.passwordChangeSucceeded = prometheus.NewDesc(
+	c.passwordChangeSucceeded = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "password_change_succeeded_total"),
 		"Total number of successful password changes",
 		nil,
 		nil,
 	)
-	c.tokenRequests = prometheus.NewDesc(
+	c.tokenRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "token_requests_total"),
 		"Total number of token requests",
 		nil,
 		nil,
 	)
-	c.windowsIntegratedAuthentications = prometheus.NewDesc(
+	c.windowsIntegratedAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "windows_integrated_authentications_total"),
 		"Total number of Windows integrated authentications (Kerberos/NTLM)",
 		nil,
 		nil,
 	)
-	c.oAuthAuthZRequests = prometheus.NewDesc(
+	c.oAuthAuthZRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_authorization_requests_total"),
 		"Total number of incoming requests to the OAuth Authorization endpoint",
 		nil,
 		nil,
 	)
-	c.oAuthClientAuthentications = prometheus.NewDesc(
+	c.oAuthClientAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_authentication_success_total"),
 		"Total number of successful OAuth client Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientAuthenticationsFailures = prometheus.NewDesc(
+	c.oAuthClientAuthenticationsFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_authentication_failure_total"),
 		"Total number of failed OAuth client Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientCredentialsRequestFailures = prometheus.NewDesc(
+	c.oAuthClientCredentialsRequestFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_credentials_failure_total"),
 		"Total number of failed OAuth Client Credentials Requests",
 		nil,
 		nil,
 	)
-	c.oAuthClientCredentialsRequests = prometheus.NewDesc(
+	c.oAuthClientCredentialsRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_credentials_success_total"),
 		"Total number of successful RP tokens issued for OAuth Client Credentials Requests",
 		nil,
 		nil,
 	)
-	c.oAuthClientPrivateKeyJwtAuthenticationFailures = prometheus.NewDesc(
+	c.oAuthClientPrivateKeyJwtAuthenticationFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_privkey_jwt_authentication_failure_total"),
 		"Total number of failed OAuth Client Private Key Jwt Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientPrivateKeyJwtAuthentications = prometheus.NewDesc(
+	c.oAuthClientPrivateKeyJwtAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_privkey_jwt_authentications_success_total"),
 		"Total number of successful OAuth Client Private Key Jwt Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientSecretBasicAuthenticationFailures = prometheus.NewDesc(
+	c.oAuthClientSecretBasicAuthenticationFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_secret_basic_authentications_failure_total"),
 		"Total number of failed OAuth Client Secret Basic Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientSecretBasicAuthentications = prometheus.NewDesc(
+	c.oAuthClientSecretBasicAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_secret_basic_authentications_success_total"),
 		"Total number of successful OAuth Client Secret Basic Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientSecretPostAuthenticationFailures = prometheus.NewDesc(
+	c.oAuthClientSecretPostAuthenticationFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_secret_post_authentications_failure_total"),
 		"Total number of failed OAuth Client Secret Post Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientSecretPostAuthentications = prometheus.NewDesc(
+	c.oAuthClientSecretPostAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_secret_post_authentications_success_total"),
 		"Total number of successful OAuth Client Secret Post Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientWindowsIntegratedAuthenticationFailures = prometheus.NewDesc(
+	c.oAuthClientWindowsIntegratedAuthenticationFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_windows_authentications_failure_total"),
 		"Total number of failed OAuth Client Windows Integrated Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthClientWindowsIntegratedAuthentications = prometheus.NewDesc(
+	c.oAuthClientWindowsIntegratedAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_client_windows_authentications_success_total"),
 		"Total number of successful OAuth Client Windows Integrated Authentications",
 		nil,
 		nil,
 	)
-	c.oAuthLogonCertificateRequestFailures = prometheus.NewDesc(
+	c.oAuthLogonCertificateRequestFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_logon_certificate_requests_failure_total"),
 		"Total number of failed OAuth Logon Certificate Requests",
 		nil,
 		nil,
 	)
-	c.oAuthLogonCertificateTokenRequests = prometheus.NewDesc(
+	c.oAuthLogonCertificateTokenRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_logon_certificate_token_requests_success_total"),
 		"Total number of successful RP tokens issued for OAuth Logon Certificate Requests",
 		nil,
 		nil,
 	)
-	c.oAuthPasswordGrantRequestFailures = prometheus.NewDesc(
+	c.oAuthPasswordGrantRequestFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_password_grant_requests_failure_total"),
 		"Total number of failed OAuth Password Grant Requests",
 		nil,
 		nil,
 	)
-	c.oAuthPasswordGrantRequests = prometheus.NewDesc(
+	c.oAuthPasswordGrantRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_password_grant_requests_success_total"),
 		"Total number of successful OAuth Password Grant Requests",
 		nil,
 		nil,
 	)
-	c.oAuthTokenRequests = prometheus.NewDesc(
+	c.oAuthTokenRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "oauth_token_requests_success_total"),
 		"Total number of successful RP tokens issued over OAuth protocol",
 		nil,
 		nil,
 	)
-	c.samlPTokenRequests = prometheus.NewDesc(
+	c.samlPTokenRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "samlp_token_requests_success_total"),
 		"Total number of successful RP tokens issued over SAML-P protocol",
 		nil,
 		nil,
 	)
-	c.ssoAuthenticationFailures = prometheus.NewDesc(
+	c.ssoAuthenticationFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sso_authentications_failure_total"),
 		"Total number of failed SSO authentications",
 		nil,
 		nil,
 	)
-	c.ssoAuthentications = prometheus.NewDesc(
+	c.ssoAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sso_authentications_success_total"),
 		"Total number of successful SSO authentications",
 		nil,
 		nil,
 	)
-	c.wsFedTokenRequests = prometheus.NewDesc(
+	c.wsFedTokenRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "wsfed_token_requests_success_total"),
 		"Total number of successful RP tokens issued over WS-Fed protocol",
 		nil,
 		nil,
 	)
-	c.wsTrustTokenRequests = prometheus.NewDesc(
+	c.wsTrustTokenRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "wstrust_token_requests_success_total"),
 		"Total number of successful RP tokens issued over WS-Trust protocol",
 		nil,
 		nil,
 	)
-	c.upAuthenticationFailures = prometheus.NewDesc(
+	c.upAuthenticationFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "userpassword_authentications_failure_total"),
 		"Total number of failed AD U/P authentications",
 		nil,
 		nil,
 	)
-	c.upAuthentications = prometheus.NewDesc(
+	c.upAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "userpassword_authentications_success_total"),
 		"Total number of successful AD U/P authentications",
 		nil,
 		nil,
 	)
-	c.externalAuthenticationFailures = prometheus.NewDesc(
+	c.externalAuthenticationFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "external_authentications_failure_total"),
 		"Total number of failed authentications from external MFA providers",
 		nil,
 		nil,
 	)
-	c.externalAuthentications = prometheus.NewDesc(
+	c.externalAuthentications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "external_authentications_success_total"),
 		"Total number of successful authentications from external MFA providers",
 		nil,
 		nil,
 	)
-	c.artifactDBFailures = prometheus.NewDesc(
+	c.artifactDBFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "db_artifact_failure_total"),
 		"Total number of failures connecting to the artifact database",
 		nil,
 		nil,
 	)
-	c.avgArtifactDBQueryTime = prometheus.NewDesc(
+	c.avgArtifactDBQueryTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "db_artifact_query_time_seconds_total"),
 		"Accumulator of time taken for an artifact database query",
 		nil,
 		nil,
 	)
-	c.configDBFailures = prometheus.NewDesc(
+	c.configDBFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "db_config_failure_total"),
 		"Total number of failures connecting to the configuration database",
 		nil,
 		nil,
 	)
-	c.avgConfigDBQueryTime = prometheus.NewDesc(
+	c.avgConfigDBQueryTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "db_config_query_time_seconds_total"),
 		"Accumulator of time taken for a configuration database query",
 		nil,
 		nil,
 	)
-	c.federationMetadataRequests = prometheus.NewDesc(
+	c.federationMetadataRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "federation_metadata_requests_total"),
 		"Total number of Federation Metadata requests",
 		nil,
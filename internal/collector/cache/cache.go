@@ -38,6 +38,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for Perflib Cache metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -101,175 +103,175 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.asyncCopyReadsTotal = prometheus.NewDesc(
+	c.asyncCopyReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "async_copy_reads_total"),
 		"(AsyncCopyReadsTotal)",
 		nil,
 		nil,
 	)
-	c.asyncDataMapsTotal = prometheus.NewDesc(
+	c.asyncDataMapsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "async_data_maps_total"),
 		"(AsyncDataMapsTotal)",
 		nil,
 		nil,
 	)
-	c.asyncFastReadsTotal = prometheus.NewDesc(
+	c.asyncFastReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "async_fast_reads_total"),
 		"(AsyncFastReadsTotal)",
 		nil,
 		nil,
 	)
-	c.asyncMDLReadsTotal = prometheus.NewDesc(
+	c.asyncMDLReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "async_mdl_reads_total"),
 		"(AsyncMDLReadsTotal)",
 		nil,
 		nil,
 	)
-	c.asyncPinReadsTotal = prometheus.NewDesc(
+	c.asyncPinReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "async_pin_reads_total"),
 		"(AsyncPinReadsTotal)",
 		nil,
 		nil,
 	)
-	c.copyReadHitsTotal = prometheus.NewDesc(
+	c.copyReadHitsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "copy_read_hits_total"),
 		"(CopyReadHitsTotal)",
 		nil,
 		nil,
 	)
-	c.copyReadsTotal = prometheus.NewDesc(
+	c.copyReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "copy_reads_total"),
 		"(CopyReadsTotal)",
 		nil,
 		nil,
 	)
-	c.dataFlushesTotal = prometheus.NewDesc(
+	c.dataFlushesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_flushes_total"),
 		"(DataFlushesTotal)",
 		nil,
 		nil,
 	)
-	c.dataFlushPagesTotal = prometheus.NewDesc(
+	c.dataFlushPagesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_flush_pages_total"),
 		"(DataFlushPagesTotal)",
 		nil,
 		nil,
 	)
-	c.dataMapHitsPercent = prometheus.NewDesc(
+	c.dataMapHitsPercent = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_map_hits_percent"),
 		"(DataMapHitsPercent)",
 		nil,
 		nil,
 	)
-	c.dataMapPinsTotal = prometheus.NewDesc(
+	c.dataMapPinsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_map_pins_total"),
 		"(DataMapPinsTotal)",
 		nil,
 		nil,
 	)
-	c.dataMapsTotal = prometheus.NewDesc(
+	c.dataMapsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_maps_total"),
 		"(DataMapsTotal)",
 		nil,
 		nil,
 	)
-	c.dirtyPages = prometheus.NewDesc(
+	c.dirtyPages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dirty_pages"),
 		"(DirtyPages)",
 		nil,
 		nil,
 	)
-	c.dirtyPageThreshold = prometheus.NewDesc(
+	c.dirtyPageThreshold = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dirty_page_threshold"),
 		"(DirtyPageThreshold)",
 		nil,
 		nil,
 	)
-	c.fastReadNotPossiblesTotal = prometheus.NewDesc(
+	c.fastReadNotPossiblesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "fast_read_not_possibles_total"),
 		"(FastReadNotPossiblesTotal)",
 		nil,
 		nil,
 	)
-	c.fastReadResourceMissesTotal = prometheus.NewDesc(
+	c.fastReadResourceMissesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "fast_read_resource_misses_total"),
 		"(FastReadResourceMissesTotal)",
 		nil,
 		nil,
 	)
-	c.fastReadsTotal = prometheus.NewDesc(
+	c.fastReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "fast_reads_total"),
 		"(FastReadsTotal)",
 		nil,
 		nil,
 	)
-	c.lazyWriteFlushesTotal = prometheus.NewDesc(
+	c.lazyWriteFlushesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "lazy_write_flushes_total"),
 		"(LazyWriteFlushesTotal)",
 		nil,
 		nil,
 	)
-	c.lazyWritePagesTotal = prometheus.NewDesc(
+	c.lazyWritePagesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "lazy_write_pages_total"),
 		"(LazyWritePagesTotal)",
 		nil,
 		nil,
 	)
-	c.mdlReadHitsTotal = prometheus.NewDesc(
+	c.mdlReadHitsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mdl_read_hits_total"),
 		"(MDLReadHitsTotal)",
 		nil,
 		nil,
 	)
-	c.mdlReadsTotal = prometheus.NewDesc(
+	c.mdlReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mdl_reads_total"),
 		"(MDLReadsTotal)",
 		nil,
 		nil,
 	)
-	c.pinReadHitsTotal = prometheus.NewDesc(
+	c.pinReadHitsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pin_read_hits_total"),
 		"(PinReadHitsTotal)",
 		nil,
 		nil,
 	)
-	c.pinReadsTotal = prometheus.NewDesc(
+	c.pinReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pin_reads_total"),
 		"(PinReadsTotal)",
 		nil,
 		nil,
 	)
-	c.readAheadsTotal = prometheus.NewDesc(
+	c.readAheadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "read_aheads_total"),
 		"(ReadAheadsTotal)",
 		nil,
 		nil,
 	)
-	c.syncCopyReadsTotal = prometheus.NewDesc(
+	c.syncCopyReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sync_copy_reads_total"),
 		"(SyncCopyReadsTotal)",
 		nil,
 		nil,
 	)
-	c.syncDataMapsTotal = prometheus.NewDesc(
+	c.syncDataMapsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sync_data_maps_total"),
 		"(SyncDataMapsTotal)",
 		nil,
 		nil,
 	)
-	c.syncFastReadsTotal = prometheus.NewDesc(
+	c.syncFastReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sync_fast_reads_total"),
 		"(SyncFastReadsTotal)",
 		nil,
 		nil,
 	)
-	c.syncMDLReadsTotal = prometheus.NewDesc(
+	c.syncMDLReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sync_mdl_reads_total"),
 		"(SyncMDLReadsTotal)",
 		nil,
 		nil,
 	)
-	c.syncPinReadsTotal = prometheus.NewDesc(
+	c.syncPinReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sync_pin_reads_total"),
 		"(SyncPinReadsTotal)",
 		nil,
@@ -65,6 +65,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus Collector for containers metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	logger *slog.Logger
@@ -178,109 +180,109 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	c.annotationsCacheHCS = make(map[string]containerInfo)
 	c.annotationsCacheJob = make(map[string]containerInfo)
 
-	c.containerAvailable = prometheus.NewDesc(
+	c.containerAvailable = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "available"),
 		"Available",
 		[]string{"container_id", "namespace", "pod", "container", "hostprocess"},
 		nil,
 	)
-	c.containersCount = prometheus.NewDesc(
+	c.containersCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "count"),
 		"Number of containers",
 		nil,
 		nil,
 	)
-	c.usageCommitBytes = prometheus.NewDesc(
+	c.usageCommitBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memory_usage_commit_bytes"),
 		"Memory Usage Commit Bytes",
 		[]string{"container_id", "namespace", "pod", "container"},
 		nil,
 	)
-	c.usageCommitPeakBytes = prometheus.NewDesc(
+	c.usageCommitPeakBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memory_usage_commit_peak_bytes"),
 		"Memory Usage Commit Peak Bytes",
 		[]string{"container_id", "namespace", "pod", "container"},
 		nil,
 	)
-	c.usagePrivateWorkingSetBytes = prometheus.NewDesc(
+	c.usagePrivateWorkingSetBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memory_usage_private_working_set_bytes"),
 		"Memory Usage Private Working Set Bytes",
 		[]string{"container_id", "namespace", "pod", "container"},
 		nil,
 	)
-	c.runtimeTotal = prometheus.NewDesc(
+	c.runtimeTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_usage_seconds_total"),
 		"Total Run time in Seconds",
 		[]string{"container_id", "namespace", "pod", "container"},
 		nil,
 	)
-	c.runtimeUser = prometheus.NewDesc(
+	c.runtimeUser = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_usage_seconds_usermode"),
 		"Run Time in User mode in Seconds",
 		[]string{"container_id", "namespace", "pod", "container"},
 		nil,
 	)
-	c.runtimeKernel = prometheus.NewDesc(
+	c.runtimeKernel = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_usage_seconds_kernelmode"),
 		"Run time in Kernel mode in Seconds",
 		[]string{"container_id", "namespace", "pod", "container"},
 		nil,
 	)
-	c.bytesReceived = prometheus.NewDesc(
+	c.bytesReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "network_receive_bytes_total"),
 		"Bytes Received on Interface",
 		[]string{"container_id", "namespace", "pod", "container", "interface"},
 		nil,
 	)
-	c.bytesSent = prometheus.NewDesc(
+	c.bytesSent = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "network_transmit_bytes_total"),
 		"Bytes Sent on Interface",
 		[]string{"container_id", "namespace", "pod", "container", "interface"},
 		nil,
 	)
-	c.packetsReceived = prometheus.NewDesc(
+	c.packetsReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "network_receive_packets_total"),
 		"Packets Received on Interface",
 		[]string{"container_id", "namespace", "pod", "container", "interface"},
 		nil,
 	)
-	c.packetsSent = prometheus.NewDesc(
+	c.packetsSent = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "network_transmit_packets_total"),
 		"Packets Sent on Interface",
 		[]string{"container_id", "namespace", "pod", "container", "interface"},
 		nil,
 	)
-	c.droppedPacketsIncoming = prometheus.NewDesc(
+	c.droppedPacketsIncoming = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "network_receive_packets_dropped_total"),
 		"Dropped Incoming Packets on Interface",
 		[]string{"container_id", "namespace", "pod", "container", "interface"},
 		nil,
 	)
-	c.droppedPacketsOutgoing = prometheus.NewDesc(
+	c.droppedPacketsOutgoing = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "network_transmit_packets_dropped_total"),
 		"Dropped Outgoing Packets on Interface",
 		[]string{"container_id", "namespace", "pod", "container", "interface"},
 		nil,
 	)
-	c.readCountNormalized = prometheus.NewDesc(
+	c.readCountNormalized = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "storage_read_count_normalized_total"),
 		"Read Count Normalized",
 		[]string{"container_id", "namespace", "pod", "container"},
 		nil,
 	)
-	c.readSizeBytes = prometheus.NewDesc(
+	c.readSizeBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "storage_read_size_bytes_total"),
 		"Read Size Bytes",
 		[]string{"container_id", "namespace", "pod", "container"},
 		nil,
 	)
-	c.writeCountNormalized = prometheus.NewDesc(
+	c.writeCountNormalized = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "storage_write_count_normalized_total"),
 		"Write Count Normalized",
 		[]string{"container_id", "namespace", "pod", "container"},
 		nil,
 	)
-	c.writeSizeBytes = prometheus.NewDesc(
+	c.writeSizeBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "storage_write_size_bytes_total"),
 		"Write Size Bytes",
 		[]string{"container_id", "namespace", "pod", "container"},
@@ -39,6 +39,8 @@ type Config struct{}
 var ConfigDefaults = Config{}
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -94,85 +96,85 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	c.mu = sync.Mutex{}
 
-	c.logicalProcessors = prometheus.NewDesc(
+	c.logicalProcessors = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "logical_processor"),
 		"Total number of logical processors",
 		nil,
 		nil,
 	)
-	c.cStateSecondsTotal = prometheus.NewDesc(
+	c.cStateSecondsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cstate_seconds_total"),
 		"Time spent in low-power idle state",
 		[]string{"core", "state"},
 		nil,
 	)
-	c.timeTotal = prometheus.NewDesc(
+	c.timeTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "time_total"),
 		"Time that processor spent in different modes (dpc, idle, interrupt, privileged, user)",
 		[]string{"core", "mode"},
 		nil,
 	)
-	c.interruptsTotal = prometheus.NewDesc(
+	c.interruptsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "interrupts_total"),
 		"Total number of received and serviced hardware interrupts",
 		[]string{"core"},
 		nil,
 	)
-	c.dpcsTotal = prometheus.NewDesc(
+	c.dpcsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dpcs_total"),
 		"Total number of received and serviced deferred procedure calls (DPCs)",
 		[]string{"core"},
 		nil,
 	)
-	c.clockInterruptsTotal = prometheus.NewDesc(
+	c.clockInterruptsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "clock_interrupts_total"),
 		"Total number of received and serviced clock tick interrupts",
 		[]string{"core"},
 		nil,
 	)
-	c.idleBreakEventsTotal = prometheus.NewDesc(
+	c.idleBreakEventsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "idle_break_events_total"),
 		"Total number of time processor was woken from idle",
 		[]string{"core"},
 		nil,
 	)
-	c.parkingStatus = prometheus.NewDesc(
+	c.parkingStatus = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "parking_status"),
 		"Parking Status represents whether a processor is parked or not",
 		[]string{"core"},
 		nil,
 	)
-	c.processorFrequencyMHz = prometheus.NewDesc(
+	c.processorFrequencyMHz = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "core_frequency_mhz"),
 		"Core frequency in megahertz",
 		[]string{"core"},
 		nil,
 	)
-	c.processorPerformance = prometheus.NewDesc(
+	c.processorPerformance = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processor_performance_total"),
 		"Processor Performance is the average performance of the processor while it is executing instructions, as a percentage of the nominal performance of the processor. On some processors, Processor Performance may exceed 100%",
 		[]string{"core"},
 		nil,
 	)
-	c.processorMPerf = prometheus.NewDesc(
+	c.processorMPerf = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processor_mperf_total"),
 		"Processor MPerf is the number of TSC ticks incremented while executing instructions",
 		[]string{"core"},
 		nil,
 	)
-	c.processorRTC = prometheus.NewDesc(
+	c.processorRTC = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processor_rtc_total"),
 		"Processor RTC represents the number of RTC ticks made since the system booted. It should consistently be 64e6, and can be used to properly derive Processor Utility Rate",
 		[]string{"core"},
 		nil,
 	)
-	c.processorUtility = prometheus.NewDesc(
+	c.processorUtility = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processor_utility_total"),
 		"Processor Utility represents is the amount of time the core spends executing instructions",
 		[]string{"core"},
 		nil,
 	)
-	c.processorPrivilegedUtility = prometheus.NewDesc(
+	c.processorPrivilegedUtility = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processor_privileged_utility_total"),
 		"Processor Privileged Utility represents is the amount of time the core has spent executing instructions inside the kernel",
 		[]string{"core"},
@@ -18,6 +18,7 @@
 package cpu_info
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -28,6 +29,7 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -40,6 +42,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for a few WMI metrics in Win32_Processor.
 type Collector struct {
+	types.DescRecorder
+
 	config    Config
 	miSession *mi.Session
 	miQuery   mi.Query
@@ -78,7 +82,7 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
-	c.cpuInfo = prometheus.NewDesc(
+	c.cpuInfo = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, "", Name),
 		"Labelled CPU information as provided by Win32_Processor",
 		[]string{
@@ -90,7 +94,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuThreadCount = prometheus.NewDesc(
+	c.cpuThreadCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "thread"),
 		"Number of threads per CPU",
 		[]string{
@@ -98,7 +102,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuCoreCount = prometheus.NewDesc(
+	c.cpuCoreCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "core"),
 		"Number of cores per CPU",
 		[]string{
@@ -106,7 +110,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuEnabledCoreCount = prometheus.NewDesc(
+	c.cpuEnabledCoreCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "enabled_core"),
 		"Number of enabled cores per CPU",
 		[]string{
@@ -114,7 +118,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuLogicalProcessorsCount = prometheus.NewDesc(
+	c.cpuLogicalProcessorsCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "logical_processor"),
 		"Number of logical processors per CPU",
 		[]string{
@@ -122,7 +126,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuL2CacheSize = prometheus.NewDesc(
+	c.cpuL2CacheSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "l2_cache_size"),
 		"Size of L2 cache per CPU",
 		[]string{
@@ -130,7 +134,7 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.cpuL3CacheSize = prometheus.NewDesc(
+	c.cpuL3CacheSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "l3_cache_size"),
 		"Size of L3 cache per CPU",
 		[]string{
@@ -178,8 +182,16 @@ type miProcessor struct {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.CollectWithContext(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but cancels the WMI query once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []miProcessor
-	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.miQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootCIMv2, c.miQuery); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
@@ -45,6 +45,8 @@ var ConfigDefaults = Config{
 
 // Collector contains the metric and state data of the DFSR collectors.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollectorConnection *pdh.Collector
@@ -164,63 +166,63 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	logger.Info("dfsr collector is in an experimental state! Metrics for this collector have not been tested.")
 
 	// connection
-	c.connectionBandwidthSavingsUsingDFSReplicationTotal = prometheus.NewDesc(
+	c.connectionBandwidthSavingsUsingDFSReplicationTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_bandwidth_savings_using_dfs_replication_bytes_total"),
 		"Total bytes of bandwidth saved using DFS Replication for this connection",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionBytesReceivedTotal = prometheus.NewDesc(
+	c.connectionBytesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_bytes_received_total"),
 		"Total bytes received for connection",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionCompressedSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionCompressedSizeOfFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_compressed_size_of_files_received_bytes_total"),
 		"Total compressed size of files received on the connection, in bytes",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_received_files_total"),
 		"Total number of files received for connection",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionRDCBytesReceivedTotal = prometheus.NewDesc(
+	c.connectionRDCBytesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_rdc_received_bytes_total"),
 		"Total bytes received on the connection while replicating files using Remote Differential Compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionRDCCompressedSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionRDCCompressedSizeOfFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_rdc_compressed_size_of_received_files_bytes_total"),
 		"Total uncompressed size of files received with Remote Differential Compression for connection",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionRDCNumberOfFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionRDCNumberOfFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_rdc_received_files_total"),
 		"Total number of files received using remote differential compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionRDCSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionRDCSizeOfFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_rdc_size_of_received_files_bytes_total"),
 		"Total size of received Remote Differential Compression files, in bytes.",
 		[]string{"name"},
 		nil,
 	)
 
-	c.connectionSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.connectionSizeOfFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_files_received_bytes_total"),
 		"Total size of files received, in bytes",
 		[]string{"name"},
@@ -228,189 +230,189 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	)
 
 	// folder
-	c.folderBandwidthSavingsUsingDFSReplicationTotal = prometheus.NewDesc(
+	c.folderBandwidthSavingsUsingDFSReplicationTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_bandwidth_savings_using_dfs_replication_bytes_total"),
 		"Total bytes of bandwidth saved using DFS Replication for this folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderCompressedSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.folderCompressedSizeOfFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_compressed_size_of_received_files_bytes_total"),
 		"Total compressed size of files received on the folder, in bytes",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictBytesCleanedUpTotal = prometheus.NewDesc(
+	c.folderConflictBytesCleanedUpTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_cleaned_up_bytes_total"),
 		"Total size of conflict loser files and folders deleted from the Conflict and Deleted folder, in bytes",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictBytesGeneratedTotal = prometheus.NewDesc(
+	c.folderConflictBytesGeneratedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_generated_bytes_total"),
 		"Total size of conflict loser files and folders moved to the Conflict and Deleted folder, in bytes",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictFilesCleanedUpTotal = prometheus.NewDesc(
+	c.folderConflictFilesCleanedUpTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_cleaned_up_files_total"),
 		"Number of conflict loser files deleted from the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictFilesGeneratedTotal = prometheus.NewDesc(
+	c.folderConflictFilesGeneratedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_generated_files_total"),
 		"Number of files and folders moved to the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictFolderCleanupsCompletedTotal = prometheus.NewDesc(
+	c.folderConflictFolderCleanupsCompletedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_folder_cleanups_total"),
 		"Number of deletions of conflict loser files and folders in the Conflict and Deleted",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderConflictSpaceInUse = prometheus.NewDesc(
+	c.folderConflictSpaceInUse = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_conflict_space_in_use_bytes"),
 		"Total size of the conflict loser files and folders currently in the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderDeletedSpaceInUse = prometheus.NewDesc(
+	c.folderDeletedSpaceInUse = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_deleted_space_in_use_bytes"),
 		"Total size (in bytes) of the deleted files and folders currently in the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderDeletedBytesCleanedUpTotal = prometheus.NewDesc(
+	c.folderDeletedBytesCleanedUpTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_deleted_cleaned_up_bytes_total"),
 		"Total size (in bytes) of replicating deleted files and folders that were cleaned up from the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderDeletedBytesGeneratedTotal = prometheus.NewDesc(
+	c.folderDeletedBytesGeneratedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_deleted_generated_bytes_total"),
 		"Total size (in bytes) of replicated deleted files and folders that were moved to the Conflict and Deleted folder after they were deleted from a replicated folder on a sending member",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderDeletedFilesCleanedUpTotal = prometheus.NewDesc(
+	c.folderDeletedFilesCleanedUpTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_deleted_cleaned_up_files_total"),
 		"Number of files and folders that were cleaned up from the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderDeletedFilesGeneratedTotal = prometheus.NewDesc(
+	c.folderDeletedFilesGeneratedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_deleted_generated_files_total"),
 		"Number of deleted files and folders that were moved to the Conflict and Deleted folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderFileInstallsRetriedTotal = prometheus.NewDesc(
+	c.folderFileInstallsRetriedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_file_installs_retried_total"),
 		"Total number of file installs that are being retried due to sharing violations or other errors encountered when installing the files",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderFileInstallsSucceededTotal = prometheus.NewDesc(
+	c.folderFileInstallsSucceededTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_file_installs_succeeded_total"),
 		"Total number of files that were successfully received from sending members and installed locally on this server",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderFilesReceivedTotal = prometheus.NewDesc(
+	c.folderFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_received_files_total"),
 		"Total number of files received",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderRDCBytesReceivedTotal = prometheus.NewDesc(
+	c.folderRDCBytesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_rdc_received_bytes_total"),
 		"Total number of bytes received in replicating files using Remote Differential Compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderRDCCompressedSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.folderRDCCompressedSizeOfFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_rdc_compressed_size_of_received_files_bytes_total"),
 		"Total compressed size (in bytes) of the files received with Remote Differential Compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderRDCNumberOfFilesReceivedTotal = prometheus.NewDesc(
+	c.folderRDCNumberOfFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_rdc_received_files_total"),
 		"Total number of files received with Remote Differential Compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderRDCSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.folderRDCSizeOfFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_rdc_files_received_bytes_total"),
 		"Total uncompressed size (in bytes) of the files received with Remote Differential Compression",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderSizeOfFilesReceivedTotal = prometheus.NewDesc(
+	c.folderSizeOfFilesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_files_received_bytes_total"),
 		"Total uncompressed size (in bytes) of the files received",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderStagingSpaceInUse = prometheus.NewDesc(
+	c.folderStagingSpaceInUse = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_staging_space_in_use_bytes"),
 		"Total size of files and folders currently in the staging folder.",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderStagingBytesCleanedUpTotal = prometheus.NewDesc(
+	c.folderStagingBytesCleanedUpTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_staging_cleaned_up_bytes_total"),
 		"Total size (in bytes) of the files and folders that have been cleaned up from the staging folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderStagingBytesGeneratedTotal = prometheus.NewDesc(
+	c.folderStagingBytesGeneratedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_staging_generated_bytes_total"),
 		"Total size (in bytes) of replicated files and folders in the staging folder created by the DFS Replication service since last restart",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderStagingFilesCleanedUpTotal = prometheus.NewDesc(
+	c.folderStagingFilesCleanedUpTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_staging_cleaned_up_files_total"),
 		"Total number of files and folders that have been cleaned up from the staging folder",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderStagingFilesGeneratedTotal = prometheus.NewDesc(
+	c.folderStagingFilesGeneratedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_staging_generated_files_total"),
 		"Total number of times replicated files and folders have been staged by the DFS Replication service",
 		[]string{"name"},
 		nil,
 	)
 
-	c.folderUpdatesDroppedTotal = prometheus.NewDesc(
+	c.folderUpdatesDroppedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "folder_dropped_updates_total"),
 		"Total number of redundant file replication update records that have been ignored by the DFS Replication service because they did not change the replicated file or folder",
 		[]string{"name"},
@@ -418,35 +420,35 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	)
 
 	// volume
-	c.volumeDatabaseCommitsTotal = prometheus.NewDesc(
+	c.volumeDatabaseCommitsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "volume_database_commits_total"),
 		"Total number of DFSR volume database commits",
 		[]string{"name"},
 		nil,
 	)
 
-	c.volumeDatabaseLookupsTotal = prometheus.NewDesc(
+	c.volumeDatabaseLookupsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "volume_database_lookups_total"),
 		"Total number of DFSR volume database lookups",
 		[]string{"name"},
 		nil,
 	)
 
-	c.volumeUSNJournalUnreadPercentage = prometheus.NewDesc(
+	c.volumeUSNJournalUnreadPercentage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "volume_usn_journal_unread_percentage"),
 		"Percentage of DFSR volume USN journal records that are unread",
 		[]string{"name"},
 		nil,
 	)
 
-	c.volumeUSNJournalRecordsAcceptedTotal = prometheus.NewDesc(
+	c.volumeUSNJournalRecordsAcceptedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "volume_usn_journal_accepted_records_total"),
 		"Total number of USN journal records accepted",
 		[]string{"name"},
 		nil,
 	)
 
-	c.volumeUSNJournalRecordsReadTotal = prometheus.NewDesc(
+	c.volumeUSNJournalRecordsReadTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "volume_usn_journal_read_records_total"),
 		"Total number of DFSR volume USN journal records read",
 		[]string{"name"},
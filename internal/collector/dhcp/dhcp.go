@@ -55,6 +55,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus Collector perflib DHCP metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	logger *slog.Logger
@@ -156,70 +158,70 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	var err error
 
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorScopeMetrics) {
-		c.scopeInfo = prometheus.NewDesc(
+		c.scopeInfo = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "scope_info"),
 			"DHCP Scope information",
 			[]string{"name", "superscope_name", "superscope_id", "scope"},
 			nil,
 		)
 
-		c.scopeState = prometheus.NewDesc(
+		c.scopeState = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "scope_state"),
 			"DHCP Scope state",
 			[]string{"scope", "state"},
 			nil,
 		)
 
-		c.scopeAddressesFreeTotal = prometheus.NewDesc(
+		c.scopeAddressesFreeTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_free"),
 			"DHCP Scope free addresses",
 			[]string{"scope"},
 			nil,
 		)
 
-		c.scopeAddressesFreeOnPartnerServerTotal = prometheus.NewDesc(
+		c.scopeAddressesFreeOnPartnerServerTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_free_on_partner_server"),
 			"DHCP Scope free addresses on partner server",
 			[]string{"scope"},
 			nil,
 		)
 
-		c.scopeAddressesFreeOnThisServerTotal = prometheus.NewDesc(
+		c.scopeAddressesFreeOnThisServerTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_free_on_this_server"),
 			"DHCP Scope free addresses on this server",
 			[]string{"scope"},
 			nil,
 		)
 
-		c.scopeAddressesInUseTotal = prometheus.NewDesc(
+		c.scopeAddressesInUseTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_in_use"),
 			"DHCP Scope addresses in use",
 			[]string{"scope"},
 			nil,
 		)
 
-		c.scopeAddressesInUseOnPartnerServerTotal = prometheus.NewDesc(
+		c.scopeAddressesInUseOnPartnerServerTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_in_use_on_partner_server"),
 			"DHCP Scope addresses in use on partner server",
 			[]string{"scope"},
 			nil,
 		)
 
-		c.scopeAddressesInUseOnThisServerTotal = prometheus.NewDesc(
+		c.scopeAddressesInUseOnThisServerTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "scope_addresses_in_use_on_this_server"),
 			"DHCP Scope addresses in use on this server",
 			[]string{"scope"},
 			nil,
 		)
 
-		c.scopePendingOffersTotal = prometheus.NewDesc(
+		c.scopePendingOffersTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "scope_pending_offers"),
 			"DHCP Scope pending offers",
 			[]string{"scope"},
 			nil,
 		)
 
-		c.scopeReservedAddressTotal = prometheus.NewDesc(
+		c.scopeReservedAddressTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "scope_reserved_address"),
 			"DHCP Scope reserved addresses",
 			[]string{"scope"},
@@ -228,151 +230,151 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	}
 
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorServerMetrics) {
-		c.packetsReceivedTotal = prometheus.NewDesc(
+		c.packetsReceivedTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "packets_received_total"),
 			"Total number of packets received by the DHCP server (PacketsReceivedTotal)",
 			nil,
 			nil,
 		)
-		c.duplicatesDroppedTotal = prometheus.NewDesc(
+		c.duplicatesDroppedTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "duplicates_dropped_total"),
 			"Total number of duplicate packets received by the DHCP server (DuplicatesDroppedTotal)",
 			nil,
 			nil,
 		)
-		c.packetsExpiredTotal = prometheus.NewDesc(
+		c.packetsExpiredTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "packets_expired_total"),
 			"Total number of packets expired in the DHCP server message queue (PacketsExpiredTotal)",
 			nil,
 			nil,
 		)
-		c.activeQueueLength = prometheus.NewDesc(
+		c.activeQueueLength = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "active_queue_length"),
 			"Number of packets in the processing queue of the DHCP server (ActiveQueueLength)",
 			nil,
 			nil,
 		)
-		c.conflictCheckQueueLength = prometheus.NewDesc(
+		c.conflictCheckQueueLength = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "conflict_check_queue_length"),
 			"Number of packets in the DHCP server queue waiting on conflict detection (ping). (ConflictCheckQueueLength)",
 			nil,
 			nil,
 		)
-		c.discoversTotal = prometheus.NewDesc(
+		c.discoversTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "discovers_total"),
 			"Total DHCP Discovers received by the DHCP server (DiscoversTotal)",
 			nil,
 			nil,
 		)
-		c.offersTotal = prometheus.NewDesc(
+		c.offersTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "offers_total"),
 			"Total DHCP Offers sent by the DHCP server (OffersTotal)",
 			nil,
 			nil,
 		)
-		c.requestsTotal = prometheus.NewDesc(
+		c.requestsTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "requests_total"),
 			"Total DHCP Requests received by the DHCP server (RequestsTotal)",
 			nil,
 			nil,
 		)
-		c.informsTotal = prometheus.NewDesc(
+		c.informsTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "informs_total"),
 			"Total DHCP Informs received by the DHCP server (InformsTotal)",
 			nil,
 			nil,
 		)
-		c.acksTotal = prometheus.NewDesc(
+		c.acksTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "acks_total"),
 			"Total DHCP Acks sent by the DHCP server (AcksTotal)",
 			nil,
 			nil,
 		)
-		c.nACKsTotal = prometheus.NewDesc(
+		c.nACKsTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "nacks_total"),
 			"Total DHCP Nacks sent by the DHCP server (NacksTotal)",
 			nil,
 			nil,
 		)
-		c.declinesTotal = prometheus.NewDesc(
+		c.declinesTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "declines_total"),
 			"Total DHCP Declines received by the DHCP server (DeclinesTotal)",
 			nil,
 			nil,
 		)
-		c.releasesTotal = prometheus.NewDesc(
+		c.releasesTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "releases_total"),
 			"Total DHCP Releases received by the DHCP server (ReleasesTotal)",
 			nil,
 			nil,
 		)
-		c.offerQueueLength = prometheus.NewDesc(
+		c.offerQueueLength = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "offer_queue_length"),
 			"Number of packets in the offer queue of the DHCP server (OfferQueueLength)",
 			nil,
 			nil,
 		)
-		c.deniedDueToMatch = prometheus.NewDesc(
+		c.deniedDueToMatch = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "denied_due_to_match_total"),
 			"Total number of DHCP requests denied, based on matches from the Deny list (DeniedDueToMatch)",
 			nil,
 			nil,
 		)
-		c.deniedDueToNonMatch = prometheus.NewDesc(
+		c.deniedDueToNonMatch = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "denied_due_to_nonmatch_total"),
 			"Total number of DHCP requests denied, based on non-matches from the Allow list (DeniedDueToNonMatch)",
 			nil,
 			nil,
 		)
-		c.failoverBndUpdSentTotal = prometheus.NewDesc(
+		c.failoverBndUpdSentTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_sent_total"),
 			"Number of DHCP fail over Binding Update messages sent (FailoverBndupdSentTotal)",
 			nil,
 			nil,
 		)
-		c.failoverBndUpdReceivedTotal = prometheus.NewDesc(
+		c.failoverBndUpdReceivedTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_received_total"),
 			"Number of DHCP fail over Binding Update messages received (FailoverBndupdReceivedTotal)",
 			nil,
 			nil,
 		)
-		c.failoverBndAckSentTotal = prometheus.NewDesc(
+		c.failoverBndAckSentTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "failover_bndack_sent_total"),
 			"Number of DHCP fail over Binding Ack messages sent (FailoverBndackSentTotal)",
 			nil,
 			nil,
 		)
-		c.failoverBndAckReceivedTotal = prometheus.NewDesc(
+		c.failoverBndAckReceivedTotal = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "failover_bndack_received_total"),
 			"Number of DHCP fail over Binding Ack messages received (FailoverBndackReceivedTotal)",
 			nil,
 			nil,
 		)
-		c.failoverBndUpdPendingOutboundQueue = prometheus.NewDesc(
+		c.failoverBndUpdPendingOutboundQueue = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_pending_in_outbound_queue"),
 			"Number of pending outbound DHCP fail over Binding Update messages (FailoverBndupdPendingOutboundQueue)",
 			nil,
 			nil,
 		)
-		c.failoverTransitionsCommunicationInterruptedState = prometheus.NewDesc(
+		c.failoverTransitionsCommunicationInterruptedState = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "failover_transitions_communicationinterrupted_state_total"),
 			"Total number of transitions into COMMUNICATION INTERRUPTED state (FailoverTransitionsCommunicationinterruptedState)",
 			nil,
 			nil,
 		)
-		c.failoverTransitionsPartnerDownState = prometheus.NewDesc(
+		c.failoverTransitionsPartnerDownState = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "failover_transitions_partnerdown_state_total"),
 			"Total number of transitions into PARTNER DOWN state (FailoverTransitionsPartnerdownState)",
 			nil,
 			nil,
 		)
-		c.failoverTransitionsRecoverState = prometheus.NewDesc(
+		c.failoverTransitionsRecoverState = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "failover_transitions_recover_total"),
 			"Total number of transitions into RECOVER state (FailoverTransitionsRecoverState)",
 			nil,
 			nil,
 		)
-		c.failoverBndUpdDropped = prometheus.NewDesc(
+		c.failoverBndUpdDropped = c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, "failover_bndupd_dropped_total"),
 			"Total number of DHCP fail over Binding Updates dropped (FailoverBndupdDropped)",
 			nil,
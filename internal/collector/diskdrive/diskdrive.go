@@ -18,6 +18,7 @@
 package diskdrive
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -27,6 +28,7 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -39,6 +41,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for a few WMI metrics in Win32_DiskDrive.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 	logger *slog.Logger
 
@@ -79,7 +83,7 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 	c.logger = logger.With(slog.String("collector", Name))
 
-	c.diskInfo = prometheus.NewDesc(
+	c.diskInfo = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"General drive information",
 		[]string{
@@ -90,25 +94,25 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 		},
 		nil,
 	)
-	c.status = prometheus.NewDesc(
+	c.status = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "status"),
 		"Status of the drive",
 		[]string{"name", "status"},
 		nil,
 	)
-	c.size = prometheus.NewDesc(
+	c.size = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "size"),
 		"Size of the disk drive. It is calculated by multiplying the total number of cylinders, tracks in each cylinder, sectors in each track, and bytes in each sector.",
 		[]string{"name"},
 		nil,
 	)
-	c.partitions = prometheus.NewDesc(
+	c.partitions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "partitions"),
 		"Number of partitions",
 		[]string{"name"},
 		nil,
 	)
-	c.availability = prometheus.NewDesc(
+	c.availability = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availability"),
 		"Availability Status",
 		[]string{"name", "availability"},
@@ -190,8 +194,16 @@ var (
 
 // Collect sends the metric values for each metric to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.CollectWithContext(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but cancels the WMI query once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []diskDrive
-	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.miQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootCIMv2, c.miQuery); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
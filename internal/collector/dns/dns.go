@@ -18,6 +18,7 @@
 package dns
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -29,6 +30,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -52,6 +54,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus Collector for WMI Win32_PerfRawData_DNS_DNS metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -158,140 +162,140 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 }
 
 func (c *Collector) buildMetricsCollector(logger *slog.Logger) error {
-	c.zoneTransferRequestsReceived = prometheus.NewDesc(
+	c.zoneTransferRequestsReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_requests_received_total"),
 		"Number of zone transfer requests (AXFR/IXFR) received by the master DNS server",
 		[]string{"qtype"},
 		nil,
 	)
-	c.zoneTransferRequestsSent = prometheus.NewDesc(
+	c.zoneTransferRequestsSent = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_requests_sent_total"),
 		"Number of zone transfer requests (AXFR/IXFR) sent by the secondary DNS server",
 		[]string{"qtype"},
 		nil,
 	)
-	c.zoneTransferResponsesReceived = prometheus.NewDesc(
+	c.zoneTransferResponsesReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_response_received_total"),
 		"Number of zone transfer responses (AXFR/IXFR) received by the secondary DNS server",
 		[]string{"qtype"},
 		nil,
 	)
-	c.zoneTransferSuccessReceived = prometheus.NewDesc(
+	c.zoneTransferSuccessReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_success_received_total"),
 		"Number of successful zone transfers (AXFR/IXFR) received by the secondary DNS server",
 		[]string{"qtype", "protocol"},
 		nil,
 	)
-	c.zoneTransferSuccessSent = prometheus.NewDesc(
+	c.zoneTransferSuccessSent = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_success_sent_total"),
 		"Number of successful zone transfers (AXFR/IXFR) of the master DNS server",
 		[]string{"qtype"},
 		nil,
 	)
-	c.zoneTransferFailures = prometheus.NewDesc(
+	c.zoneTransferFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "zone_transfer_failures_total"),
 		"Number of failed zone transfers of the master DNS server",
 		nil,
 		nil,
 	)
-	c.memoryUsedBytes = prometheus.NewDesc(
+	c.memoryUsedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memory_used_bytes"),
 		"Current memory used by DNS server",
 		[]string{"area"},
 		nil,
 	)
-	c.dynamicUpdatesQueued = prometheus.NewDesc(
+	c.dynamicUpdatesQueued = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_updates_queued"),
 		"Number of dynamic updates queued by the DNS server",
 		nil,
 		nil,
 	)
-	c.dynamicUpdatesReceived = prometheus.NewDesc(
+	c.dynamicUpdatesReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_updates_received_total"),
 		"Number of secure update requests received by the DNS server",
 		[]string{"operation"},
 		nil,
 	)
-	c.dynamicUpdatesFailures = prometheus.NewDesc(
+	c.dynamicUpdatesFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_updates_failures_total"),
 		"Number of dynamic updates which timed out or were rejected by the DNS server",
 		[]string{"reason"},
 		nil,
 	)
-	c.notifyReceived = prometheus.NewDesc(
+	c.notifyReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "notify_received_total"),
 		"Number of notifies received by the secondary DNS server",
 		nil,
 		nil,
 	)
-	c.notifySent = prometheus.NewDesc(
+	c.notifySent = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "notify_sent_total"),
 		"Number of notifies sent by the master DNS server",
 		nil,
 		nil,
 	)
-	c.secureUpdateFailures = prometheus.NewDesc(
+	c.secureUpdateFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "secure_update_failures_total"),
 		"Number of secure updates that failed on the DNS server",
 		nil,
 		nil,
 	)
-	c.secureUpdateReceived = prometheus.NewDesc(
+	c.secureUpdateReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "secure_update_received_total"),
 		"Number of secure update requests received by the DNS server",
 		nil,
 		nil,
 	)
-	c.queries = prometheus.NewDesc(
+	c.queries = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "queries_total"),
 		"Number of queries received by DNS server",
 		[]string{"protocol"},
 		nil,
 	)
-	c.responses = prometheus.NewDesc(
+	c.responses = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "responses_total"),
 		"Number of responses sent by DNS server",
 		[]string{"protocol"},
 		nil,
 	)
-	c.recursiveQueries = prometheus.NewDesc(
+	c.recursiveQueries = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "recursive_queries_total"),
 		"Number of recursive queries received by DNS server",
 		nil,
 		nil,
 	)
-	c.recursiveQueryFailures = prometheus.NewDesc(
+	c.recursiveQueryFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "recursive_query_failures_total"),
 		"Number of recursive query failures",
 		nil,
 		nil,
 	)
-	c.recursiveQuerySendTimeouts = prometheus.NewDesc(
+	c.recursiveQuerySendTimeouts = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "recursive_query_send_timeouts_total"),
 		"Number of recursive query sending timeouts",
 		nil,
 		nil,
 	)
-	c.winsQueries = prometheus.NewDesc(
+	c.winsQueries = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "wins_queries_total"),
 		"Number of WINS lookup requests received by the server",
 		[]string{"direction"},
 		nil,
 	)
-	c.winsResponses = prometheus.NewDesc(
+	c.winsResponses = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "wins_responses_total"),
 		"Number of WINS lookup responses sent by the server",
 		[]string{"direction"},
 		nil,
 	)
-	c.unmatchedResponsesReceived = prometheus.NewDesc(
+	c.unmatchedResponsesReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "unmatched_responses_total"),
 		"Number of response packets received by the DNS server that do not match any outstanding remote query",
 		nil,
 		nil,
 	)
 
-	c.dnsWMIStats = prometheus.NewDesc(
+	c.dnsWMIStats = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "wmi_stats_total"),
 		"DNS WMI statistics from MicrosoftDNS_Statistic",
 		[]string{"name", "collection_name", "dns_server"},
@@ -332,16 +336,24 @@ func (c *Collector) buildErrorStatsCollector(miSession *mi.Session) error {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.CollectWithContext(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but stops the collection once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
 	errs := make([]error, 0)
 
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
-		if err := c.collectMetrics(ch); err != nil {
+		if err := c.collectMetrics(ctx, ch); err != nil {
 			errs = append(errs, fmt.Errorf("failed collecting metrics: %w", err))
 		}
 	}
 
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorWMIStats) {
-		if err := c.collectErrorStats(ch, maxScrapeDuration); err != nil {
+		if err := c.collectErrorStats(ctx, ch); err != nil {
 			errs = append(errs, fmt.Errorf("failed collecting WMI statistics: %w", err))
 		}
 	}
@@ -349,8 +361,8 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 	return errors.Join(errs...)
 }
 
-func (c *Collector) collectMetrics(ch chan<- prometheus.Metric) error {
-	err := c.perfDataCollector.Collect(&c.perfDataObject)
+func (c *Collector) collectMetrics(ctx context.Context, ch chan<- prometheus.Metric) error {
+	err := c.perfDataCollector.CollectWithContext(ctx, &c.perfDataObject)
 	if err != nil {
 		return fmt.Errorf("failed to collect DNS metrics: %w", err)
 	} else if len(c.perfDataObject) == 0 {
@@ -633,9 +645,9 @@ func (c *Collector) collectMetrics(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
-func (c *Collector) collectErrorStats(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+func (c *Collector) collectErrorStats(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var stats []Statistic
-	if err := c.miSession.Query(&stats, mi.NamespaceRootMicrosoftDNS, c.miQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &stats, mi.NamespaceRootMicrosoftDNS, c.miQuery); err != nil {
 		return fmt.Errorf("failed to query DNS statistics: %w", err)
 	}
 
@@ -28,6 +28,7 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -67,6 +68,8 @@ var ConfigDefaults = Config{
 }
 
 type Collector struct {
+	types.DescRecorder
+
 	collectorADAccessProcesses
 	collectorActiveSync
 	collectorAutoDiscover
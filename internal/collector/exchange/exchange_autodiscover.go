@@ -44,7 +44,7 @@ func (c *Collector) buildAutoDiscover() error {
 		return fmt.Errorf("failed to create MSExchange Autodiscover collector: %w", err)
 	}
 
-	c.autoDiscoverRequestsPerSec = prometheus.NewDesc(
+	c.autoDiscoverRequestsPerSec = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "autodiscover_requests_total"),
 		"Number of autodiscover service requests processed each second",
 		nil,
@@ -44,7 +44,7 @@ func (c *Collector) buildAvailabilityService() error {
 		return fmt.Errorf("failed to create MSExchange Availability Service collector: %w", err)
 	}
 
-	c.availabilityRequestsSec = prometheus.NewDesc(
+	c.availabilityRequestsSec = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "availability_service_requests_per_sec"),
 		"Number of requests serviced per second",
 		nil,
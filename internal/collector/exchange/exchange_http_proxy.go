@@ -57,37 +57,37 @@ func (c *Collector) buildHTTPProxy() error {
 		return fmt.Errorf("failed to create MSExchange HttpProxy collector: %w", err)
 	}
 
-	c.mailboxServerLocatorAverageLatency = prometheus.NewDesc(
+	c.mailboxServerLocatorAverageLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_mailbox_server_locator_avg_latency_sec"),
 		"Average latency (sec) of MailboxServerLocator web service calls",
 		[]string{"name"},
 		nil,
 	)
-	c.averageAuthenticationLatency = prometheus.NewDesc(
+	c.averageAuthenticationLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_avg_auth_latency"),
 		"Average time spent authenticating CAS requests over the last 200 samples",
 		[]string{"name"},
 		nil,
 	)
-	c.outstandingProxyRequests = prometheus.NewDesc(
+	c.outstandingProxyRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_outstanding_proxy_requests"),
 		"Number of concurrent outstanding proxy requests",
 		[]string{"name"},
 		nil,
 	)
-	c.proxyRequestsPerSec = prometheus.NewDesc(
+	c.proxyRequestsPerSec = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_requests_total"),
 		"Number of proxy requests processed each second",
 		[]string{"name"},
 		nil,
 	)
-	c.averageCASProcessingLatency = prometheus.NewDesc(
+	c.averageCASProcessingLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_avg_cas_processing_latency_sec"),
 		"Average latency (sec) of CAS processing time over the last 200 reqs",
 		[]string{"name"},
 		nil,
 	)
-	c.mailboxServerProxyFailureRate = prometheus.NewDesc(
+	c.mailboxServerProxyFailureRate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_mailbox_proxy_failure_rate"),
 		"% of failures between this CAS and MBX servers over the last 200 samples",
 		[]string{"name"},
@@ -55,37 +55,37 @@ func (c *Collector) buildRpcClientAccess() error {
 		return fmt.Errorf("failed to create MSExchange RpcClientAccess collector: %w", err)
 	}
 
-	c.rpcAveragedLatency = prometheus.NewDesc(
+	c.rpcAveragedLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "rpc_avg_latency_sec"),
 		"The latency (sec) averaged for the past 1024 packets",
 		nil,
 		nil,
 	)
-	c.rpcRequests = prometheus.NewDesc(
+	c.rpcRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "rpc_requests"),
 		"Number of client requests currently being processed by the RPC Client Access service",
 		nil,
 		nil,
 	)
-	c.activeUserCount = prometheus.NewDesc(
+	c.activeUserCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "rpc_active_user_count"),
 		"Number of unique users that have shown some kind of activity in the last 2 minutes",
 		nil,
 		nil,
 	)
-	c.connectionCount = prometheus.NewDesc(
+	c.connectionCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "rpc_connection_count"),
 		"Total number of client connections maintained",
 		nil,
 		nil,
 	)
-	c.rpcOperationsPerSec = prometheus.NewDesc(
+	c.rpcOperationsPerSec = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "rpc_operations_total"),
 		"The rate at which RPC operations occur",
 		nil,
 		nil,
 	)
-	c.userCount = prometheus.NewDesc(
+	c.userCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "rpc_user_count"),
 		"Number of users",
 		nil,
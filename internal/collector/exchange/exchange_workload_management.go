@@ -54,31 +54,31 @@ func (c *Collector) buildWorkloadManagementWorkloads() error {
 		return fmt.Errorf("failed to create MSExchange WorkloadManagement Workloads collector: %w", err)
 	}
 
-	c.activeTasks = prometheus.NewDesc(
+	c.activeTasks = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "workload_active_tasks"),
 		"Number of active tasks currently running in the background for workload management",
 		[]string{"name"},
 		nil,
 	)
-	c.completedTasks = prometheus.NewDesc(
+	c.completedTasks = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "workload_completed_tasks"),
 		"Number of workload management tasks that have been completed",
 		[]string{"name"},
 		nil,
 	)
-	c.queuedTasks = prometheus.NewDesc(
+	c.queuedTasks = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "workload_queued_tasks"),
 		"Number of workload management tasks that are currently queued up waiting to be processed",
 		[]string{"name"},
 		nil,
 	)
-	c.yieldedTasks = prometheus.NewDesc(
+	c.yieldedTasks = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "workload_yielded_tasks"),
 		"The total number of tasks that have been yielded by a workload",
 		[]string{"name"},
 		nil,
 	)
-	c.isActive = prometheus.NewDesc(
+	c.isActive = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "workload_is_active"),
 		"Active indicates whether the workload is in an active (1) or paused (0) state",
 		[]string{"name"},
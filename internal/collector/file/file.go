@@ -47,6 +47,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus Collector for collecting file times.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	logger    *slog.Logger
@@ -108,14 +110,14 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.logger.Info("file collector is in an experimental state! It may subject to change.")
 
-	c.fileMTime = prometheus.NewDesc(
+	c.fileMTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mtime_timestamp_seconds"),
 		"File modification time",
 		[]string{"file", "pattern"},
 		nil,
 	)
 
-	c.fileSize = prometheus.NewDesc(
+	c.fileSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "size_bytes"),
 		"File size",
 		[]string{"file", "pattern"},
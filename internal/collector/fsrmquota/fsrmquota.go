@@ -18,6 +18,7 @@
 package fsrmquota
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -38,6 +39,8 @@ type Config struct{}
 var ConfigDefaults = Config{}
 
 type Collector struct {
+	types.DescRecorder
+
 	config    Config
 	miSession *mi.Session
 	miQuery   mi.Query
@@ -91,55 +94,55 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 	c.miQuery = miQuery
 	c.miSession = miSession
 
-	c.quotasCount = prometheus.NewDesc(
+	c.quotasCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "count"),
 		"Number of Quotas",
 		nil,
 		nil,
 	)
-	c.peakUsage = prometheus.NewDesc(
+	c.peakUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "peak_usage_bytes"),
 		"The highest amount of disk space usage charged to this quota. (PeakUsage)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.size = prometheus.NewDesc(
+	c.size = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "size_bytes"),
 		"The size of the quota. (Size)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.usage = prometheus.NewDesc(
+	c.usage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "usage_bytes"),
 		"The current amount of disk space usage charged to this quota. (Usage)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.description = prometheus.NewDesc(
+	c.description = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "description"),
 		"Description of the quota (Description)",
 		[]string{"path", "template", "description"},
 		nil,
 	)
-	c.disabled = prometheus.NewDesc(
+	c.disabled = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "disabled"),
 		"If 1, the quota is disabled. The default value is 0. (Disabled)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.softLimit = prometheus.NewDesc(
+	c.softLimit = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "softlimit"),
 		"If 1, the quota is a soft limit. If 0, the quota is a hard limit. The default value is 0. Optional (SoftLimit)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.template = prometheus.NewDesc(
+	c.template = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "template"),
 		"Quota template name. (Template)",
 		[]string{"path", "template"},
 		nil,
 	)
-	c.matchesTemplate = prometheus.NewDesc(
+	c.matchesTemplate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "matchestemplate"),
 		"If 1, the property values of this quota match those values of the template from which it was derived. (MatchesTemplate)",
 		[]string{"path", "template"},
@@ -171,8 +174,16 @@ type msftFSRMQuota struct {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.CollectWithContext(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but cancels the WMI query once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []msftFSRMQuota
-	if err := c.miSession.Query(&dst, mi.NamespaceRootWindowsFSRM, c.miQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootWindowsFSRM, c.miQuery); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
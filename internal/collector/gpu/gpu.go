@@ -40,6 +40,8 @@ type Config struct{}
 var ConfigDefaults = Config{}
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	gpuDeviceCache map[string]gpuDevice
@@ -125,97 +127,97 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	var err error
 
-	c.gpuInfo = prometheus.NewDesc(
+	c.gpuInfo = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"A metric with a constant '1' value labeled with gpu device information.",
 		[]string{"luid", "device_id", "name", "bus_number", "phys", "function_number"},
 		nil,
 	)
 
-	c.gpuSharedSystemMemorySize = prometheus.NewDesc(
+	c.gpuSharedSystemMemorySize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "shared_system_memory_size_bytes"),
 		"The size, in bytes, of memory from system memory that can be shared by many users.",
 		[]string{"luid", "device_id"},
 		nil,
 	)
-	c.gpuDedicatedSystemMemorySize = prometheus.NewDesc(
+	c.gpuDedicatedSystemMemorySize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dedicated_system_memory_size_bytes"),
 		"The size, in bytes, of memory that is dedicated from system memory.",
 		[]string{"luid", "device_id"},
 		nil,
 	)
-	c.gpuDedicatedVideoMemorySize = prometheus.NewDesc(
+	c.gpuDedicatedVideoMemorySize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dedicated_video_memory_size_bytes"),
 		"The size, in bytes, of memory that is dedicated from video memory.",
 		[]string{"luid", "device_id"},
 		nil,
 	)
 
-	c.gpuEngineRunningTime = prometheus.NewDesc(
+	c.gpuEngineRunningTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "engine_time_seconds"),
 		"Total running time of the GPU in seconds.",
 		[]string{"process_id", "luid", "device_id", "phys", "eng", "engtype"},
 		nil,
 	)
 
-	c.gpuAdapterMemoryDedicatedUsage = prometheus.NewDesc(
+	c.gpuAdapterMemoryDedicatedUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "adapter_memory_dedicated_bytes"),
 		"Dedicated GPU memory usage in bytes.",
 		[]string{"luid", "device_id", "phys"},
 		nil,
 	)
-	c.gpuAdapterMemorySharedUsage = prometheus.NewDesc(
+	c.gpuAdapterMemorySharedUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "adapter_memory_shared_bytes"),
 		"Shared GPU memory usage in bytes.",
 		[]string{"luid", "device_id", "phys"},
 		nil,
 	)
-	c.gpuAdapterMemoryTotalCommitted = prometheus.NewDesc(
+	c.gpuAdapterMemoryTotalCommitted = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "adapter_memory_committed_bytes"),
 		"Total committed GPU memory in bytes.",
 		[]string{"luid", "device_id", "phys"},
 		nil,
 	)
 
-	c.gpuLocalAdapterMemoryUsage = prometheus.NewDesc(
+	c.gpuLocalAdapterMemoryUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "local_adapter_memory_bytes"),
 		"Local adapter memory usage in bytes.",
 		[]string{"luid", "device_id", "phys", "part"},
 		nil,
 	)
 
-	c.gpuNonLocalAdapterMemoryUsage = prometheus.NewDesc(
+	c.gpuNonLocalAdapterMemoryUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "non_local_adapter_memory_bytes"),
 		"Non-local adapter memory usage in bytes.",
 		[]string{"luid", "device_id", "phys", "part"},
 		nil,
 	)
 
-	c.gpuProcessMemoryDedicatedUsage = prometheus.NewDesc(
+	c.gpuProcessMemoryDedicatedUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_dedicated_bytes"),
 		"Dedicated process memory usage in bytes.",
 		[]string{"process_id", "luid", "device_id", "phys"},
 		nil,
 	)
-	c.gpuProcessMemoryLocalUsage = prometheus.NewDesc(
+	c.gpuProcessMemoryLocalUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_local_bytes"),
 		"Local process memory usage in bytes.",
 		[]string{"process_id", "luid", "device_id", "phys"},
 		nil,
 	)
-	c.gpuProcessMemoryNonLocalUsage = prometheus.NewDesc(
+	c.gpuProcessMemoryNonLocalUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_non_local_bytes"),
 		"Non-local process memory usage in bytes.",
 		[]string{"process_id", "luid", "device_id", "phys"},
 		nil,
 	)
-	c.gpuProcessMemorySharedUsage = prometheus.NewDesc(
+	c.gpuProcessMemorySharedUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_shared_bytes"),
 		"Shared process memory usage in bytes.",
 		[]string{"process_id", "luid", "device_id", "phys"},
 		nil,
 	)
-	c.gpuProcessMemoryTotalCommitted = prometheus.NewDesc(
+	c.gpuProcessMemoryTotalCommitted = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_committed_bytes"),
 		"Total committed process memory in bytes.",
 		[]string{"process_id", "luid", "device_id", "phys"},
@@ -29,6 +29,7 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -79,6 +80,8 @@ var ConfigDefaults = Config{
 
 // Collector is a Prometheus Collector for hyper-v.
 type Collector struct {
+	types.DescRecorder
+
 	collectorDataStore
 	collectorDynamicMemoryBalancer
 	collectorDynamicMemoryVM
@@ -57,25 +57,25 @@ func (c *Collector) buildDynamicMemoryBalancer() error {
 		return fmt.Errorf("failed to create Hyper-V Virtual Machine Health Summary collector: %w", err)
 	}
 
-	c.vmDynamicMemoryBalancerAvailableMemory = prometheus.NewDesc(
+	c.vmDynamicMemoryBalancerAvailableMemory = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_balancer_available_memory_bytes"),
 		"Represents the amount of memory left on the node.",
 		[]string{"balancer"},
 		nil,
 	)
-	c.vmDynamicMemoryBalancerAvailableMemoryForBalancing = prometheus.NewDesc(
+	c.vmDynamicMemoryBalancerAvailableMemoryForBalancing = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_balancer_available_memory_for_balancing_bytes"),
 		"Represents the available memory for balancing purposes.",
 		[]string{"balancer"},
 		nil,
 	)
-	c.vmDynamicMemoryBalancerAveragePressure = prometheus.NewDesc(
+	c.vmDynamicMemoryBalancerAveragePressure = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_balancer_average_pressure_ratio"),
 		"Represents the average system pressure on the balancer node among all balanced objects.",
 		[]string{"balancer"},
 		nil,
 	)
-	c.vmDynamicMemoryBalancerSystemCurrentPressure = prometheus.NewDesc(
+	c.vmDynamicMemoryBalancerSystemCurrentPressure = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_balancer_system_current_pressure_ratio"),
 		"Represents the current pressure in the system.",
 		[]string{"balancer"},
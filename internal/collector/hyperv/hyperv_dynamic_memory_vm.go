@@ -68,61 +68,61 @@ func (c *Collector) buildDynamicMemoryVM() error {
 		return fmt.Errorf("failed to create Hyper-V Dynamic Memory VM collector: %w", err)
 	}
 
-	c.vmMemoryAddedMemory = prometheus.NewDesc(
+	c.vmMemoryAddedMemory = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_vm_added_total"),
 		"Represents the cumulative amount of memory added to the VM.",
 		[]string{"vm"},
 		nil,
 	)
-	c.vmMemoryCurrentPressure = prometheus.NewDesc(
+	c.vmMemoryCurrentPressure = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_vm_pressure_current_ratio"),
 		"Represents the current pressure in the VM.",
 		[]string{"vm"},
 		nil,
 	)
-	c.vmMemoryGuestAvailableMemory = prometheus.NewDesc(
+	c.vmMemoryGuestAvailableMemory = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_vm_guest_available_bytes"),
 		"Represents the current amount of available memory in the VM (reported by the VM).",
 		[]string{"vm"},
 		nil,
 	)
-	c.vmMemoryGuestVisiblePhysicalMemory = prometheus.NewDesc(
+	c.vmMemoryGuestVisiblePhysicalMemory = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_vm_guest_visible_physical_memory_bytes"),
 		"Represents the amount of memory visible in the VM.'",
 		[]string{"vm"},
 		nil,
 	)
-	c.vmMemoryMaximumPressure = prometheus.NewDesc(
+	c.vmMemoryMaximumPressure = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_vm_pressure_maximum_ratio"),
 		"Represents the maximum pressure band in the VM.",
 		[]string{"vm"},
 		nil,
 	)
-	c.vmMemoryMemoryAddOperations = prometheus.NewDesc(
+	c.vmMemoryMemoryAddOperations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_vm_add_operations_total"),
 		"Represents the total number of add operations for the VM.",
 		[]string{"vm"},
 		nil,
 	)
-	c.vmMemoryMemoryRemoveOperations = prometheus.NewDesc(
+	c.vmMemoryMemoryRemoveOperations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_vm_remove_operations_total"),
 		"Represents the total number of remove operations for the VM.",
 		[]string{"vm"},
 		nil,
 	)
-	c.vmMemoryMinimumPressure = prometheus.NewDesc(
+	c.vmMemoryMinimumPressure = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_vm_pressure_minimum_ratio"),
 		"Represents the minimum pressure band in the VM.",
 		[]string{"vm"},
 		nil,
 	)
-	c.vmMemoryPhysicalMemory = prometheus.NewDesc(
+	c.vmMemoryPhysicalMemory = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_vm_physical_bytes"),
 		"Represents the current amount of memory in the VM.",
 		[]string{"vm"},
 		nil,
 	)
-	c.vmMemoryRemovedMemory = prometheus.NewDesc(
+	c.vmMemoryRemovedMemory = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "dynamic_memory_vm_removed_bytes_total"),
 		"Represents the cumulative amount of memory removed from the VM.",
 		[]string{"vm"},
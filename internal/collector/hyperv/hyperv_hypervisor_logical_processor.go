@@ -57,20 +57,20 @@ func (c *Collector) buildHypervisorLogicalProcessor() error {
 		return fmt.Errorf("failed to create Hyper-V Hypervisor Logical Processor collector: %w", err)
 	}
 
-	c.hypervisorLogicalProcessorTimeTotal = prometheus.NewDesc(
+	c.hypervisorLogicalProcessorTimeTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_logical_processor_time_total"),
 		"Time that processor spent in different modes (hypervisor, guest, idle)",
 		[]string{"core", "state"},
 		nil,
 	)
-	c.hypervisorLogicalProcessorTotalRunTimeTotal = prometheus.NewDesc(
+	c.hypervisorLogicalProcessorTotalRunTimeTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_logical_processor_total_run_time_total"),
 		"Time that processor spent",
 		[]string{"core"},
 		nil,
 	)
 
-	c.hypervisorLogicalProcessorContextSwitches = prometheus.NewDesc(
+	c.hypervisorLogicalProcessorContextSwitches = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_logical_processor_context_switches_total"),
 		"The rate of virtual processor context switches on the processor.",
 		[]string{"core"},
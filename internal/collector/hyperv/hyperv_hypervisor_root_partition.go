@@ -85,127 +85,127 @@ func (c *Collector) buildHypervisorRootPartition() error {
 		return fmt.Errorf("failed to create Hyper-V Hypervisor Root Partition collector: %w", err)
 	}
 
-	c.hypervisorRootPartitionAddressSpaces = prometheus.NewDesc(
+	c.hypervisorRootPartitionAddressSpaces = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_address_spaces"),
 		"The number of address spaces in the virtual TLB of the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionAttachedDevices = prometheus.NewDesc(
+	c.hypervisorRootPartitionAttachedDevices = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_attached_devices"),
 		"The number of devices attached to the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionDepositedPages = prometheus.NewDesc(
+	c.hypervisorRootPartitionDepositedPages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_deposited_pages"),
 		"The number of pages deposited into the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionDeviceDMAErrors = prometheus.NewDesc(
+	c.hypervisorRootPartitionDeviceDMAErrors = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_device_dma_errors"),
 		"An indicator of illegal DMA requests generated by all devices assigned to the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionDeviceInterruptErrors = prometheus.NewDesc(
+	c.hypervisorRootPartitionDeviceInterruptErrors = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_device_interrupt_errors"),
 		"An indicator of illegal interrupt requests generated by all devices assigned to the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionDeviceInterruptMappings = prometheus.NewDesc(
+	c.hypervisorRootPartitionDeviceInterruptMappings = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_device_interrupt_mappings"),
 		"The number of device interrupt mappings used by the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionDeviceInterruptThrottleEvents = prometheus.NewDesc(
+	c.hypervisorRootPartitionDeviceInterruptThrottleEvents = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_device_interrupt_throttle_events"),
 		"The number of times an interrupt from a device assigned to the partition was temporarily throttled because the device was generating too many interrupts",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionGPAPages = prometheus.NewDesc(
+	c.hypervisorRootPartitionGPAPages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_preferred_numa_node_index"),
 		"The number of pages present in the GPA space of the partition (zero for root partition)",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionGPASpaceModifications = prometheus.NewDesc(
+	c.hypervisorRootPartitionGPASpaceModifications = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_gpa_space_modifications"),
 		"The rate of modifications to the GPA space of the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionIOTLBFlushCost = prometheus.NewDesc(
+	c.hypervisorRootPartitionIOTLBFlushCost = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_io_tlb_flush_cost"),
 		"The average time (in nanoseconds) spent processing an I/O TLB flush",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionIOTLBFlushes = prometheus.NewDesc(
+	c.hypervisorRootPartitionIOTLBFlushes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_io_tlb_flush"),
 		"The rate of flushes of I/O TLBs of the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionRecommendedVirtualTLBSize = prometheus.NewDesc(
+	c.hypervisorRootPartitionRecommendedVirtualTLBSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_recommended_virtual_tlb_size"),
 		"The recommended number of pages to be deposited for the virtual TLB",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionSkippedTimerTicks = prometheus.NewDesc(
+	c.hypervisorRootPartitionSkippedTimerTicks = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_physical_pages_allocated"),
 		"The number of timer interrupts skipped for the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartition1GDevicePages = prometheus.NewDesc(
+	c.hypervisorRootPartition1GDevicePages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_1G_device_pages"),
 		"The number of 1G pages present in the device space of the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartition1GGPAPages = prometheus.NewDesc(
+	c.hypervisorRootPartition1GGPAPages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_1G_gpa_pages"),
 		"The number of 1G pages present in the GPA space of the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartition2MDevicePages = prometheus.NewDesc(
+	c.hypervisorRootPartition2MDevicePages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_2M_device_pages"),
 		"The number of 2M pages present in the device space of the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartition2MGPAPages = prometheus.NewDesc(
+	c.hypervisorRootPartition2MGPAPages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_2M_gpa_pages"),
 		"The number of 2M pages present in the GPA space of the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartition4KDevicePages = prometheus.NewDesc(
+	c.hypervisorRootPartition4KDevicePages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_4K_device_pages"),
 		"The number of 4K pages present in the device space of the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartition4KGPAPages = prometheus.NewDesc(
+	c.hypervisorRootPartition4KGPAPages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_4K_gpa_pages"),
 		"The number of 4K pages present in the GPA space of the partition",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionVirtualTLBFlushEntries = prometheus.NewDesc(
+	c.hypervisorRootPartitionVirtualTLBFlushEntries = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_virtual_tlb_flush_entries"),
 		"The rate of flushes of the entire virtual TLB",
 		nil,
 		nil,
 	)
-	c.hypervisorRootPartitionVirtualTLBPages = prometheus.NewDesc(
+	c.hypervisorRootPartitionVirtualTLBPages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "root_partition_virtual_tlb_pages"),
 		"The number of pages used by the virtual TLB of the partition",
 		nil,
@@ -59,35 +59,35 @@ func (c *Collector) buildHypervisorVirtualProcessor() error {
 		return fmt.Errorf("failed to create Hyper-V Hypervisor Virtual Processor collector: %w", err)
 	}
 
-	c.hypervisorVirtualProcessorTimeTotal = prometheus.NewDesc(
+	c.hypervisorVirtualProcessorTimeTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_virtual_processor_time_total"),
 		"DEPRECATED: use hypervisor_virtual_processor_mode_time_total. Time that processor spent in different modes (hypervisor, guest_run, guest_idle, remote)",
 		[]string{"vm", "core", "state"},
 		nil,
 	)
 	// New metric with better name for clarity, old one is kept for backward compatibility
-	c.hypervisorVirtualProcessorModeTimeTotal = prometheus.NewDesc(
+	c.hypervisorVirtualProcessorModeTimeTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_virtual_processor_mode_time_total"),
 		"Time that processor spent in different modes (hypervisor, guest_run, guest_idle, remote)",
 		[]string{"vm", "core", "state"},
 		nil,
 	)
 	// end same metric
-	c.hypervisorVirtualProcessorTotalRunTimeTotal = prometheus.NewDesc(
+	c.hypervisorVirtualProcessorTotalRunTimeTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_virtual_processor_total_run_time_total"),
 		"DEPRECATED: use hypervisor_virtual_processor_run_time_total. Time that processor spent",
 		[]string{"vm", "core"},
 		nil,
 	)
 	// New metric with better name for clarity, old one is kept for backward compatibility
-	c.hypervisorVirtualProcessorRunTimeTotal = prometheus.NewDesc(
+	c.hypervisorVirtualProcessorRunTimeTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_virtual_processor_run_time_total"),
 		"Time that processor spent",
 		[]string{"vm", "core"},
 		nil,
 	)
 	// end same metric
-	c.hypervisorVirtualProcessorContextSwitches = prometheus.NewDesc(
+	c.hypervisorVirtualProcessorContextSwitches = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hypervisor_virtual_processor_cpu_wait_time_per_dispatch_total"),
 		"The average time (in nanoseconds) spent waiting for a virtual processor to be dispatched onto a logical processor.",
 		[]string{"vm", "core"},
@@ -49,7 +49,7 @@ func (c *Collector) buildVirtualMachineHealthSummary() error {
 		return fmt.Errorf("failed to create Hyper-V Virtual Machine Health Summary collector: %w", err)
 	}
 
-	c.health = prometheus.NewDesc(
+	c.health = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_machine_health_total_count"),
 		"Represents the number of virtual machines with critical health",
 		[]string{"state"},
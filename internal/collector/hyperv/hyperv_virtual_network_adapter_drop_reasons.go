@@ -172,7 +172,7 @@ func (c *Collector) buildVirtualNetworkAdapterDropReasons() error {
 		return fmt.Errorf("failed to create Hyper-V Virtual Network Adapter Drop Reasons collector: %w", err)
 	}
 
-	c.virtualNetworkAdapterDropReasons = prometheus.NewDesc(
+	c.virtualNetworkAdapterDropReasons = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_network_adapter_drop_reasons"),
 		"Hyper-V Virtual Network Adapter Drop Reasons",
 		[]string{"adapter", "reason", "direction"},
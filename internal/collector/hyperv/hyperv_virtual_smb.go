@@ -79,103 +79,103 @@ func (c *Collector) buildVirtualSMB() error {
 		return fmt.Errorf("failed to create Hyper-V Virtual SMB collector: %w", err)
 	}
 
-	c.virtualSMBDirectMappedSections = prometheus.NewDesc(
+	c.virtualSMBDirectMappedSections = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_direct_mapped_sections"),
 		"Represents the number of direct-mapped sections in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBDirectMappedPages = prometheus.NewDesc(
+	c.virtualSMBDirectMappedPages = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_direct_mapped_pages"),
 		"Represents the number of direct-mapped pages in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBWriteBytesRDMA = prometheus.NewDesc(
+	c.virtualSMBWriteBytesRDMA = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_write_bytes_rdma"),
 		"Represents the number of bytes written per second using RDMA in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBWriteBytes = prometheus.NewDesc(
+	c.virtualSMBWriteBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_write_bytes"),
 		"Represents the number of bytes written per second in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBReadBytesRDMA = prometheus.NewDesc(
+	c.virtualSMBReadBytesRDMA = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_read_bytes_rdma"),
 		"Represents the number of bytes read per second using RDMA in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBReadBytes = prometheus.NewDesc(
+	c.virtualSMBReadBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_read_bytes"),
 		"Represents the number of bytes read per second in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBFlushRequests = prometheus.NewDesc(
+	c.virtualSMBFlushRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_flush_requests"),
 		"Represents the number of flush requests per second in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBWriteRequestsRDMA = prometheus.NewDesc(
+	c.virtualSMBWriteRequestsRDMA = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_write_requests_rdma"),
 		"Represents the number of write requests per second using RDMA in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBWriteRequests = prometheus.NewDesc(
+	c.virtualSMBWriteRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_write_requests"),
 		"Represents the number of write requests per second in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBReadRequestsRDMA = prometheus.NewDesc(
+	c.virtualSMBReadRequestsRDMA = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_read_requests_rdma"),
 		"Represents the number of read requests per second using RDMA in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBReadRequests = prometheus.NewDesc(
+	c.virtualSMBReadRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_read_requests"),
 		"Represents the number of read requests per second in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBCurrentPendingRequests = prometheus.NewDesc(
+	c.virtualSMBCurrentPendingRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_current_pending_requests"),
 		"Represents the current number of pending requests in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBCurrentOpenFileCount = prometheus.NewDesc(
+	c.virtualSMBCurrentOpenFileCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_current_open_file_count"),
 		"Represents the current number of open files in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBTreeConnectCount = prometheus.NewDesc(
+	c.virtualSMBTreeConnectCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_tree_connect_count"),
 		"Represents the number of tree connects in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBRequests = prometheus.NewDesc(
+	c.virtualSMBRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_requests"),
 		"Represents the number of requests per second in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBSentBytes = prometheus.NewDesc(
+	c.virtualSMBSentBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_sent_bytes"),
 		"Represents the number of bytes sent per second in the virtual SMB",
 		[]string{"instance"},
 		nil,
 	)
-	c.virtualSMBReceivedBytes = prometheus.NewDesc(
+	c.virtualSMBReceivedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_smb_received_bytes"),
 		"Represents the number of bytes received per second in the virtual SMB",
 		[]string{"instance"},
@@ -69,73 +69,73 @@ func (c *Collector) buildVirtualStorageDevice() error {
 		return fmt.Errorf("failed to create Hyper-V Virtual Storage Device collector: %w", err)
 	}
 
-	c.virtualStorageDeviceErrorCount = prometheus.NewDesc(
+	c.virtualStorageDeviceErrorCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_error_count_total"),
 		"Represents the total number of errors that have occurred on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceQueueLength = prometheus.NewDesc(
+	c.virtualStorageDeviceQueueLength = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_queue_length"),
 		"Represents the average queue length on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceReadBytes = prometheus.NewDesc(
+	c.virtualStorageDeviceReadBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_bytes_read"),
 		"Represents the total number of bytes that have been read on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceReadOperations = prometheus.NewDesc(
+	c.virtualStorageDeviceReadOperations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_operations_read_total"),
 		"Represents the total number of read operations that have occurred on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceWriteBytes = prometheus.NewDesc(
+	c.virtualStorageDeviceWriteBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_bytes_written"),
 		"Represents the total number of bytes that have been written on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceWriteOperations = prometheus.NewDesc(
+	c.virtualStorageDeviceWriteOperations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_operations_written_total"),
 		"Represents the total number of write operations that have occurred on this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceLatency = prometheus.NewDesc(
+	c.virtualStorageDeviceLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_latency_seconds"),
 		"Represents the average IO transfer latency for this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceThroughput = prometheus.NewDesc(
+	c.virtualStorageDeviceThroughput = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_throughput_total"),
 		"Represents the total number of 8KB IO transfers completed by this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceNormalizedThroughput = prometheus.NewDesc(
+	c.virtualStorageDeviceNormalizedThroughput = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_normalized_throughput"),
 		"Represents the average number of IO transfers completed by this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceLowerQueueLength = prometheus.NewDesc(
+	c.virtualStorageDeviceLowerQueueLength = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_lower_queue_length"),
 		"Represents the average queue length on the underlying storage subsystem for this device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceLowerLatency = prometheus.NewDesc(
+	c.virtualStorageDeviceLowerLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_storage_device_lower_latency_seconds"),
 		"Represents the average IO transfer latency on the underlying storage subsystem for this virtual device.",
 		[]string{"device"},
 		nil,
 	)
-	c.virtualStorageDeviceIOQuotaReplenishmentRate = prometheus.NewDesc(
+	c.virtualStorageDeviceIOQuotaReplenishmentRate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "io_quota_replenishment_rate"),
 		"Represents the IO quota replenishment rate for this virtual device.",
 		[]string{"device"},
@@ -51,6 +51,8 @@ var ConfigDefaults = Config{
 }
 
 type Collector struct {
+	types.DescRecorder
+
 	collectorWebService
 	collectorHttpServiceRequestQueues
 	collectorAppPoolWAS
@@ -168,7 +170,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.iisVersion = c.getIISVersion()
 
-	c.info = prometheus.NewDesc(
+	c.info = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"ISS information",
 		[]string{},
@@ -85,79 +85,79 @@ func (c *Collector) buildAppPoolWAS() error {
 	}
 
 	// APP_POOL_WAS
-	c.currentApplicationPoolState = prometheus.NewDesc(
+	c.currentApplicationPoolState = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_application_pool_state"),
 		"The current status of the application pool (1 - Uninitialized, 2 - Initialized, 3 - Running, 4 - Disabling, 5 - Disabled, 6 - Shutdown Pending, 7 - Delete Pending) (CurrentApplicationPoolState)",
 		[]string{"app", "state"},
 		nil,
 	)
-	c.currentApplicationPoolUptime = prometheus.NewDesc(
+	c.currentApplicationPoolUptime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_application_pool_start_time"),
 		"The unix timestamp for the application pool start time (CurrentApplicationPoolUptime)",
 		[]string{"app"},
 		nil,
 	)
-	c.currentWorkerProcesses = prometheus.NewDesc(
+	c.currentWorkerProcesses = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_worker_processes"),
 		"The current number of worker processes that are running in the application pool (CurrentWorkerProcesses)",
 		[]string{"app"},
 		nil,
 	)
-	c.maximumWorkerProcesses = prometheus.NewDesc(
+	c.maximumWorkerProcesses = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "maximum_worker_processes"),
 		"The maximum number of worker processes that have been created for the application pool since Windows Process Activation Service (WAS) started (MaximumWorkerProcesses)",
 		[]string{"app"},
 		nil,
 	)
-	c.recentWorkerProcessFailures = prometheus.NewDesc(
+	c.recentWorkerProcessFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "recent_worker_process_failures"),
 		"The number of times that worker processes for the application pool failed during the rapid-fail protection interval (RecentWorkerProcessFailures)",
 		[]string{"app"},
 		nil,
 	)
-	c.timeSinceLastWorkerProcessFailure = prometheus.NewDesc(
+	c.timeSinceLastWorkerProcessFailure = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "time_since_last_worker_process_failure"),
 		"The length of time, in seconds, since the last worker process failure occurred for the application pool (TimeSinceLastWorkerProcessFailure)",
 		[]string{"app"},
 		nil,
 	)
-	c.totalApplicationPoolRecycles = prometheus.NewDesc(
+	c.totalApplicationPoolRecycles = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "total_application_pool_recycles"),
 		"The number of times that the application pool has been recycled since Windows Process Activation Service (WAS) started (TotalApplicationPoolRecycles)",
 		[]string{"app"},
 		nil,
 	)
-	c.totalApplicationPoolUptime = prometheus.NewDesc(
+	c.totalApplicationPoolUptime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "total_application_pool_start_time"),
 		"The unix timestamp for the application pool of when the Windows Process Activation Service (WAS) started (TotalApplicationPoolUptime)",
 		[]string{"app"},
 		nil,
 	)
-	c.totalWorkerProcessesCreated = prometheus.NewDesc(
+	c.totalWorkerProcessesCreated = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "total_worker_processes_created"),
 		"The number of worker processes created for the application pool since Windows Process Activation Service (WAS) started (TotalWorkerProcessesCreated)",
 		[]string{"app"},
 		nil,
 	)
-	c.totalWorkerProcessFailures = prometheus.NewDesc(
+	c.totalWorkerProcessFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "total_worker_process_failures"),
 		"The number of times that worker processes have crashed since the application pool was started (TotalWorkerProcessFailures)",
 		[]string{"app"},
 		nil,
 	)
-	c.totalWorkerProcessPingFailures = prometheus.NewDesc(
+	c.totalWorkerProcessPingFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "total_worker_process_ping_failures"),
 		"The number of times that Windows Process Activation Service (WAS) did not receive a response to ping messages sent to a worker process (TotalWorkerProcessPingFailures)",
 		[]string{"app"},
 		nil,
 	)
-	c.totalWorkerProcessShutdownFailures = prometheus.NewDesc(
+	c.totalWorkerProcessShutdownFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "total_worker_process_shutdown_failures"),
 		"The number of times that Windows Process Activation Service (WAS) failed to shut down a worker process (TotalWorkerProcessShutdownFailures)",
 		[]string{"app"},
 		nil,
 	)
-	c.totalWorkerProcessStartupFailures = prometheus.NewDesc(
+	c.totalWorkerProcessStartupFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "total_worker_process_startup_failures"),
 		"The number of times that Windows Process Activation Service (WAS) failed to start a worker process (TotalWorkerProcessStartupFailures)",
 		[]string{"app"},
@@ -59,25 +59,25 @@ func (c *Collector) buildHttpServiceRequestQueues() error {
 		return fmt.Errorf("failed to create Http Service collector: %w", err)
 	}
 
-	c.httpRequestQueuesCurrentQueueSize = prometheus.NewDesc(
+	c.httpRequestQueuesCurrentQueueSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_requests_current_queue_size"),
 		"Http Request Current Queue Size",
 		[]string{"site"},
 		nil,
 	)
-	c.httpRequestQueuesTotalRejectedRequest = prometheus.NewDesc(
+	c.httpRequestQueuesTotalRejectedRequest = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_request_total_rejected_request"),
 		"Http Request Total Rejected Request",
 		[]string{"site"},
 		nil,
 	)
-	c.httpRequestQueuesMaxQueueItemAge = prometheus.NewDesc(
+	c.httpRequestQueuesMaxQueueItemAge = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_requests_max_queue_item_age"),
 		"Http Request Max Queue Item Age. The values might be bogus if the queue is empty.",
 		[]string{"site"},
 		nil,
 	)
-	c.httpRequestQueuesArrivalRate = prometheus.NewDesc(
+	c.httpRequestQueuesArrivalRate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_requests_arrival_rate"),
 		"Http Request Arrival Rate",
 		[]string{"site"},
@@ -109,169 +109,169 @@ func (c *Collector) buildWebServiceCache() error {
 	}
 
 	// Web Service Cache
-	c.serviceCacheActiveFlushedEntries = prometheus.NewDesc(
+	c.serviceCacheActiveFlushedEntries = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_cache_active_flushed_entries"),
 		"Number of file handles cached that will be closed when all current transfers complete.",
 		nil,
 		nil,
 	)
-	c.serviceCacheCurrentFileCacheMemoryUsage = prometheus.NewDesc(
+	c.serviceCacheCurrentFileCacheMemoryUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_file_cache_memory_bytes"),
 		"Current number of bytes used by file cache",
 		nil,
 		nil,
 	)
-	c.serviceCacheMaximumFileCacheMemoryUsage = prometheus.NewDesc(
+	c.serviceCacheMaximumFileCacheMemoryUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_file_cache_max_memory_bytes"),
 		"Maximum number of bytes used by file cache",
 		nil,
 		nil,
 	)
-	c.serviceCacheFileCacheFlushesTotal = prometheus.NewDesc(
+	c.serviceCacheFileCacheFlushesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_file_cache_flushes_total"),
 		"Total number of file cache flushes (since service startup)",
 		nil,
 		nil,
 	)
-	c.serviceCacheFileCacheQueriesTotal = prometheus.NewDesc(
+	c.serviceCacheFileCacheQueriesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_file_cache_queries_total"),
 		"Total number of file cache queries (hits + misses)",
 		nil,
 		nil,
 	)
-	c.serviceCacheFileCacheHitsTotal = prometheus.NewDesc(
+	c.serviceCacheFileCacheHitsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_file_cache_hits_total"),
 		"Total number of successful lookups in the user-mode file cache",
 		nil,
 		nil,
 	)
-	c.serviceCacheFilesCached = prometheus.NewDesc(
+	c.serviceCacheFilesCached = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_file_cache_items"),
 		"Current number of files whose contents are present in cache",
 		nil,
 		nil,
 	)
-	c.serviceCacheFilesCachedTotal = prometheus.NewDesc(
+	c.serviceCacheFilesCachedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_file_cache_items_total"),
 		"Total number of files whose contents were ever added to the cache (since service startup)",
 		nil,
 		nil,
 	)
-	c.serviceCacheFilesFlushedTotal = prometheus.NewDesc(
+	c.serviceCacheFilesFlushedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_file_cache_items_flushed_total"),
 		"Total number of file handles that have been removed from the cache (since service startup)",
 		nil,
 		nil,
 	)
-	c.serviceCacheURICacheFlushesTotal = prometheus.NewDesc(
+	c.serviceCacheURICacheFlushesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_uri_cache_flushes_total"),
 		"Total number of URI cache flushes (since service startup)",
 		[]string{"mode"},
 		nil,
 	)
-	c.serviceCacheURICacheQueriesTotal = prometheus.NewDesc(
+	c.serviceCacheURICacheQueriesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_uri_cache_queries_total"),
 		"Total number of uri cache queries (hits + misses)",
 		[]string{"mode"},
 		nil,
 	)
-	c.serviceCacheURICacheHitsTotal = prometheus.NewDesc(
+	c.serviceCacheURICacheHitsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_uri_cache_hits_total"),
 		"Total number of successful lookups in the URI cache (since service startup)",
 		[]string{"mode"},
 		nil,
 	)
-	c.serviceCacheURIsCached = prometheus.NewDesc(
+	c.serviceCacheURIsCached = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_uri_cache_items"),
 		"Number of URI information blocks currently in the cache",
 		[]string{"mode"},
 		nil,
 	)
-	c.serviceCacheURIsCachedTotal = prometheus.NewDesc(
+	c.serviceCacheURIsCachedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_uri_cache_items_total"),
 		"Total number of URI information blocks added to the cache (since service startup)",
 		[]string{"mode"},
 		nil,
 	)
-	c.serviceCacheURIsFlushedTotal = prometheus.NewDesc(
+	c.serviceCacheURIsFlushedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_uri_cache_items_flushed_total"),
 		"The number of URI information blocks that have been removed from the cache (since service startup)",
 		[]string{"mode"},
 		nil,
 	)
-	c.serviceCacheMetadataCached = prometheus.NewDesc(
+	c.serviceCacheMetadataCached = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_metadata_cache_items"),
 		"Number of metadata information blocks currently present in cache",
 		nil,
 		nil,
 	)
-	c.serviceCacheMetadataCacheFlushes = prometheus.NewDesc(
+	c.serviceCacheMetadataCacheFlushes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_metadata_cache_flushes_total"),
 		"Total number of metadata cache flushes (since service startup)",
 		nil,
 		nil,
 	)
-	c.serviceCacheMetadataCacheQueriesTotal = prometheus.NewDesc(
+	c.serviceCacheMetadataCacheQueriesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_metadata_cache_queries_total"),
 		"Total metadata cache queries (hits + misses)",
 		nil,
 		nil,
 	)
-	c.serviceCacheMetadataCacheHitsTotal = prometheus.NewDesc(
+	c.serviceCacheMetadataCacheHitsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_metadata_cache_hits_total"),
 		"Total number of successful lookups in the metadata cache (since service startup)",
 		nil,
 		nil,
 	)
-	c.serviceCacheMetadataCachedTotal = prometheus.NewDesc(
+	c.serviceCacheMetadataCachedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_metadata_cache_items_cached_total"),
 		"Total number of metadata information blocks added to the cache (since service startup)",
 		nil,
 		nil,
 	)
-	c.serviceCacheMetadataFlushedTotal = prometheus.NewDesc(
+	c.serviceCacheMetadataFlushedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_metadata_cache_items_flushed_total"),
 		"Total number of metadata information blocks removed from the cache (since service startup)",
 		nil,
 		nil,
 	)
-	c.serviceCacheOutputCacheActiveFlushedItems = prometheus.NewDesc(
+	c.serviceCacheOutputCacheActiveFlushedItems = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_output_cache_active_flushed_items"),
 		"",
 		nil,
 		nil,
 	)
-	c.serviceCacheOutputCacheItems = prometheus.NewDesc(
+	c.serviceCacheOutputCacheItems = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_output_cache_items"),
 		"Number of items current present in output cache",
 		nil,
 		nil,
 	)
-	c.serviceCacheOutputCacheMemoryUsage = prometheus.NewDesc(
+	c.serviceCacheOutputCacheMemoryUsage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_output_cache_memory_bytes"),
 		"Current number of bytes used by output cache",
 		nil,
 		nil,
 	)
-	c.serviceCacheOutputCacheQueriesTotal = prometheus.NewDesc(
+	c.serviceCacheOutputCacheQueriesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_output_cache_queries_total"),
 		"Total output cache queries (hits + misses)",
 		nil,
 		nil,
 	)
-	c.serviceCacheOutputCacheHitsTotal = prometheus.NewDesc(
+	c.serviceCacheOutputCacheHitsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_output_cache_hits_total"),
 		"Total number of successful lookups in output cache (since service startup)",
 		nil,
 		nil,
 	)
-	c.serviceCacheOutputCacheFlushedItemsTotal = prometheus.NewDesc(
+	c.serviceCacheOutputCacheFlushedItemsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_output_cache_items_flushed_total"),
 		"Total number of items flushed from output cache (since service startup)",
 		nil,
 		nil,
 	)
-	c.serviceCacheOutputCacheFlushesTotal = prometheus.NewDesc(
+	c.serviceCacheOutputCacheFlushesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_output_cache_flushes_total"),
 		"Total number of flushes of output cache (since service startup)",
 		nil,
@@ -46,6 +46,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for WMI Win32_PerfRawData_DNS_DNS metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	licenseStatus *prometheus.Desc
@@ -76,7 +78,7 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.licenseStatus = prometheus.NewDesc(
+	c.licenseStatus = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "status"),
 		"Status of windows license",
 		[]string{"state"},
@@ -65,6 +65,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus Collector for perflib logicalDisk metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 	logger *slog.Logger
 
@@ -200,131 +202,131 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		}
 	}
 
-	c.information = prometheus.NewDesc(
+	c.information = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"A metric with a constant '1' value labeled with logical disk information",
 		[]string{"disk", "type", "volume", "volume_name", "filesystem", "serial_number"},
 		nil,
 	)
-	c.readOnly = prometheus.NewDesc(
+	c.readOnly = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "readonly"),
 		"Whether the logical disk is read-only",
 		[]string{"volume"},
 		nil,
 	)
-	c.requestsQueued = prometheus.NewDesc(
+	c.requestsQueued = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "requests_queued"),
 		"The number of requests queued to the disk (LogicalDisk.CurrentDiskQueueLength)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.avgReadQueue = prometheus.NewDesc(
+	c.avgReadQueue = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "avg_read_requests_queued"),
 		"Average number of read requests that were queued for the selected disk during the sample interval (LogicalDisk.AvgDiskReadQueueLength)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.avgWriteQueue = prometheus.NewDesc(
+	c.avgWriteQueue = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "avg_write_requests_queued"),
 		"Average number of write requests that were queued for the selected disk during the sample interval (LogicalDisk.AvgDiskWriteQueueLength)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.readBytesTotal = prometheus.NewDesc(
+	c.readBytesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "read_bytes_total"),
 		"The number of bytes transferred from the disk during read operations (LogicalDisk.DiskReadBytesPerSec)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.readsTotal = prometheus.NewDesc(
+	c.readsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "reads_total"),
 		"The number of read operations on the disk (LogicalDisk.DiskReadsPerSec)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.writeBytesTotal = prometheus.NewDesc(
+	c.writeBytesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "write_bytes_total"),
 		"The number of bytes transferred to the disk during write operations (LogicalDisk.DiskWriteBytesPerSec)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.writesTotal = prometheus.NewDesc(
+	c.writesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "writes_total"),
 		"The number of write operations on the disk (LogicalDisk.DiskWritesPerSec)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.readTime = prometheus.NewDesc(
+	c.readTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "read_seconds_total"),
 		"Seconds that the disk was busy servicing read requests (LogicalDisk.PercentDiskReadTime)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.writeTime = prometheus.NewDesc(
+	c.writeTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "write_seconds_total"),
 		"Seconds that the disk was busy servicing write requests (LogicalDisk.PercentDiskWriteTime)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.freeSpace = prometheus.NewDesc(
+	c.freeSpace = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "free_bytes"),
 		"Free space in bytes, updates every 10-15 min (LogicalDisk.PercentFreeSpace)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.totalSpace = prometheus.NewDesc(
+	c.totalSpace = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "size_bytes"),
 		"Total space in bytes, updates every 10-15 min (LogicalDisk.PercentFreeSpace_Base)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.idleTime = prometheus.NewDesc(
+	c.idleTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "idle_seconds_total"),
 		"Seconds that the disk was idle (LogicalDisk.PercentIdleTime)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.splitIOs = prometheus.NewDesc(
+	c.splitIOs = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "split_ios_total"),
 		"The number of I/Os to the disk were split into multiple I/Os (LogicalDisk.SplitIOPerSec)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.readLatency = prometheus.NewDesc(
+	c.readLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "read_latency_seconds_total"),
 		"Shows the average time, in seconds, of a read operation from the disk (LogicalDisk.AvgDiskSecPerRead)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.writeLatency = prometheus.NewDesc(
+	c.writeLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "write_latency_seconds_total"),
 		"Shows the average time, in seconds, of a write operation to the disk (LogicalDisk.AvgDiskSecPerWrite)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.readWriteLatency = prometheus.NewDesc(
+	c.readWriteLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "read_write_latency_seconds_total"),
 		"Shows the time, in seconds, of the average disk transfer (LogicalDisk.AvgDiskSecPerTransfer)",
 		[]string{"volume"},
 		nil,
 	)
 
-	c.bitlockerStatus = prometheus.NewDesc(
+	c.bitlockerStatus = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bitlocker_status"),
 		"BitLocker status for the logical disk",
 		[]string{"volume", "status"},
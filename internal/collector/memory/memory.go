@@ -43,6 +43,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for perflib Memory metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -113,223 +115,223 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.availableBytes = prometheus.NewDesc(
+	c.availableBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "available_bytes"),
 		"The amount of physical memory immediately available for allocation to a process or for system use. It is equal to the sum of memory assigned to"+
 			" the standby (cached), free and zero page lists (AvailableBytes)",
 		nil,
 		nil,
 	)
-	c.cacheBytes = prometheus.NewDesc(
+	c.cacheBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cache_bytes"),
 		"(CacheBytes)",
 		nil,
 		nil,
 	)
-	c.cacheBytesPeak = prometheus.NewDesc(
+	c.cacheBytesPeak = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cache_bytes_peak"),
 		"(CacheBytesPeak)",
 		nil,
 		nil,
 	)
-	c.cacheFaultsTotal = prometheus.NewDesc(
+	c.cacheFaultsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cache_faults_total"),
 		"Number of faults which occur when a page sought in the file system cache is not found there and must be retrieved from elsewhere in memory (soft fault) "+
 			"or from disk (hard fault) (Cache Faults/sec)",
 		nil,
 		nil,
 	)
-	c.commitLimit = prometheus.NewDesc(
+	c.commitLimit = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "commit_limit"),
 		"(CommitLimit)",
 		nil,
 		nil,
 	)
-	c.committedBytes = prometheus.NewDesc(
+	c.committedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "committed_bytes"),
 		"(CommittedBytes)",
 		nil,
 		nil,
 	)
-	c.demandZeroFaultsTotal = prometheus.NewDesc(
+	c.demandZeroFaultsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "demand_zero_faults_total"),
 		"The number of zeroed pages required to satisfy faults. Zeroed pages, pages emptied of previously stored data and filled with zeros, are a security"+
 			" feature of Windows that prevent processes from seeing data stored by earlier processes that used the memory space (Demand Zero Faults/sec)",
 		nil,
 		nil,
 	)
-	c.freeAndZeroPageListBytes = prometheus.NewDesc(
+	c.freeAndZeroPageListBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "free_and_zero_page_list_bytes"),
 		"The amount of physical memory, in bytes, that is assigned to the free and zero page lists. This memory does not contain cached data. It is immediately"+
 			" available for allocation to a process or for system use (FreeAndZeroPageListBytes)",
 		nil,
 		nil,
 	)
-	c.freeSystemPageTableEntries = prometheus.NewDesc(
+	c.freeSystemPageTableEntries = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "free_system_page_table_entries"),
 		"(FreeSystemPageTableEntries)",
 		nil,
 		nil,
 	)
-	c.modifiedPageListBytes = prometheus.NewDesc(
+	c.modifiedPageListBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "modified_page_list_bytes"),
 		"The amount of physical memory, in bytes, that is assigned to the modified page list. This memory contains cached data and code that is not actively in "+
 			"use by processes, the system and the system cache (ModifiedPageListBytes)",
 		nil,
 		nil,
 	)
-	c.pageFaultsTotal = prometheus.NewDesc(
+	c.pageFaultsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "page_faults_total"),
 		"Overall rate at which faulted pages are handled by the processor (Page Faults/sec)",
 		nil,
 		nil,
 	)
-	c.swapPageReadsTotal = prometheus.NewDesc(
+	c.swapPageReadsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "swap_page_reads_total"),
 		"Number of disk page reads (a single read operation reading several pages is still only counted once) (PageReadsPerSec)",
 		nil,
 		nil,
 	)
-	c.swapPagesReadTotal = prometheus.NewDesc(
+	c.swapPagesReadTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "swap_pages_read_total"),
 		"Number of pages read across all page reads (ie counting all pages read even if they are read in a single operation) (PagesInputPerSec)",
 		nil,
 		nil,
 	)
-	c.swapPagesWrittenTotal = prometheus.NewDesc(
+	c.swapPagesWrittenTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "swap_pages_written_total"),
 		"Number of pages written across all page writes (ie counting all pages written even if they are written in a single operation) (PagesOutputPerSec)",
 		nil,
 		nil,
 	)
-	c.swapPageOperationsTotal = prometheus.NewDesc(
+	c.swapPageOperationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "swap_page_operations_total"),
 		"Total number of swap page read and writes (PagesPerSec)",
 		nil,
 		nil,
 	)
-	c.swapPageWritesTotal = prometheus.NewDesc(
+	c.swapPageWritesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "swap_page_writes_total"),
 		"Number of disk page writes (a single write operation writing several pages is still only counted once) (PageWritesPerSec)",
 		nil,
 		nil,
 	)
-	c.poolNonPagedAllocationsTotal = prometheus.NewDesc(
+	c.poolNonPagedAllocationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pool_nonpaged_allocs_total"),
 		"The number of calls to allocate space in the nonpaged pool. The nonpaged pool is an area of system memory area for objects that cannot be written"+
 			" to disk, and must remain in physical memory as long as they are allocated (PoolNonpagedAllocs)",
 		nil,
 		nil,
 	)
-	c.poolNonPagedBytes = prometheus.NewDesc(
+	c.poolNonPagedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pool_nonpaged_bytes"),
 		"Number of bytes in the non-paged pool, an area of the system virtual memory that is used for objects that cannot be written to disk, but must "+
 			"remain in physical memory as long as they are allocated (PoolNonpagedBytes)",
 		nil,
 		nil,
 	)
-	c.poolPagedAllocationsTotal = prometheus.NewDesc(
+	c.poolPagedAllocationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pool_paged_allocs_total"),
 		"Number of calls to allocate space in the paged pool, regardless of the amount of space allocated in each call (PoolPagedAllocs)",
 		nil,
 		nil,
 	)
-	c.poolPagedBytes = prometheus.NewDesc(
+	c.poolPagedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pool_paged_bytes"),
 		"(PoolPagedBytes)",
 		nil,
 		nil,
 	)
-	c.poolPagedResidentBytes = prometheus.NewDesc(
+	c.poolPagedResidentBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pool_paged_resident_bytes"),
 		"The size, in bytes, of the portion of the paged pool that is currently resident and active in physical memory. The paged pool is an area of the "+
 			"system virtual memory that is used for objects that can be written to disk when they are not being used (PoolPagedResidentBytes)",
 		nil,
 		nil,
 	)
-	c.standbyCacheCoreBytes = prometheus.NewDesc(
+	c.standbyCacheCoreBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "standby_cache_core_bytes"),
 		"The amount of physical memory, in bytes, that is assigned to the core standby cache page lists. This memory contains cached data and code that is "+
 			"not actively in use by processes, the system and the system cache (StandbyCacheCoreBytes)",
 		nil,
 		nil,
 	)
-	c.standbyCacheNormalPriorityBytes = prometheus.NewDesc(
+	c.standbyCacheNormalPriorityBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "standby_cache_normal_priority_bytes"),
 		"The amount of physical memory, in bytes, that is assigned to the normal priority standby cache page lists. This memory contains cached data and "+
 			"code that is not actively in use by processes, the system and the system cache (StandbyCacheNormalPriorityBytes)",
 		nil,
 		nil,
 	)
-	c.standbyCacheReserveBytes = prometheus.NewDesc(
+	c.standbyCacheReserveBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "standby_cache_reserve_bytes"),
 		"The amount of physical memory, in bytes, that is assigned to the reserve standby cache page lists. This memory contains cached data and code "+
 			"that is not actively in use by processes, the system and the system cache (StandbyCacheReserveBytes)",
 		nil,
 		nil,
 	)
-	c.systemCacheResidentBytes = prometheus.NewDesc(
+	c.systemCacheResidentBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "system_cache_resident_bytes"),
 		"The size, in bytes, of the portion of the system file cache which is currently resident and active in physical memory (SystemCacheResidentBytes)",
 		nil,
 		nil,
 	)
-	c.systemCodeResidentBytes = prometheus.NewDesc(
+	c.systemCodeResidentBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "system_code_resident_bytes"),
 		"The size, in bytes, of the pageable operating system code that is currently resident and active in physical memory (SystemCodeResidentBytes)",
 		nil,
 		nil,
 	)
-	c.systemCodeTotalBytes = prometheus.NewDesc(
+	c.systemCodeTotalBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "system_code_total_bytes"),
 		"The size, in bytes, of the pageable operating system code currently mapped into the system virtual address space (SystemCodeTotalBytes)",
 		nil,
 		nil,
 	)
-	c.systemDriverResidentBytes = prometheus.NewDesc(
+	c.systemDriverResidentBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "system_driver_resident_bytes"),
 		"The size, in bytes, of the pageable physical memory being used by device drivers. It is the working set (physical memory area) of the drivers (SystemDriverResidentBytes)",
 		nil,
 		nil,
 	)
-	c.systemDriverTotalBytes = prometheus.NewDesc(
+	c.systemDriverTotalBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "system_driver_total_bytes"),
 		"The size, in bytes, of the pageable virtual memory currently being used by device drivers. Pageable memory can be written to disk when it is not being used (SystemDriverTotalBytes)",
 		nil,
 		nil,
 	)
-	c.transitionFaultsTotal = prometheus.NewDesc(
+	c.transitionFaultsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transition_faults_total"),
 		"Number of faults rate at which page faults are resolved by recovering pages that were being used by another process sharing the page, or were on the "+
 			"modified page list or the standby list, or were being written to disk at the time of the page fault (TransitionFaultsPerSec)",
 		nil,
 		nil,
 	)
-	c.transitionPagesRepurposedTotal = prometheus.NewDesc(
+	c.transitionPagesRepurposedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transition_pages_repurposed_total"),
 		"Transition Pages RePurposed is the rate at which the number of transition cache pages were reused for a different purpose (TransitionPagesRePurposedPerSec)",
 		nil,
 		nil,
 	)
-	c.writeCopiesTotal = prometheus.NewDesc(
+	c.writeCopiesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "write_copies_total"),
 		"The number of page faults caused by attempting to write that were satisfied by copying the page from elsewhere in physical memory (WriteCopiesPerSec)",
 		nil,
 		nil,
 	)
-	c.processMemoryLimitBytes = prometheus.NewDesc(
+	c.processMemoryLimitBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process_memory_limit_bytes"),
 		"The size of the user-mode portion of the virtual address space of the calling process, in bytes. This value depends on the type of process, the type of processor, and the configuration of the operating system.",
 		nil,
 		nil,
 	)
-	c.physicalMemoryTotalBytes = prometheus.NewDesc(
+	c.physicalMemoryTotalBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "physical_total_bytes"),
 		"The amount of actual physical memory, in bytes.",
 		nil,
 		nil,
 	)
-	c.physicalMemoryFreeBytes = prometheus.NewDesc(
+	c.physicalMemoryFreeBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "physical_free_bytes"),
 		"The amount of physical memory currently available, in bytes. This is the amount of physical memory that can be immediately reused without having to write its contents to disk first. It is the sum of the size of the standby, free, and zero lists.",
 		nil,
@@ -18,6 +18,7 @@
 package mscluster
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -28,6 +29,8 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -62,6 +65,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus Collector for WMI MSCluster_Cluster metrics.
 type Collector struct {
+	types.DescRecorder
+
 	collectorCluster
 	collectorNetwork
 	collectorNode
@@ -181,6 +186,14 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.CollectWithContext(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but cancels the WMI queries once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
 	if len(c.config.CollectorsEnabled) == 0 {
 		return nil
 	}
@@ -194,7 +207,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 		defer wg.Done()
 
 		if slices.Contains(c.config.CollectorsEnabled, subCollectorCluster) {
-			if err := c.collectCluster(ch, maxScrapeDuration); err != nil {
+			if err := c.collectCluster(ctx, ch); err != nil {
 				errCh <- fmt.Errorf("failed to collect cluster metrics: %w", err)
 			}
 		}
@@ -204,7 +217,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 		defer wg.Done()
 
 		if slices.Contains(c.config.CollectorsEnabled, subCollectorNetwork) {
-			if err := c.collectNetwork(ch, maxScrapeDuration); err != nil {
+			if err := c.collectNetwork(ctx, ch); err != nil {
 				errCh <- fmt.Errorf("failed to collect network metrics: %w", err)
 			}
 		}
@@ -218,7 +231,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 		if slices.Contains(c.config.CollectorsEnabled, subCollectorNode) {
 			var err error
 
-			nodeNames, err = c.collectNode(ch, maxScrapeDuration)
+			nodeNames, err = c.collectNode(ctx, ch)
 			if err != nil {
 				errCh <- fmt.Errorf("failed to collect node metrics: %w", err)
 			}
@@ -228,7 +241,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 			defer wg.Done()
 
 			if slices.Contains(c.config.CollectorsEnabled, subCollectorResource) {
-				if err := c.collectResource(ch, maxScrapeDuration, nodeNames); err != nil {
+				if err := c.collectResource(ctx, ch, nodeNames); err != nil {
 					errCh <- fmt.Errorf("failed to collect resource metrics: %w", err)
 				}
 			}
@@ -238,7 +251,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 			defer wg.Done()
 
 			if slices.Contains(c.config.CollectorsEnabled, subCollectorResourceGroup) {
-				if err := c.collectResourceGroup(ch, maxScrapeDuration, nodeNames); err != nil {
+				if err := c.collectResourceGroup(ctx, ch, nodeNames); err != nil {
 					errCh <- fmt.Errorf("failed to collect resource group metrics: %w", err)
 				}
 			}
@@ -249,7 +262,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 		defer wg.Done()
 
 		if slices.Contains(c.config.CollectorsEnabled, subCollectorSharedVolumes) {
-			if err := c.collectSharedVolumes(ch, maxScrapeDuration); err != nil {
+			if err := c.collectSharedVolumes(ctx, ch); err != nil {
 				errCh <- fmt.Errorf("failed to collect shared_volumes metrics: %w", err)
 			}
 		}
@@ -259,7 +272,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 		defer wg.Done()
 
 		if slices.Contains(c.config.CollectorsEnabled, subCollectorVirtualDisk) {
-			if err := c.collectVirtualDisk(ch, maxScrapeDuration); err != nil {
+			if err := c.collectVirtualDisk(ctx, ch); err != nil {
 				errCh <- fmt.Errorf("failed to collect virtualdisk metrics: %w", err)
 			}
 		}
@@ -18,8 +18,8 @@
 package mscluster
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
@@ -210,463 +210,463 @@ func (c *Collector) buildCluster() error {
 
 	c.clusterMIQuery = clusterMIQuery
 
-	c.clusterAddEvictDelay = prometheus.NewDesc(
+	c.clusterAddEvictDelay = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "add_evict_delay"),
 		"Provides access to the cluster's AddEvictDelay property, which is the number a seconds that a new node is delayed after an eviction of another node.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterAdminAccessPoint = prometheus.NewDesc(
+	c.clusterAdminAccessPoint = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "admin_access_point"),
 		"The type of the cluster administrative access point.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterAutoAssignNodeSite = prometheus.NewDesc(
+	c.clusterAutoAssignNodeSite = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "auto_assign_node_site"),
 		"Determines whether or not the cluster will attempt to automatically assign nodes to sites based on networks and Active Directory Site information.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterAutoBalancerLevel = prometheus.NewDesc(
+	c.clusterAutoBalancerLevel = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "auto_balancer_level"),
 		"Determines the level of aggressiveness of AutoBalancer.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterAutoBalancerMode = prometheus.NewDesc(
+	c.clusterAutoBalancerMode = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "auto_balancer_mode"),
 		"Determines whether or not the auto balancer is enabled.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterBackupInProgress = prometheus.NewDesc(
+	c.clusterBackupInProgress = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "backup_in_progress"),
 		"Indicates whether a backup is in progress.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterBlockCacheSize = prometheus.NewDesc(
+	c.clusterBlockCacheSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "block_cache_size"),
 		"CSV BlockCache Size in MB.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusSvcHangTimeout = prometheus.NewDesc(
+	c.clusterClusSvcHangTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "clus_svc_hang_timeout"),
 		"Controls how long the cluster network driver waits between Failover Cluster Service heartbeats before it determines that the Failover Cluster Service has stopped responding.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusSvcRegroupOpeningTimeout = prometheus.NewDesc(
+	c.clusterClusSvcRegroupOpeningTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "clus_svc_regroup_opening_timeout"),
 		"Controls how long a node will wait on other nodes in the opening stage before deciding that they failed.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusSvcRegroupPruningTimeout = prometheus.NewDesc(
+	c.clusterClusSvcRegroupPruningTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "clus_svc_regroup_pruning_timeout"),
 		"Controls how long the membership leader will wait to reach full connectivity between cluster nodes.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusSvcRegroupStageTimeout = prometheus.NewDesc(
+	c.clusterClusSvcRegroupStageTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "clus_svc_regroup_stage_timeout"),
 		"Controls how long a node will wait on other nodes in a membership stage before deciding that they failed.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusSvcRegroupTickInMilliseconds = prometheus.NewDesc(
+	c.clusterClusSvcRegroupTickInMilliseconds = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "clus_svc_regroup_tick_in_milliseconds"),
 		"Controls how frequently the membership algorithm is sending periodic membership messages.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusterEnforcedAntiAffinity = prometheus.NewDesc(
+	c.clusterClusterEnforcedAntiAffinity = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "cluster_enforced_anti_affinity"),
 		"Enables or disables hard enforcement of group anti-affinity classes.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusterFunctionalLevel = prometheus.NewDesc(
+	c.clusterClusterFunctionalLevel = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "cluster_functional_level"),
 		"The functional level the cluster is currently running in.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusterGroupWaitDelay = prometheus.NewDesc(
+	c.clusterClusterGroupWaitDelay = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "cluster_group_wait_delay"),
 		"Maximum time in seconds that a group waits for its preferred node to come online during cluster startup before coming online on a different node.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusterLogLevel = prometheus.NewDesc(
+	c.clusterClusterLogLevel = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "cluster_log_level"),
 		"Controls the level of cluster logging.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusterLogSize = prometheus.NewDesc(
+	c.clusterClusterLogSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "cluster_log_size"),
 		"Controls the maximum size of the cluster log files on each of the nodes.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterClusterUpgradeVersion = prometheus.NewDesc(
+	c.clusterClusterUpgradeVersion = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "cluster_upgrade_version"),
 		"Specifies the upgrade version the cluster is currently running in.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterCrossSiteDelay = prometheus.NewDesc(
+	c.clusterCrossSiteDelay = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "cross_site_delay"),
 		"Controls how long the cluster network driver waits in milliseconds between sending Cluster Service heartbeats across sites.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterCrossSiteThreshold = prometheus.NewDesc(
+	c.clusterCrossSiteThreshold = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "cross_site_threshold"),
 		"Controls how many Cluster Service heartbeats can be missed across sites before it determines that Cluster Service has stopped responding.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterCrossSubnetDelay = prometheus.NewDesc(
+	c.clusterCrossSubnetDelay = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "cross_subnet_delay"),
 		"Controls how long the cluster network driver waits in milliseconds between sending Cluster Service heartbeats across subnets.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterCrossSubnetThreshold = prometheus.NewDesc(
+	c.clusterCrossSubnetThreshold = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "cross_subnet_threshold"),
 		"Controls how many Cluster Service heartbeats can be missed across subnets before it determines that Cluster Service has stopped responding.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterCsvBalancer = prometheus.NewDesc(
+	c.clusterCsvBalancer = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "csv_balancer"),
 		"Whether automatic balancing for CSV is enabled.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterDatabaseReadWriteMode = prometheus.NewDesc(
+	c.clusterDatabaseReadWriteMode = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "database_read_write_mode"),
 		"Sets the database read and write mode.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterDefaultNetworkRole = prometheus.NewDesc(
+	c.clusterDefaultNetworkRole = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "default_network_role"),
 		"Provides access to the cluster's DefaultNetworkRole property.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterDetectedCloudPlatform = prometheus.NewDesc(
+	c.clusterDetectedCloudPlatform = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "detected_cloud_platform"),
 		"(DetectedCloudPlatform)",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterDetectManagedEvents = prometheus.NewDesc(
+	c.clusterDetectManagedEvents = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "detect_managed_events"),
 		"(DetectManagedEvents)",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterDetectManagedEventsThreshold = prometheus.NewDesc(
+	c.clusterDetectManagedEventsThreshold = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "detect_managed_events_threshold"),
 		"(DetectManagedEventsThreshold)",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterDisableGroupPreferredOwnerRandomization = prometheus.NewDesc(
+	c.clusterDisableGroupPreferredOwnerRandomization = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "disable_group_preferred_owner_randomization"),
 		"(DisableGroupPreferredOwnerRandomization)",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterDrainOnShutdown = prometheus.NewDesc(
+	c.clusterDrainOnShutdown = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "drain_on_shutdown"),
 		"Whether to drain the node when cluster service is being stopped.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterDynamicQuorumEnabled = prometheus.NewDesc(
+	c.clusterDynamicQuorumEnabled = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "dynamic_quorum_enabled"),
 		"Allows cluster service to adjust node weights as needed to increase availability.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterEnableSharedVolumes = prometheus.NewDesc(
+	c.clusterEnableSharedVolumes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "enable_shared_volumes"),
 		"Enables or disables cluster shared volumes on this cluster.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterFixQuorum = prometheus.NewDesc(
+	c.clusterFixQuorum = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "fix_quorum"),
 		"Provides access to the cluster's FixQuorum property, which specifies if the cluster is in a fix quorum state.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterGracePeriodEnabled = prometheus.NewDesc(
+	c.clusterGracePeriodEnabled = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "grace_period_enabled"),
 		"Whether the node grace period feature of this cluster is enabled.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterGracePeriodTimeout = prometheus.NewDesc(
+	c.clusterGracePeriodTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "grace_period_timeout"),
 		"The grace period timeout in milliseconds.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterGroupDependencyTimeout = prometheus.NewDesc(
+	c.clusterGroupDependencyTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "group_dependency_timeout"),
 		"The timeout after which a group will be brought online despite unsatisfied dependencies",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterHangRecoveryAction = prometheus.NewDesc(
+	c.clusterHangRecoveryAction = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "hang_recovery_action"),
 		"Controls the action to take if the user-mode processes have stopped responding.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterIgnorePersistentStateOnStartup = prometheus.NewDesc(
+	c.clusterIgnorePersistentStateOnStartup = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "ignore_persistent_state_on_startup"),
 		"Provides access to the cluster's IgnorePersistentStateOnStartup property, which specifies whether the cluster will bring online groups that were online when the cluster was shut down.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterLogResourceControls = prometheus.NewDesc(
+	c.clusterLogResourceControls = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "log_resource_controls"),
 		"Controls the logging of resource controls.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterLowerQuorumPriorityNodeId = prometheus.NewDesc(
+	c.clusterLowerQuorumPriorityNodeId = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "lower_quorum_priority_node_id"),
 		"Specifies the Node ID that has a lower priority when voting for quorum is performed. If the quorum vote is split 50/50%, the specified node's vote would be ignored to break the tie. If this is not set then the cluster will pick a node at random to break the tie.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterMaxNumberOfNodes = prometheus.NewDesc(
+	c.clusterMaxNumberOfNodes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "max_number_of_nodes"),
 		"Indicates the maximum number of nodes that may participate in the Cluster.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterMessageBufferLength = prometheus.NewDesc(
+	c.clusterMessageBufferLength = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "message_buffer_length"),
 		"The maximum unacknowledged message count for GEM.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterMinimumNeverPreemptPriority = prometheus.NewDesc(
+	c.clusterMinimumNeverPreemptPriority = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "minimum_never_preempt_priority"),
 		"Groups with this priority or higher cannot be preempted.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterMinimumPreemptorPriority = prometheus.NewDesc(
+	c.clusterMinimumPreemptorPriority = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "minimum_preemptor_priority"),
 		"Minimum priority a cluster group must have to be able to preempt another group.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterNetftIPSecEnabled = prometheus.NewDesc(
+	c.clusterNetftIPSecEnabled = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "netft_ip_sec_enabled"),
 		"Whether IPSec is enabled for cluster internal traffic.cluster",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterPlacementOptions = prometheus.NewDesc(
+	c.clusterPlacementOptions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "placement_options"),
 		"Various option flags to modify default placement behavior.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterPlumbAllCrossSubnetRoutes = prometheus.NewDesc(
+	c.clusterPlumbAllCrossSubnetRoutes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "plumb_all_cross_subnet_routes"),
 		"Plumbs all possible cross subnet routes to all nodes.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterPreventQuorum = prometheus.NewDesc(
+	c.clusterPreventQuorum = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "prevent_quorum"),
 		"Whether the cluster will ignore group persistent state on startup.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterQuarantineDuration = prometheus.NewDesc(
+	c.clusterQuarantineDuration = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "quarantine_duration"),
 		"The quarantine period timeout in milliseconds.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterQuarantineThreshold = prometheus.NewDesc(
+	c.clusterQuarantineThreshold = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "quarantine_threshold"),
 		"Number of node failures before it will be quarantined.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterQuorumArbitrationTimeMax = prometheus.NewDesc(
+	c.clusterQuorumArbitrationTimeMax = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "quorum_arbitration_time_max"),
 		"Controls the maximum time necessary to decide the Quorum owner node.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterQuorumArbitrationTimeMin = prometheus.NewDesc(
+	c.clusterQuorumArbitrationTimeMin = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "quorum_arbitration_time_min"),
 		"Controls the minimum time necessary to decide the Quorum owner node.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterQuorumLogFileSize = prometheus.NewDesc(
+	c.clusterQuorumLogFileSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "quorum_log_file_size"),
 		"This property is obsolete.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterQuorumTypeValue = prometheus.NewDesc(
+	c.clusterQuorumTypeValue = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "quorum_type_value"),
 		"Get the current quorum type value. -1: Unknown; 1: Node; 2: FileShareWitness; 3: Storage; 4: None",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterRequestReplyTimeout = prometheus.NewDesc(
+	c.clusterRequestReplyTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "request_reply_timeout"),
 		"Controls the request reply time-out period.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterResiliencyDefaultPeriod = prometheus.NewDesc(
+	c.clusterResiliencyDefaultPeriod = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "resiliency_default_period"),
 		"The default resiliency period, in seconds, for the cluster.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterResiliencyLevel = prometheus.NewDesc(
+	c.clusterResiliencyLevel = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "resiliency_level"),
 		"The resiliency level for the cluster.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterResourceDllDeadlockPeriod = prometheus.NewDesc(
+	c.clusterResourceDllDeadlockPeriod = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "resource_dll_deadlock_period"),
 		"This property is obsolete.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterRootMemoryReserved = prometheus.NewDesc(
+	c.clusterRootMemoryReserved = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "root_memory_reserved"),
 		"Controls the amount of memory reserved for the parent partition on all cluster nodes.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterRouteHistoryLength = prometheus.NewDesc(
+	c.clusterRouteHistoryLength = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "route_history_length"),
 		"The history length for routes to help finding network issues.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterS2DBusTypes = prometheus.NewDesc(
+	c.clusterS2DBusTypes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "s2d_bus_types"),
 		"Bus types for storage spaces direct.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterS2DCacheDesiredState = prometheus.NewDesc(
+	c.clusterS2DCacheDesiredState = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "s2d_cache_desired_state"),
 		"Desired state of the storage spaces direct cache.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterS2DCacheFlashReservePercent = prometheus.NewDesc(
+	c.clusterS2DCacheFlashReservePercent = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "s2d_cache_flash_reserve_percent"),
 		"Percentage of allocated flash space to utilize when caching.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterS2DCachePageSizeKBytes = prometheus.NewDesc(
+	c.clusterS2DCachePageSizeKBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "s2d_cache_page_size_k_bytes"),
 		"Page size in KB used by S2D cache.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterS2DEnabled = prometheus.NewDesc(
+	c.clusterS2DEnabled = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "s2d_enabled"),
 		"Whether direct attached storage (DAS) is enabled.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterS2DIOLatencyThreshold = prometheus.NewDesc(
+	c.clusterS2DIOLatencyThreshold = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "s2dio_latency_threshold"),
 		"The I/O latency threshold for storage spaces direct.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterS2DOptimizations = prometheus.NewDesc(
+	c.clusterS2DOptimizations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "s2d_optimizations"),
 		"Optimization flags for storage spaces direct.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterSameSubnetDelay = prometheus.NewDesc(
+	c.clusterSameSubnetDelay = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "same_subnet_delay"),
 		"Controls how long the cluster network driver waits in milliseconds between sending Cluster Service heartbeats on the same subnet.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterSameSubnetThreshold = prometheus.NewDesc(
+	c.clusterSameSubnetThreshold = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "same_subnet_threshold"),
 		"Controls how many Cluster Service heartbeats can be missed on the same subnet before it determines that Cluster Service has stopped responding.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterSecurityLevel = prometheus.NewDesc(
+	c.clusterSecurityLevel = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "security_level"),
 		"Controls the level of security that should apply to intracluster messages. 0: Clear Text; 1: Sign; 2: Encrypt ",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterSecurityLevelForStorage = prometheus.NewDesc(
+	c.clusterSecurityLevelForStorage = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "security_level_for_storage"),
 		"(SecurityLevelForStorage)",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterSharedVolumeVssWriterOperationTimeout = prometheus.NewDesc(
+	c.clusterSharedVolumeVssWriterOperationTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "shared_volume_vss_writer_operation_timeout"),
 		"CSV VSS Writer operation timeout in seconds.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterShutdownTimeoutInMinutes = prometheus.NewDesc(
+	c.clusterShutdownTimeoutInMinutes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "shutdown_timeout_in_minutes"),
 		"The maximum time in minutes allowed for cluster resources to come offline during cluster service shutdown.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterUseClientAccessNetworksForSharedVolumes = prometheus.NewDesc(
+	c.clusterUseClientAccessNetworksForSharedVolumes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "use_client_access_networks_for_shared_volumes"),
 		"Whether the use of client access networks for cluster shared volumes feature of this cluster is enabled. 0: Disabled; 1: Enabled; 2: Auto",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterWitnessDatabaseWriteTimeout = prometheus.NewDesc(
+	c.clusterWitnessDatabaseWriteTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "witness_database_write_timeout"),
 		"Controls the maximum time in seconds that a cluster database write to a witness can take before the write is abandoned.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterWitnessDynamicWeight = prometheus.NewDesc(
+	c.clusterWitnessDynamicWeight = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "witness_dynamic_weight"),
 		"The weight of the configured witness.",
 		[]string{"name"},
 		nil,
 	)
-	c.clusterWitnessRestartInterval = prometheus.NewDesc(
+	c.clusterWitnessRestartInterval = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "witness_restart_interval"),
 		"Controls the witness restart interval.",
 		[]string{"name"},
@@ -681,9 +681,9 @@ func (c *Collector) buildCluster() error {
 	return nil
 }
 
-func (c *Collector) collectCluster(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+func (c *Collector) collectCluster(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []msClusterCluster
-	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.clusterMIQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootMSCluster, c.clusterMIQuery); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
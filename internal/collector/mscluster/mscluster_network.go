@@ -18,8 +18,8 @@
 package mscluster
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -58,31 +58,31 @@ func (c *Collector) buildNetwork() error {
 
 	c.networkMIQuery = networkMIQuery
 
-	c.networkCharacteristics = prometheus.NewDesc(
+	c.networkCharacteristics = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNetwork, "characteristics"),
 		"Provides the characteristics of the network.",
 		[]string{"name"},
 		nil,
 	)
-	c.networkFlags = prometheus.NewDesc(
+	c.networkFlags = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNetwork, "flags"),
 		"Provides access to the flags set for the node. ",
 		[]string{"name"},
 		nil,
 	)
-	c.networkMetric = prometheus.NewDesc(
+	c.networkMetric = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNetwork, "metric"),
 		"The metric of a cluster network (networks with lower values are used first). If this value is set, then the AutoMetric property is set to false.",
 		[]string{"name"},
 		nil,
 	)
-	c.networkRole = prometheus.NewDesc(
+	c.networkRole = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNetwork, "role"),
 		"Provides access to the network's Role property. The Role property describes the role of the network in the cluster. 0: None; 1: Cluster; 2: Client; 3: Both ",
 		[]string{"name"},
 		nil,
 	)
-	c.networkState = prometheus.NewDesc(
+	c.networkState = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNetwork, "state"),
 		"Provides the current state of the network. 1-1: Unknown; 0: Unavailable; 1: Down; 2: Partitioned; 3: Up",
 		[]string{"name"},
@@ -100,10 +100,10 @@ func (c *Collector) buildNetwork() error {
 
 // Collect sends the metric values for each metric
 // to the provided prometheus metric channel.
-func (c *Collector) collectNetwork(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+func (c *Collector) collectNetwork(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []msClusterNetwork
 
-	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.networkMIQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootMSCluster, c.networkMIQuery); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
@@ -18,8 +18,8 @@
 package mscluster
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
@@ -84,85 +84,85 @@ func (c *Collector) buildNode() error {
 
 	c.nodeMIQuery = nodeMIQuery
 
-	c.nodeBuildNumber = prometheus.NewDesc(
+	c.nodeBuildNumber = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "build_number"),
 		"Provides access to the node's BuildNumber property.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeCharacteristics = prometheus.NewDesc(
+	c.nodeCharacteristics = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "characteristics"),
 		"Provides access to the characteristics set for the node.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeDetectedCloudPlatform = prometheus.NewDesc(
+	c.nodeDetectedCloudPlatform = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "detected_cloud_platform"),
 		"(DetectedCloudPlatform)",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeDynamicWeight = prometheus.NewDesc(
+	c.nodeDynamicWeight = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "dynamic_weight"),
 		"The dynamic vote weight of the node adjusted by dynamic quorum feature.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeFlags = prometheus.NewDesc(
+	c.nodeFlags = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "flags"),
 		"Provides access to the flags set for the node.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeMajorVersion = prometheus.NewDesc(
+	c.nodeMajorVersion = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "major_version"),
 		"Provides access to the node's MajorVersion property, which specifies the major portion of the Windows version installed.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeMinorVersion = prometheus.NewDesc(
+	c.nodeMinorVersion = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "minor_version"),
 		"Provides access to the node's MinorVersion property, which specifies the minor portion of the Windows version installed.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeNeedsPreventQuorum = prometheus.NewDesc(
+	c.nodeNeedsPreventQuorum = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "needs_prevent_quorum"),
 		"Whether the cluster service on that node should be started with prevent quorum flag.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeNodeDrainStatus = prometheus.NewDesc(
+	c.nodeNodeDrainStatus = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "node_drain_status"),
 		"The current node drain status of a node. 0: Not Initiated; 1: In Progress; 2: Completed; 3: Failed",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeNodeHighestVersion = prometheus.NewDesc(
+	c.nodeNodeHighestVersion = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "node_highest_version"),
 		"Provides access to the node's NodeHighestVersion property, which specifies the highest possible version of the cluster service with which the node can join or communicate.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeNodeLowestVersion = prometheus.NewDesc(
+	c.nodeNodeLowestVersion = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "node_lowest_version"),
 		"Provides access to the node's NodeLowestVersion property, which specifies the lowest possible version of the cluster service with which the node can join or communicate.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeNodeWeight = prometheus.NewDesc(
+	c.nodeNodeWeight = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "node_weight"),
 		"The vote weight of the node.",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeState = prometheus.NewDesc(
+	c.nodeState = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "state"),
 		"Returns the current state of a node. -1: Unknown; 0: Up; 1: Down; 2: Paused; 3: Joining",
 		[]string{"name"},
 		nil,
 	)
-	c.nodeStatusInformation = prometheus.NewDesc(
+	c.nodeStatusInformation = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameNode, "status_information"),
 		"The isolation or quarantine status of the node.",
 		[]string{"name"},
@@ -180,10 +180,10 @@ func (c *Collector) buildNode() error {
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
-func (c *Collector) collectNode(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) ([]string, error) {
+func (c *Collector) collectNode(ctx context.Context, ch chan<- prometheus.Metric) ([]string, error) {
 	var dst []msClusterNode
 
-	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.nodeMIQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootMSCluster, c.nodeMIQuery); err != nil {
 		return nil, fmt.Errorf("WMI query failed: %w", err)
 	}
 
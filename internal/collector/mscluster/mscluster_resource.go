@@ -18,8 +18,8 @@
 package mscluster
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -84,109 +84,109 @@ func (c *Collector) buildResource() error {
 
 	c.resourceMIQuery = resourceMIQuery
 
-	c.resourceCharacteristics = prometheus.NewDesc(
+	c.resourceCharacteristics = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "characteristics"),
 		"Provides the characteristics of the object.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceDeadlockTimeout = prometheus.NewDesc(
+	c.resourceDeadlockTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "deadlock_timeout"),
 		"Indicates the length of time to wait, in milliseconds, before declaring a deadlock in any call into a resource.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceEmbeddedFailureAction = prometheus.NewDesc(
+	c.resourceEmbeddedFailureAction = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "embedded_failure_action"),
 		"The time, in milliseconds, that a resource should remain in a failed state before the Cluster service attempts to restart it.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceFlags = prometheus.NewDesc(
+	c.resourceFlags = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "flags"),
 		"Provides access to the flags set for the object.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceIsAlivePollInterval = prometheus.NewDesc(
+	c.resourceIsAlivePollInterval = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "is_alive_poll_interval"),
 		"Provides access to the resource's IsAlivePollInterval property, which is the recommended interval in milliseconds at which the Cluster Service should poll the resource to determine whether it is operational. If the property is set to 0xFFFFFFFF, the Cluster Service uses the IsAlivePollInterval property for the resource type associated with the resource.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceLooksAlivePollInterval = prometheus.NewDesc(
+	c.resourceLooksAlivePollInterval = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "looks_alive_poll_interval"),
 		"Provides access to the resource's LooksAlivePollInterval property, which is the recommended interval in milliseconds at which the Cluster Service should poll the resource to determine whether it appears operational. If the property is set to 0xFFFFFFFF, the Cluster Service uses the LooksAlivePollInterval property for the resource type associated with the resource.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceMonitorProcessId = prometheus.NewDesc(
+	c.resourceMonitorProcessId = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "monitor_process_id"),
 		"Provides the process ID of the resource host service that is currently hosting the resource.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceOwnerNode = prometheus.NewDesc(
+	c.resourceOwnerNode = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "owner_node"),
 		"The node hosting the resource. 0: Not hosted; 1: Hosted",
 		[]string{"type", "owner_group", "node_name", "name"},
 		nil,
 	)
-	c.resourceOwnerNode = prometheus.NewDesc(
+	c.resourceOwnerNode = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "owner_node"),
 		"The node hosting the resource. 0: Not hosted; 1: Hosted",
 		[]string{"type", "owner_group", "node_name", "name"},
 		nil,
 	)
-	c.resourcePendingTimeout = prometheus.NewDesc(
+	c.resourcePendingTimeout = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "pending_timeout"),
 		"Provides access to the resource's PendingTimeout property. If a resource cannot be brought online or taken offline in the number of milliseconds specified by the PendingTimeout property, the resource is forcibly terminated.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceResourceClass = prometheus.NewDesc(
+	c.resourceResourceClass = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "resource_class"),
 		"Gets or sets the resource class of a resource. 0: Unknown; 1: Storage; 2: Network; 32768: Unknown ",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceRestartAction = prometheus.NewDesc(
+	c.resourceRestartAction = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "restart_action"),
 		"Provides access to the resource's RestartAction property, which is the action to be taken by the Cluster Service if the resource fails.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceRestartDelay = prometheus.NewDesc(
+	c.resourceRestartDelay = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "restart_delay"),
 		"Indicates the time delay before a failed resource is restarted.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceRestartPeriod = prometheus.NewDesc(
+	c.resourceRestartPeriod = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "restart_period"),
 		"Provides access to the resource's RestartPeriod property, which is interval of time, in milliseconds, during which a specified number of restart attempts can be made on a nonresponsive resource.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceRestartThreshold = prometheus.NewDesc(
+	c.resourceRestartThreshold = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "restart_threshold"),
 		"Provides access to the resource's RestartThreshold property which is the maximum number of restart attempts that can be made on a resource within an interval defined by the RestartPeriod property before the Cluster Service initiates the action specified by the RestartAction property.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceRetryPeriodOnFailure = prometheus.NewDesc(
+	c.resourceRetryPeriodOnFailure = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "retry_period_on_failure"),
 		"Provides access to the resource's RetryPeriodOnFailure property, which is the interval of time (in milliseconds) that a resource should remain in a failed state before the Cluster service attempts to restart it.",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceState = prometheus.NewDesc(
+	c.resourceState = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "state"),
 		"The current state of the resource. -1: Unknown; 0: Inherited; 1: Initializing; 2: Online; 3: Offline; 4: Failed; 128: Pending; 129: Online Pending; 130: Offline Pending ",
 		[]string{"type", "owner_group", "name"},
 		nil,
 	)
-	c.resourceSubClass = prometheus.NewDesc(
+	c.resourceSubClass = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResource, "subclass"),
 		"Provides the list of references to nodes that can be the owner of this resource.",
 		[]string{"type", "owner_group", "name"},
@@ -204,10 +204,10 @@ func (c *Collector) buildResource() error {
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
-func (c *Collector) collectResource(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration, nodeNames []string) error {
+func (c *Collector) collectResource(ctx context.Context, ch chan<- prometheus.Metric, nodeNames []string) error {
 	var dst []msClusterResource
 
-	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.resourceMIQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootMSCluster, c.resourceMIQuery); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
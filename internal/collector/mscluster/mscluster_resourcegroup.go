@@ -18,8 +18,8 @@
 package mscluster
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -76,91 +76,91 @@ func (c *Collector) buildResourceGroup() error {
 
 	c.resourceGroupMIQuery = resourceGroupMIQuery
 
-	c.resourceGroupAutoFailbackType = prometheus.NewDesc(
+	c.resourceGroupAutoFailbackType = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "auto_failback_type"),
 		"Provides access to the group's AutoFailbackType property.",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupCharacteristics = prometheus.NewDesc(
+	c.resourceGroupCharacteristics = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "characteristics"),
 		"Provides the characteristics of the group.",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupColdStartSetting = prometheus.NewDesc(
+	c.resourceGroupColdStartSetting = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "cold_start_setting"),
 		"Indicates whether a group can start after a cluster cold start.",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupDefaultOwner = prometheus.NewDesc(
+	c.resourceGroupDefaultOwner = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "default_owner"),
 		"Number of the last node the resource group was activated on or explicitly moved to.",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupFailbackWindowEnd = prometheus.NewDesc(
+	c.resourceGroupFailbackWindowEnd = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "failback_window_end"),
 		"The FailbackWindowEnd property provides the latest time that the group can be moved back to the node identified as its preferred node.",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupFailbackWindowStart = prometheus.NewDesc(
+	c.resourceGroupFailbackWindowStart = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "failback_window_start"),
 		"The FailbackWindowStart property provides the earliest time (that is, local time as kept by the cluster) that the group can be moved back to the node identified as its preferred node.",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupFailOverPeriod = prometheus.NewDesc(
+	c.resourceGroupFailOverPeriod = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "failover_period"),
 		"The FailoverPeriod property specifies a number of hours during which a maximum number of failover attempts, specified by the FailoverThreshold property, can occur.",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupFailOverThreshold = prometheus.NewDesc(
+	c.resourceGroupFailOverThreshold = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "failover_threshold"),
 		"The FailoverThreshold property specifies the maximum number of failover attempts.",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupFlags = prometheus.NewDesc(
+	c.resourceGroupFlags = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "flags"),
 		"Provides access to the flags set for the group. ",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupGroupType = prometheus.NewDesc(
+	c.resourceGroupGroupType = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "group_type"),
 		"The Type of the resource group.",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupOwnerNode = prometheus.NewDesc(
+	c.resourceGroupOwnerNode = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "owner_node"),
 		"The node hosting the resource group. 0: Not hosted; 1: Hosted",
 		[]string{"node_name", "name"},
 		nil,
 	)
-	c.resourceGroupOwnerNode = prometheus.NewDesc(
+	c.resourceGroupOwnerNode = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "owner_node"),
 		"The node hosting the resource group. 0: Not hosted; 1: Hosted",
 		[]string{"node_name", "name"},
 		nil,
 	)
-	c.resourceGroupPriority = prometheus.NewDesc(
+	c.resourceGroupPriority = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "priority"),
 		"Priority value of the resource group",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupResiliencyPeriod = prometheus.NewDesc(
+	c.resourceGroupResiliencyPeriod = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "resiliency_period"),
 		"The resiliency period for this group, in seconds.",
 		[]string{"name"},
 		nil,
 	)
-	c.resourceGroupState = prometheus.NewDesc(
+	c.resourceGroupState = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameResourceGroup, "state"),
 		"The current state of the resource group. -1: Unknown; 0: Online; 1: Offline; 2: Failed; 3: Partial Online; 4: Pending",
 		[]string{"name"},
@@ -178,10 +178,10 @@ func (c *Collector) buildResourceGroup() error {
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
-func (c *Collector) collectResourceGroup(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration, nodeNames []string) error {
+func (c *Collector) collectResourceGroup(ctx context.Context, ch chan<- prometheus.Metric, nodeNames []string) error {
 	var dst []msClusterResourceGroup
 
-	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.resourceGroupMIQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootMSCluster, c.resourceGroupMIQuery); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
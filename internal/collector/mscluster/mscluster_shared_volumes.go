@@ -18,9 +18,9 @@
 package mscluster
 
 import (
+	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -55,21 +55,21 @@ func (c *Collector) buildSharedVolumes() error {
 
 	c.sharedVolumesMIQuery = sharedVolumesMIQuery
 
-	c.sharedVolumesInfo = prometheus.NewDesc(
+	c.sharedVolumesInfo = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameSharedVolumes, "info"),
 		"Cluster Shared Volumes information (value is always 1)",
 		[]string{"name", "path", "volume_guid"},
 		nil,
 	)
 
-	c.sharedVolumesTotalSize = prometheus.NewDesc(
+	c.sharedVolumesTotalSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameSharedVolumes, "total_bytes"),
 		"Total size of the Cluster Shared Volume in bytes",
 		[]string{"name", "volume_guid"},
 		nil,
 	)
 
-	c.sharedVolumesFreeSpace = prometheus.NewDesc(
+	c.sharedVolumesFreeSpace = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameSharedVolumes, "free_bytes"),
 		"Free space on the Cluster Shared Volume in bytes",
 		[]string{"name", "volume_guid"},
@@ -84,9 +84,9 @@ func (c *Collector) buildSharedVolumes() error {
 	return nil
 }
 
-func (c *Collector) collectSharedVolumes(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+func (c *Collector) collectSharedVolumes(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []msClusterDiskPartition
-	if err := c.miSession.Query(&dst, mi.NamespaceRootMSCluster, c.sharedVolumesMIQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootMSCluster, c.sharedVolumesMIQuery); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
@@ -18,8 +18,8 @@
 package mscluster
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -60,42 +60,42 @@ func (c *Collector) buildVirtualDisk() error {
 
 	c.virtualDiskMIQuery = virtualDiskMIQuery
 
-	c.virtualDiskInfo = prometheus.NewDesc(
+	c.virtualDiskInfo = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameVirtualDisk, "info"),
 		"Virtual Disk information (value is always 1)",
 		[]string{"name", "unique_id"},
 		nil,
 	)
 
-	c.virtualDiskHealthStatus = prometheus.NewDesc(
+	c.virtualDiskHealthStatus = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameVirtualDisk, "health_status"),
 		"Health status of the virtual disk. 0: Healthy, 1: Warning, 2: Unhealthy, 5: Unknown",
 		[]string{"name", "unique_id"},
 		nil,
 	)
 
-	c.virtualDiskSize = prometheus.NewDesc(
+	c.virtualDiskSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameVirtualDisk, "size_bytes"),
 		"Total size of the virtual disk in bytes",
 		[]string{"name", "unique_id"},
 		nil,
 	)
 
-	c.virtualDiskAllocatedSize = prometheus.NewDesc(
+	c.virtualDiskAllocatedSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameVirtualDisk, "allocated_size_bytes"),
 		"Allocated size of the virtual disk in bytes (data actually provisioned, excludes thin-provisioned unused capacity)",
 		[]string{"name", "unique_id"},
 		nil,
 	)
 
-	c.virtualDiskFootprintOnPool = prometheus.NewDesc(
+	c.virtualDiskFootprintOnPool = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameVirtualDisk, "footprint_on_pool_bytes"),
 		"Physical storage consumed by the virtual disk on the storage pool in bytes",
 		[]string{"name", "unique_id"},
 		nil,
 	)
 
-	c.virtualDiskStorageEfficiency = prometheus.NewDesc(
+	c.virtualDiskStorageEfficiency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameVirtualDisk, "storage_efficiency_percent"),
 		"Storage efficiency percentage (AllocatedSize / FootprintOnPool * 100)",
 		[]string{"name", "unique_id"},
@@ -111,10 +111,10 @@ func (c *Collector) buildVirtualDisk() error {
 	return nil
 }
 
-func (c *Collector) collectVirtualDisk(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+func (c *Collector) collectVirtualDisk(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []msftVirtualDisk
 
-	if err := c.miSession.Query(&dst, mi.NamespaceRootStorage, c.virtualDiskMIQuery, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootStorage, c.virtualDiskMIQuery); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
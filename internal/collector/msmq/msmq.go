@@ -38,6 +38,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for WMI Win32_PerfRawData_MSMQ_MSMQQueue metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config            Config
 	perfDataCollector *pdh.Collector
 	perfDataObject    []perfDataCounterValues
@@ -77,25 +79,25 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.bytesInJournalQueue = prometheus.NewDesc(
+	c.bytesInJournalQueue = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_in_journal_queue"),
 		"Size of queue journal in bytes",
 		[]string{"name"},
 		nil,
 	)
-	c.bytesInQueue = prometheus.NewDesc(
+	c.bytesInQueue = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_in_queue"),
 		"Size of queue in bytes",
 		[]string{"name"},
 		nil,
 	)
-	c.messagesInJournalQueue = prometheus.NewDesc(
+	c.messagesInJournalQueue = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_in_journal_queue"),
 		"Count messages in queue journal",
 		[]string{"name"},
 		nil,
 	)
-	c.messagesInQueue = prometheus.NewDesc(
+	c.messagesInQueue = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "messages_in_queue"),
 		"Count messages in queue",
 		[]string{"name"},
@@ -78,6 +78,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus Collector for various WMI Win32_PerfRawData_MSSQLSERVER_* metrics.
 type Collector struct {
+	types.DescRecorder
+
 	collectorAccessMethods
 	collectorAvailabilityReplica
 	collectorBufferManager
@@ -256,13 +258,13 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		c.closeFns = append(c.closeFns, subCollectors[name].close)
 	}
 
-	c.mssqlScrapeDurationDesc = prometheus.NewDesc(
+	c.mssqlScrapeDurationDesc = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "collector_duration_seconds"),
 		"windows_exporter: Duration of an mssql child collection.",
 		[]string{"collector", "mssql_instance"},
 		nil,
 	)
-	c.mssqlScrapeSuccessDesc = prometheus.NewDesc(
+	c.mssqlScrapeSuccessDesc = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "collector_success"),
 		"windows_exporter: Whether a mssql child collector was successful.",
 		[]string{"collector", "mssql_instance"},
@@ -137,265 +137,265 @@ func (c *Collector) buildAccessMethods() error {
 	}
 
 	// Win32_PerfRawData_{instance}_SQLServerAccessMethods
-	c.accessMethodsAUcleanupbatches = prometheus.NewDesc(
+	c.accessMethodsAUcleanupbatches = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_au_batch_cleanups"),
 		"(AccessMethods.AUcleanupbatches)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsAUcleanups = prometheus.NewDesc(
+	c.accessMethodsAUcleanups = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_au_cleanups"),
 		"(AccessMethods.AUcleanups)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsByReferenceLobCreateCount = prometheus.NewDesc(
+	c.accessMethodsByReferenceLobCreateCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_by_reference_lob_creates"),
 		"(AccessMethods.ByreferenceLobCreateCount)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsByReferenceLobUseCount = prometheus.NewDesc(
+	c.accessMethodsByReferenceLobUseCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_by_reference_lob_uses"),
 		"(AccessMethods.ByreferenceLobUseCount)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsCountLobReadahead = prometheus.NewDesc(
+	c.accessMethodsCountLobReadahead = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_lob_read_aheads"),
 		"(AccessMethods.CountLobReadahead)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsCountPullInRow = prometheus.NewDesc(
+	c.accessMethodsCountPullInRow = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_column_value_pulls"),
 		"(AccessMethods.CountPullInRow)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsCountPushOffRow = prometheus.NewDesc(
+	c.accessMethodsCountPushOffRow = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_column_value_pushes"),
 		"(AccessMethods.CountPushOffRow)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsDeferreddroppedAUs = prometheus.NewDesc(
+	c.accessMethodsDeferreddroppedAUs = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_deferred_dropped_aus"),
 		"(AccessMethods.DeferreddroppedAUs)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsDeferredDroppedrowsets = prometheus.NewDesc(
+	c.accessMethodsDeferredDroppedrowsets = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_deferred_dropped_rowsets"),
 		"(AccessMethods.DeferredDroppedrowsets)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsDroppedrowsetcleanups = prometheus.NewDesc(
+	c.accessMethodsDroppedrowsetcleanups = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_dropped_rowset_cleanups"),
 		"(AccessMethods.Droppedrowsetcleanups)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsDroppedrowsetsskipped = prometheus.NewDesc(
+	c.accessMethodsDroppedrowsetsskipped = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_dropped_rowset_skips"),
 		"(AccessMethods.Droppedrowsetsskipped)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsExtentDeallocations = prometheus.NewDesc(
+	c.accessMethodsExtentDeallocations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_extent_deallocations"),
 		"(AccessMethods.ExtentDeallocations)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsExtentsAllocated = prometheus.NewDesc(
+	c.accessMethodsExtentsAllocated = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_extent_allocations"),
 		"(AccessMethods.ExtentsAllocated)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsFailedAUcleanupbatches = prometheus.NewDesc(
+	c.accessMethodsFailedAUcleanupbatches = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_au_batch_cleanup_failures"),
 		"(AccessMethods.FailedAUcleanupbatches)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsFailedleafpagecookie = prometheus.NewDesc(
+	c.accessMethodsFailedleafpagecookie = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_leaf_page_cookie_failures"),
 		"(AccessMethods.Failedleafpagecookie)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsFailedtreepagecookie = prometheus.NewDesc(
+	c.accessMethodsFailedtreepagecookie = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_tree_page_cookie_failures"),
 		"(AccessMethods.Failedtreepagecookie)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsForwardedRecords = prometheus.NewDesc(
+	c.accessMethodsForwardedRecords = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_forwarded_records"),
 		"(AccessMethods.ForwardedRecords)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsFreeSpacePageFetches = prometheus.NewDesc(
+	c.accessMethodsFreeSpacePageFetches = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_free_space_page_fetches"),
 		"(AccessMethods.FreeSpacePageFetches)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsFreeSpaceScans = prometheus.NewDesc(
+	c.accessMethodsFreeSpaceScans = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_free_space_scans"),
 		"(AccessMethods.FreeSpaceScans)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsFullScans = prometheus.NewDesc(
+	c.accessMethodsFullScans = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_full_scans"),
 		"(AccessMethods.FullScans)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsIndexSearches = prometheus.NewDesc(
+	c.accessMethodsIndexSearches = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_index_searches"),
 		"(AccessMethods.IndexSearches)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsInSysXactwaits = prometheus.NewDesc(
+	c.accessMethodsInSysXactwaits = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_insysxact_waits"),
 		"(AccessMethods.InSysXactwaits)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsLobHandleCreateCount = prometheus.NewDesc(
+	c.accessMethodsLobHandleCreateCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_lob_handle_creates"),
 		"(AccessMethods.LobHandleCreateCount)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsLobHandleDestroyCount = prometheus.NewDesc(
+	c.accessMethodsLobHandleDestroyCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_lob_handle_destroys"),
 		"(AccessMethods.LobHandleDestroyCount)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsLobSSProviderCreateCount = prometheus.NewDesc(
+	c.accessMethodsLobSSProviderCreateCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_lob_ss_provider_creates"),
 		"(AccessMethods.LobSSProviderCreateCount)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsLobSSProviderDestroyCount = prometheus.NewDesc(
+	c.accessMethodsLobSSProviderDestroyCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_lob_ss_provider_destroys"),
 		"(AccessMethods.LobSSProviderDestroyCount)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsLobSSProviderTruncationCount = prometheus.NewDesc(
+	c.accessMethodsLobSSProviderTruncationCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_lob_ss_provider_truncations"),
 		"(AccessMethods.LobSSProviderTruncationCount)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsMixedPageAllocations = prometheus.NewDesc(
+	c.accessMethodsMixedPageAllocations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_mixed_page_allocations"),
 		"(AccessMethods.MixedpageallocationsPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsPageCompressionAttempts = prometheus.NewDesc(
+	c.accessMethodsPageCompressionAttempts = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_page_compression_attempts"),
 		"(AccessMethods.PagecompressionattemptsPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsPageDeallocations = prometheus.NewDesc(
+	c.accessMethodsPageDeallocations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_page_deallocations"),
 		"(AccessMethods.PageDeallocationsPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsPagesAllocated = prometheus.NewDesc(
+	c.accessMethodsPagesAllocated = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_page_allocations"),
 		"(AccessMethods.PagesAllocatedPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsPagesCompressed = prometheus.NewDesc(
+	c.accessMethodsPagesCompressed = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_page_compressions"),
 		"(AccessMethods.PagescompressedPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsPageSplits = prometheus.NewDesc(
+	c.accessMethodsPageSplits = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_page_splits"),
 		"(AccessMethods.PageSplitsPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsProbeScans = prometheus.NewDesc(
+	c.accessMethodsProbeScans = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_probe_scans"),
 		"(AccessMethods.ProbeScansPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsRangeScans = prometheus.NewDesc(
+	c.accessMethodsRangeScans = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_range_scans"),
 		"(AccessMethods.RangeScansPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsScanPointRevalidations = prometheus.NewDesc(
+	c.accessMethodsScanPointRevalidations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_scan_point_revalidations"),
 		"(AccessMethods.ScanPointRevalidationsPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsSkippedGhostedRecords = prometheus.NewDesc(
+	c.accessMethodsSkippedGhostedRecords = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_ghost_record_skips"),
 		"(AccessMethods.SkippedGhostedRecordsPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsTableLockEscalations = prometheus.NewDesc(
+	c.accessMethodsTableLockEscalations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_table_lock_escalations"),
 		"(AccessMethods.TableLockEscalationsPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsUsedleafpagecookie = prometheus.NewDesc(
+	c.accessMethodsUsedleafpagecookie = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_leaf_page_cookie_uses"),
 		"(AccessMethods.Usedleafpagecookie)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsUsedtreepagecookie = prometheus.NewDesc(
+	c.accessMethodsUsedtreepagecookie = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_tree_page_cookie_uses"),
 		"(AccessMethods.Usedtreepagecookie)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsWorkfilesCreated = prometheus.NewDesc(
+	c.accessMethodsWorkfilesCreated = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_workfile_creates"),
 		"(AccessMethods.WorkfilesCreatedPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsWorktablesCreated = prometheus.NewDesc(
+	c.accessMethodsWorktablesCreated = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_worktables_creates"),
 		"(AccessMethods.WorktablesCreatedPersec)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsWorktablesFromCacheHits = prometheus.NewDesc(
+	c.accessMethodsWorktablesFromCacheHits = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_worktables_from_cache_hits"),
 		"(AccessMethods.WorktablesFromCacheRatio)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.accessMethodsWorktablesFromCacheLookups = prometheus.NewDesc(
+	c.accessMethodsWorktablesFromCacheLookups = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accessmethods_worktables_from_cache_lookups"),
 		"(AccessMethods.WorktablesFromCacheRatio_Base)",
 		[]string{"mssql_instance"},
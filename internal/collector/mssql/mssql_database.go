@@ -161,289 +161,289 @@ func (c *Collector) buildDatabases() error {
 		}
 	}
 
-	c.databasesActiveParallelRedoThreads = prometheus.NewDesc(
+	c.databasesActiveParallelRedoThreads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_active_parallel_redo_threads"),
 		"(Databases.ActiveParallelredothreads)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesActiveTransactions = prometheus.NewDesc(
+	c.databasesActiveTransactions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_active_transactions"),
 		"(Databases.ActiveTransactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesBackupPerRestoreThroughput = prometheus.NewDesc(
+	c.databasesBackupPerRestoreThroughput = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_backup_restore_operations"),
 		"(Databases.BackupPerRestoreThroughput)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesBulkCopyRows = prometheus.NewDesc(
+	c.databasesBulkCopyRows = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_bulk_copy_rows"),
 		"(Databases.BulkCopyRows)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesBulkCopyThroughput = prometheus.NewDesc(
+	c.databasesBulkCopyThroughput = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_bulk_copy_bytes"),
 		"(Databases.BulkCopyThroughput)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesCommitTableEntries = prometheus.NewDesc(
+	c.databasesCommitTableEntries = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_commit_table_entries"),
 		"(Databases.Committableentries)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesDataFilesSizeKB = prometheus.NewDesc(
+	c.databasesDataFilesSizeKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_data_files_size_bytes"),
 		"(Databases.DataFilesSizeKB)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesDBCCLogicalScanBytes = prometheus.NewDesc(
+	c.databasesDBCCLogicalScanBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_dbcc_logical_scan_bytes"),
 		"(Databases.DBCCLogicalScanBytes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesGroupCommitTime = prometheus.NewDesc(
+	c.databasesGroupCommitTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_group_commit_stall_seconds"),
 		"(Databases.GroupCommitTime)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogBytesFlushed = prometheus.NewDesc(
+	c.databasesLogBytesFlushed = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_flushed_bytes"),
 		"(Databases.LogBytesFlushed)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogCacheHits = prometheus.NewDesc(
+	c.databasesLogCacheHits = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_cache_hits"),
 		"(Databases.LogCacheHitRatio)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogCacheLookups = prometheus.NewDesc(
+	c.databasesLogCacheLookups = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_cache_lookups"),
 		"(Databases.LogCacheHitRatio_Base)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogCacheReads = prometheus.NewDesc(
+	c.databasesLogCacheReads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_cache_reads"),
 		"(Databases.LogCacheReads)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFilesSizeKB = prometheus.NewDesc(
+	c.databasesLogFilesSizeKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_files_size_bytes"),
 		"(Databases.LogFilesSizeKB)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFilesUsedSizeKB = prometheus.NewDesc(
+	c.databasesLogFilesUsedSizeKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_files_used_size_bytes"),
 		"(Databases.LogFilesUsedSizeKB)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFlushes = prometheus.NewDesc(
+	c.databasesLogFlushes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_flushes"),
 		"(Databases.LogFlushes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFlushWaits = prometheus.NewDesc(
+	c.databasesLogFlushWaits = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_flush_waits"),
 		"(Databases.LogFlushWaits)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFlushWaitTime = prometheus.NewDesc(
+	c.databasesLogFlushWaitTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_flush_wait_seconds"),
 		"(Databases.LogFlushWaitTime)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogFlushWriteTimeMS = prometheus.NewDesc(
+	c.databasesLogFlushWriteTimeMS = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_flush_write_seconds"),
 		"(Databases.LogFlushWriteTimems)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogGrowths = prometheus.NewDesc(
+	c.databasesLogGrowths = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_growths"),
 		"(Databases.LogGrowths)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolCacheMisses = prometheus.NewDesc(
+	c.databasesLogPoolCacheMisses = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_cache_misses"),
 		"(Databases.LogPoolCacheMisses)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolDiskReads = prometheus.NewDesc(
+	c.databasesLogPoolDiskReads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_disk_reads"),
 		"(Databases.LogPoolDiskReads)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolHashDeletes = prometheus.NewDesc(
+	c.databasesLogPoolHashDeletes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_hash_deletes"),
 		"(Databases.LogPoolHashDeletes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolHashInserts = prometheus.NewDesc(
+	c.databasesLogPoolHashInserts = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_hash_inserts"),
 		"(Databases.LogPoolHashInserts)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolInvalidHashEntry = prometheus.NewDesc(
+	c.databasesLogPoolInvalidHashEntry = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_invalid_hash_entries"),
 		"(Databases.LogPoolInvalidHashEntry)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolLogScanPushes = prometheus.NewDesc(
+	c.databasesLogPoolLogScanPushes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_log_scan_pushes"),
 		"(Databases.LogPoolLogScanPushes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolLogWriterPushes = prometheus.NewDesc(
+	c.databasesLogPoolLogWriterPushes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_log_writer_pushes"),
 		"(Databases.LogPoolLogWriterPushes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolPushEmptyFreePool = prometheus.NewDesc(
+	c.databasesLogPoolPushEmptyFreePool = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_empty_free_pool_pushes"),
 		"(Databases.LogPoolPushEmptyFreePool)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolPushLowMemory = prometheus.NewDesc(
+	c.databasesLogPoolPushLowMemory = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_low_memory_pushes"),
 		"(Databases.LogPoolPushLowMemory)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolPushNoFreeBuffer = prometheus.NewDesc(
+	c.databasesLogPoolPushNoFreeBuffer = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_no_free_buffer_pushes"),
 		"(Databases.LogPoolPushNoFreeBuffer)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolReqBehindTrunc = prometheus.NewDesc(
+	c.databasesLogPoolReqBehindTrunc = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_req_behind_trunc"),
 		"(Databases.LogPoolReqBehindTrunc)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolRequestsOldVLF = prometheus.NewDesc(
+	c.databasesLogPoolRequestsOldVLF = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_requests_old_vlf"),
 		"(Databases.LogPoolRequestsOldVLF)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolRequests = prometheus.NewDesc(
+	c.databasesLogPoolRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_requests"),
 		"(Databases.LogPoolRequests)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolTotalActiveLogSize = prometheus.NewDesc(
+	c.databasesLogPoolTotalActiveLogSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_total_active_log_bytes"),
 		"(Databases.LogPoolTotalActiveLogSize)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogPoolTotalSharedPoolSize = prometheus.NewDesc(
+	c.databasesLogPoolTotalSharedPoolSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_pool_total_shared_pool_bytes"),
 		"(Databases.LogPoolTotalSharedPoolSize)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogShrinks = prometheus.NewDesc(
+	c.databasesLogShrinks = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_shrinks"),
 		"(Databases.LogShrinks)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesLogTruncations = prometheus.NewDesc(
+	c.databasesLogTruncations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_truncations"),
 		"(Databases.LogTruncations)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesPercentLogUsed = prometheus.NewDesc(
+	c.databasesPercentLogUsed = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_log_used_percent"),
 		"(Databases.PercentLogUsed)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesReplPendingXacts = prometheus.NewDesc(
+	c.databasesReplPendingXacts = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_pending_repl_transactions"),
 		"(Databases.ReplPendingTransactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesReplTransRate = prometheus.NewDesc(
+	c.databasesReplTransRate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_repl_transactions"),
 		"(Databases.ReplTranactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesShrinkDataMovementBytes = prometheus.NewDesc(
+	c.databasesShrinkDataMovementBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_shrink_data_movement_bytes"),
 		"(Databases.ShrinkDataMovementBytes)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesTrackedTransactions = prometheus.NewDesc(
+	c.databasesTrackedTransactions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_tracked_transactions"),
 		"(Databases.Trackedtransactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesTransactions = prometheus.NewDesc(
+	c.databasesTransactions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_transactions"),
 		"(Databases.Transactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesWriteTransactions = prometheus.NewDesc(
+	c.databasesWriteTransactions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_write_transactions"),
 		"(Databases.WriteTransactions)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesXTPControllerDLCLatencyPerFetch = prometheus.NewDesc(
+	c.databasesXTPControllerDLCLatencyPerFetch = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_xtp_controller_dlc_fetch_latency_seconds"),
 		"(Databases.XTPControllerDLCLatencyPerFetch)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesXTPControllerDLCPeakLatency = prometheus.NewDesc(
+	c.databasesXTPControllerDLCPeakLatency = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_xtp_controller_dlc_peak_latency_seconds"),
 		"(Databases.XTPControllerDLCPeakLatency)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesXTPControllerLogProcessed = prometheus.NewDesc(
+	c.databasesXTPControllerLogProcessed = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_xtp_controller_log_processed_bytes"),
 		"(Databases.XTPControllerLogProcessed)",
 		[]string{"mssql_instance", "database"},
 		nil,
 	)
-	c.databasesXTPMemoryUsedKB = prometheus.NewDesc(
+	c.databasesXTPMemoryUsedKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "databases_xtp_memory_used_bytes"),
 		"(Databases.XTPMemoryUsedKB)",
 		[]string{"mssql_instance", "database"},
@@ -97,145 +97,145 @@ func (c *Collector) buildGeneralStatistics() error {
 	}
 
 	// Win32_PerfRawData_{instance}_SQLServerGeneralStatistics
-	c.genStatsActiveTempTables = prometheus.NewDesc(
+	c.genStatsActiveTempTables = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_active_temp_tables"),
 		"(GeneralStatistics.ActiveTempTables)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsConnectionReset = prometheus.NewDesc(
+	c.genStatsConnectionReset = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_connection_resets"),
 		"(GeneralStatistics.ConnectionReset)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsEventNotificationsDelayedDrop = prometheus.NewDesc(
+	c.genStatsEventNotificationsDelayedDrop = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_event_notifications_delayed_drop"),
 		"(GeneralStatistics.EventNotificationsDelayedDrop)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsHTTPAuthenticatedRequests = prometheus.NewDesc(
+	c.genStatsHTTPAuthenticatedRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_http_authenticated_requests"),
 		"(GeneralStatistics.HTTPAuthenticatedRequests)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsLogicalConnections = prometheus.NewDesc(
+	c.genStatsLogicalConnections = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_logical_connections"),
 		"(GeneralStatistics.LogicalConnections)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsLogins = prometheus.NewDesc(
+	c.genStatsLogins = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_logins"),
 		"(GeneralStatistics.Logins)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsLogouts = prometheus.NewDesc(
+	c.genStatsLogouts = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_logouts"),
 		"(GeneralStatistics.Logouts)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsMarsDeadlocks = prometheus.NewDesc(
+	c.genStatsMarsDeadlocks = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_mars_deadlocks"),
 		"(GeneralStatistics.MarsDeadlocks)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsNonAtomicYieldRate = prometheus.NewDesc(
+	c.genStatsNonAtomicYieldRate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_non_atomic_yields"),
 		"(GeneralStatistics.Nonatomicyields)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsProcessesBlocked = prometheus.NewDesc(
+	c.genStatsProcessesBlocked = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_blocked_processes"),
 		"(GeneralStatistics.Processesblocked)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsSOAPEmptyRequests = prometheus.NewDesc(
+	c.genStatsSOAPEmptyRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_soap_empty_requests"),
 		"(GeneralStatistics.SOAPEmptyRequests)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsSOAPMethodInvocations = prometheus.NewDesc(
+	c.genStatsSOAPMethodInvocations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_soap_method_invocations"),
 		"(GeneralStatistics.SOAPMethodInvocations)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsSOAPSessionInitiateRequests = prometheus.NewDesc(
+	c.genStatsSOAPSessionInitiateRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_soap_session_initiate_requests"),
 		"(GeneralStatistics.SOAPSessionInitiateRequests)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsSOAPSessionTerminateRequests = prometheus.NewDesc(
+	c.genStatsSOAPSessionTerminateRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_soap_session_terminate_requests"),
 		"(GeneralStatistics.SOAPSessionTerminateRequests)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsSOAPSQLRequests = prometheus.NewDesc(
+	c.genStatsSOAPSQLRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_soapsql_requests"),
 		"(GeneralStatistics.SOAPSQLRequests)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsSOAPWSDLRequests = prometheus.NewDesc(
+	c.genStatsSOAPWSDLRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_soapwsdl_requests"),
 		"(GeneralStatistics.SOAPWSDLRequests)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsSQLTraceIOProviderLockWaits = prometheus.NewDesc(
+	c.genStatsSQLTraceIOProviderLockWaits = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_sql_trace_io_provider_lock_waits"),
 		"(GeneralStatistics.SQLTraceIOProviderLockWaits)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsTempDBRecoveryUnitID = prometheus.NewDesc(
+	c.genStatsTempDBRecoveryUnitID = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_tempdb_recovery_unit_ids_generated"),
 		"(GeneralStatistics.Tempdbrecoveryunitid)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsTempDBrowSetID = prometheus.NewDesc(
+	c.genStatsTempDBrowSetID = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_tempdb_rowset_ids_generated"),
 		"(GeneralStatistics.Tempdbrowsetid)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsTempTablesCreationRate = prometheus.NewDesc(
+	c.genStatsTempTablesCreationRate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_temp_tables_creations"),
 		"(GeneralStatistics.TempTablesCreations)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsTempTablesForDestruction = prometheus.NewDesc(
+	c.genStatsTempTablesForDestruction = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_temp_tables_awaiting_destruction"),
 		"(GeneralStatistics.TempTablesForDestruction)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsTraceEventNotificationQueue = prometheus.NewDesc(
+	c.genStatsTraceEventNotificationQueue = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_trace_event_notification_queue_size"),
 		"(GeneralStatistics.TraceEventNotificationQueue)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsTransactions = prometheus.NewDesc(
+	c.genStatsTransactions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_transactions"),
 		"(GeneralStatistics.Transactions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.genStatsUserConnections = prometheus.NewDesc(
+	c.genStatsUserConnections = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "genstats_user_connections"),
 		"(GeneralStatistics.UserConnections)",
 		[]string{"mssql_instance"},
@@ -31,7 +31,7 @@ type collectorInstance struct {
 }
 
 func (c *Collector) buildInstance() error {
-	c.instances = prometheus.NewDesc(
+	c.instances = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "instance_info"),
 		"A metric with a constant '1' value labeled with mssql instance information",
 		[]string{"edition", "mssql_instance", "patch", "version"},
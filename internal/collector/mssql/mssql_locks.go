@@ -67,49 +67,49 @@ func (c *Collector) buildLocks() error {
 		}
 	}
 
-	c.locksWaitTime = prometheus.NewDesc(
+	c.locksWaitTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "locks_wait_time_seconds"),
 		"(Locks.AverageWaitTimems Total time in seconds which locks have been holding resources)",
 		[]string{"mssql_instance", "resource"},
 		nil,
 	)
-	c.locksCount = prometheus.NewDesc(
+	c.locksCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "locks_count"),
 		"(Locks.AverageWaitTimems_Base count of how often requests have run into locks)",
 		[]string{"mssql_instance", "resource"},
 		nil,
 	)
-	c.locksLockRequests = prometheus.NewDesc(
+	c.locksLockRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "locks_lock_requests"),
 		"(Locks.LockRequests)",
 		[]string{"mssql_instance", "resource"},
 		nil,
 	)
-	c.locksLockTimeouts = prometheus.NewDesc(
+	c.locksLockTimeouts = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "locks_lock_timeouts"),
 		"(Locks.LockTimeouts)",
 		[]string{"mssql_instance", "resource"},
 		nil,
 	)
-	c.locksLockTimeoutstimeout0 = prometheus.NewDesc(
+	c.locksLockTimeoutstimeout0 = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "locks_lock_timeouts_excluding_NOWAIT"),
 		"(Locks.LockTimeoutstimeout0)",
 		[]string{"mssql_instance", "resource"},
 		nil,
 	)
-	c.locksLockWaits = prometheus.NewDesc(
+	c.locksLockWaits = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "locks_lock_waits"),
 		"(Locks.LockWaits)",
 		[]string{"mssql_instance", "resource"},
 		nil,
 	)
-	c.locksLockWaitTimeMS = prometheus.NewDesc(
+	c.locksLockWaitTimeMS = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "locks_lock_wait_seconds"),
 		"(Locks.LockWaitTimems)",
 		[]string{"mssql_instance", "resource"},
 		nil,
 	)
-	c.locksNumberOfDeadlocks = prometheus.NewDesc(
+	c.locksNumberOfDeadlocks = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "locks_deadlocks"),
 		"(Locks.NumberOfDeadlocks)",
 		[]string{"mssql_instance", "resource"},
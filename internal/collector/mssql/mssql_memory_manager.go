@@ -88,121 +88,121 @@ func (c *Collector) buildMemoryManager() error {
 		}
 	}
 
-	c.memMgrConnectionMemoryKB = prometheus.NewDesc(
+	c.memMgrConnectionMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_connection_memory_bytes"),
 		"(MemoryManager.ConnectionMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrDatabaseCacheMemoryKB = prometheus.NewDesc(
+	c.memMgrDatabaseCacheMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_database_cache_memory_bytes"),
 		"(MemoryManager.DatabaseCacheMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrExternalBenefitOfMemory = prometheus.NewDesc(
+	c.memMgrExternalBenefitOfMemory = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_external_benefit_of_memory"),
 		"(MemoryManager.Externalbenefitofmemory)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrFreeMemoryKB = prometheus.NewDesc(
+	c.memMgrFreeMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_free_memory_bytes"),
 		"(MemoryManager.FreeMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrGrantedWorkspaceMemoryKB = prometheus.NewDesc(
+	c.memMgrGrantedWorkspaceMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_granted_workspace_memory_bytes"),
 		"(MemoryManager.GrantedWorkspaceMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrLockBlocks = prometheus.NewDesc(
+	c.memMgrLockBlocks = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_lock_blocks"),
 		"(MemoryManager.LockBlocks)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrLockBlocksAllocated = prometheus.NewDesc(
+	c.memMgrLockBlocksAllocated = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_allocated_lock_blocks"),
 		"(MemoryManager.LockBlocksAllocated)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrLockMemoryKB = prometheus.NewDesc(
+	c.memMgrLockMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_lock_memory_bytes"),
 		"(MemoryManager.LockMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrLockOwnerBlocks = prometheus.NewDesc(
+	c.memMgrLockOwnerBlocks = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_lock_owner_blocks"),
 		"(MemoryManager.LockOwnerBlocks)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrLockOwnerBlocksAllocated = prometheus.NewDesc(
+	c.memMgrLockOwnerBlocksAllocated = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_allocated_lock_owner_blocks"),
 		"(MemoryManager.LockOwnerBlocksAllocated)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrLogPoolMemoryKB = prometheus.NewDesc(
+	c.memMgrLogPoolMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_log_pool_memory_bytes"),
 		"(MemoryManager.LogPoolMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrMaximumWorkspaceMemoryKB = prometheus.NewDesc(
+	c.memMgrMaximumWorkspaceMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_maximum_workspace_memory_bytes"),
 		"(MemoryManager.MaximumWorkspaceMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrMemoryGrantsOutstanding = prometheus.NewDesc(
+	c.memMgrMemoryGrantsOutstanding = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_outstanding_memory_grants"),
 		"(MemoryManager.MemoryGrantsOutstanding)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrMemoryGrantsPending = prometheus.NewDesc(
+	c.memMgrMemoryGrantsPending = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_pending_memory_grants"),
 		"(MemoryManager.MemoryGrantsPending)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrOptimizerMemoryKB = prometheus.NewDesc(
+	c.memMgrOptimizerMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_optimizer_memory_bytes"),
 		"(MemoryManager.OptimizerMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrReservedServerMemoryKB = prometheus.NewDesc(
+	c.memMgrReservedServerMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_reserved_server_memory_bytes"),
 		"(MemoryManager.ReservedServerMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrSQLCacheMemoryKB = prometheus.NewDesc(
+	c.memMgrSQLCacheMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_sql_cache_memory_bytes"),
 		"(MemoryManager.SQLCacheMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrStolenServerMemoryKB = prometheus.NewDesc(
+	c.memMgrStolenServerMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_stolen_server_memory_bytes"),
 		"(MemoryManager.StolenServerMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrTargetServerMemoryKB = prometheus.NewDesc(
+	c.memMgrTargetServerMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_target_server_memory_bytes"),
 		"(MemoryManager.TargetServerMemoryKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.memMgrTotalServerMemoryKB = prometheus.NewDesc(
+	c.memMgrTotalServerMemoryKB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "memmgr_total_server_memory_bytes"),
 		"(MemoryManager.TotalServerMemoryKB)",
 		[]string{"mssql_instance"},
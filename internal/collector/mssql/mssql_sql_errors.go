@@ -54,7 +54,7 @@ func (c *Collector) buildSQLErrors() error {
 	}
 
 	// Win32_PerfRawData_{instance}_SQLServerSQLErrors
-	c.sqlErrorsTotal = prometheus.NewDesc(
+	c.sqlErrorsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sql_errors_total"),
 		"(SQLErrors.Total)",
 		[]string{"mssql_instance", "resource"},
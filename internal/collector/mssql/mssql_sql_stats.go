@@ -70,67 +70,67 @@ func (c *Collector) buildSQLStats() error {
 		}
 	}
 
-	c.sqlStatsAutoParamAttempts = prometheus.NewDesc(
+	c.sqlStatsAutoParamAttempts = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_auto_parameterization_attempts"),
 		"(SQLStatistics.AutoParamAttempts)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsBatchRequests = prometheus.NewDesc(
+	c.sqlStatsBatchRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_batch_requests"),
 		"(SQLStatistics.BatchRequests)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsFailedAutoParams = prometheus.NewDesc(
+	c.sqlStatsFailedAutoParams = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_failed_auto_parameterization_attempts"),
 		"(SQLStatistics.FailedAutoParams)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsForcedParameterizations = prometheus.NewDesc(
+	c.sqlStatsForcedParameterizations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_forced_parameterizations"),
 		"(SQLStatistics.ForcedParameterizations)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsGuidedplanexecutions = prometheus.NewDesc(
+	c.sqlStatsGuidedplanexecutions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_guided_plan_executions"),
 		"(SQLStatistics.Guidedplanexecutions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsMisguidedplanexecutions = prometheus.NewDesc(
+	c.sqlStatsMisguidedplanexecutions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_misguided_plan_executions"),
 		"(SQLStatistics.Misguidedplanexecutions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsSafeAutoParams = prometheus.NewDesc(
+	c.sqlStatsSafeAutoParams = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_safe_auto_parameterization_attempts"),
 		"(SQLStatistics.SafeAutoParams)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsSQLAttentionrate = prometheus.NewDesc(
+	c.sqlStatsSQLAttentionrate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_sql_attentions"),
 		"(SQLStatistics.SQLAttentions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsSQLCompilations = prometheus.NewDesc(
+	c.sqlStatsSQLCompilations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_sql_compilations"),
 		"(SQLStatistics.SQLCompilations)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsSQLReCompilations = prometheus.NewDesc(
+	c.sqlStatsSQLReCompilations = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_sql_recompilations"),
 		"(SQLStatistics.SQLReCompilations)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.sqlStatsUnsafeAutoParams = prometheus.NewDesc(
+	c.sqlStatsUnsafeAutoParams = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sqlstats_unsafe_auto_parameterization_attempts"),
 		"(SQLStatistics.UnsafeAutoParams)",
 		[]string{"mssql_instance"},
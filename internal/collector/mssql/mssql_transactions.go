@@ -74,79 +74,79 @@ func (c *Collector) buildTransactions() error {
 		}
 	}
 
-	c.transactionsTempDbFreeSpaceBytes = prometheus.NewDesc(
+	c.transactionsTempDbFreeSpaceBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_tempdb_free_space_bytes"),
 		"(Transactions.FreeSpaceInTempDbKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsLongestTransactionRunningSeconds = prometheus.NewDesc(
+	c.transactionsLongestTransactionRunningSeconds = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_longest_transaction_running_seconds"),
 		"(Transactions.LongestTransactionRunningTime)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsNonSnapshotVersionActiveTotal = prometheus.NewDesc(
+	c.transactionsNonSnapshotVersionActiveTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_nonsnapshot_version_active_total"),
 		"(Transactions.NonSnapshotVersionTransactions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsSnapshotActiveTotal = prometheus.NewDesc(
+	c.transactionsSnapshotActiveTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_snapshot_active_total"),
 		"(Transactions.SnapshotTransactions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsActive = prometheus.NewDesc(
+	c.transactionsActive = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_active"),
 		"(Transactions.Transactions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsUpdateConflictsTotal = prometheus.NewDesc(
+	c.transactionsUpdateConflictsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_update_conflicts_total"),
 		"(Transactions.UpdateConflictRatio)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsUpdateSnapshotActiveTotal = prometheus.NewDesc(
+	c.transactionsUpdateSnapshotActiveTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_update_snapshot_active_total"),
 		"(Transactions.UpdateSnapshotTransactions)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsVersionCleanupRateBytes = prometheus.NewDesc(
+	c.transactionsVersionCleanupRateBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_version_cleanup_rate_bytes"),
 		"(Transactions.VersionCleanupRateKBs)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsVersionGenerationRateBytes = prometheus.NewDesc(
+	c.transactionsVersionGenerationRateBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_version_generation_rate_bytes"),
 		"(Transactions.VersionGenerationRateKBs)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsVersionStoreSizeBytes = prometheus.NewDesc(
+	c.transactionsVersionStoreSizeBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_version_store_size_bytes"),
 		"(Transactions.VersionStoreSizeKB)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsVersionStoreUnits = prometheus.NewDesc(
+	c.transactionsVersionStoreUnits = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_version_store_units"),
 		"(Transactions.VersionStoreUnitCount)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsVersionStoreCreationUnits = prometheus.NewDesc(
+	c.transactionsVersionStoreCreationUnits = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_version_store_creation_units"),
 		"(Transactions.VersionStoreUnitCreation)",
 		[]string{"mssql_instance"},
 		nil,
 	)
-	c.transactionsVersionStoreTruncationUnits = prometheus.NewDesc(
+	c.transactionsVersionStoreTruncationUnits = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "transactions_version_store_truncation_units"),
 		"(Transactions.VersionStoreUnitTruncation)",
 		[]string{"mssql_instance"},
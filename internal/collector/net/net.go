@@ -61,6 +61,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus Collector for Perflib Network Interface metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -175,103 +177,103 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		}
 	}
 
-	c.bytesReceivedTotal = prometheus.NewDesc(
+	c.bytesReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_received_total"),
 		"(Network.BytesReceivedPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.bytesSentTotal = prometheus.NewDesc(
+	c.bytesSentTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_sent_total"),
 		"(Network.BytesSentPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.bytesTotal = prometheus.NewDesc(
+	c.bytesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_total"),
 		"(Network.BytesTotalPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.outputQueueLength = prometheus.NewDesc(
+	c.outputQueueLength = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "output_queue_length_packets"),
 		"(Network.OutputQueueLength)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsOutboundDiscarded = prometheus.NewDesc(
+	c.packetsOutboundDiscarded = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_outbound_discarded_total"),
 		"(Network.PacketsOutboundDiscarded)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsOutboundErrors = prometheus.NewDesc(
+	c.packetsOutboundErrors = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_outbound_errors_total"),
 		"(Network.PacketsOutboundErrors)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsReceivedDiscarded = prometheus.NewDesc(
+	c.packetsReceivedDiscarded = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_discarded_total"),
 		"(Network.PacketsReceivedDiscarded)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsReceivedErrors = prometheus.NewDesc(
+	c.packetsReceivedErrors = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_errors_total"),
 		"(Network.PacketsReceivedErrors)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsReceivedTotal = prometheus.NewDesc(
+	c.packetsReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_total"),
 		"(Network.PacketsReceivedPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsReceivedUnknown = prometheus.NewDesc(
+	c.packetsReceivedUnknown = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_unknown_total"),
 		"(Network.PacketsReceivedUnknown)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsTotal = prometheus.NewDesc(
+	c.packetsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_total"),
 		"(Network.PacketsPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.packetsSentTotal = prometheus.NewDesc(
+	c.packetsSentTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_sent_total"),
 		"(Network.PacketsSentPerSec)",
 		[]string{"nic"},
 		nil,
 	)
-	c.currentBandwidth = prometheus.NewDesc(
+	c.currentBandwidth = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_bandwidth_bytes"),
 		"(Network.CurrentBandwidth)",
 		[]string{"nic"},
 		nil,
 	)
-	c.nicIPAddressInfo = prometheus.NewDesc(
+	c.nicIPAddressInfo = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "nic_address_info"),
 		"A metric with a constant '1' value labeled with the network interface's address information.",
 		[]string{"nic", "address", "family"},
 		nil,
 	)
-	c.nicOperStatus = prometheus.NewDesc(
+	c.nicOperStatus = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "nic_operation_status"),
 		"The operational status for the interface as defined in RFC 2863 as IfOperStatus.",
 		[]string{"nic", "status"},
 		nil,
 	)
-	c.nicInfo = prometheus.NewDesc(
+	c.nicInfo = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "nic_info"),
 		"A metric with a constant '1' value labeled with the network interface's general information.",
 		[]string{"nic", "friendly_name", "mac"},
 		nil,
 	)
-	c.routeInfo = prometheus.NewDesc(
+	c.routeInfo = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "route_info"),
 		"A metric with a constant '1' value labeled with the network interface's route information.",
 		[]string{"nic", "src", "dest", "metric"},
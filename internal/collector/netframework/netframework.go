@@ -18,6 +18,7 @@
 package netframework
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -28,6 +29,8 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -64,10 +67,12 @@ const (
 
 // A Collector is a Prometheus Collector for WMI Win32_PerfRawData_NETFramework_NETCLRExceptions metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config    Config
 	miSession *mi.Session
 
-	collectorFns []func(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error
+	collectorFns []func(ctx context.Context, ch chan<- prometheus.Metric) error
 
 	// clrexceptions
 	numberOfExceptionsThrown *prometheus.Desc
@@ -188,11 +193,11 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 
 	c.miSession = miSession
 
-	c.collectorFns = make([]func(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error, 0, len(c.config.CollectorsEnabled))
+	c.collectorFns = make([]func(ctx context.Context, ch chan<- prometheus.Metric) error, 0, len(c.config.CollectorsEnabled))
 
 	subCollectors := map[string]struct {
 		build   func()
-		collect func(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error
+		collect func(ctx context.Context, ch chan<- prometheus.Metric) error
 		close   func()
 	}{
 		collectorClrExceptions: {
@@ -248,6 +253,14 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.CollectWithContext(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but cancels the WMI queries once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
 	errCh := make(chan error, len(c.collectorFns))
 	errs := make([]error, 0, len(c.collectorFns))
 
@@ -256,10 +269,10 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 	for _, fn := range c.collectorFns {
 		wg.Add(1)
 
-		go func(fn func(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error) {
+		go func(fn func(ctx context.Context, ch chan<- prometheus.Metric) error) {
 			defer wg.Done()
 
-			if err := fn(ch, maxScrapeDuration); err != nil {
+			if err := fn(ctx, ch); err != nil {
 				errCh <- err
 			}
 		}(fn)
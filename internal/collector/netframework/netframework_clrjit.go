@@ -18,8 +18,8 @@
 package netframework
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -28,25 +28,25 @@ import (
 )
 
 func (c *Collector) buildClrJIT() {
-	c.numberOfMethodsJitted = prometheus.NewDesc(
+	c.numberOfMethodsJitted = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrJIT+"_jit_methods_total"),
 		"Displays the total number of methods JIT-compiled since the application started. This counter does not include pre-JIT-compiled methods.",
 		[]string{"process"},
 		nil,
 	)
-	c.timeInJit = prometheus.NewDesc(
+	c.timeInJit = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrJIT+"_jit_time_percent"),
 		"Displays the percentage of time spent in JIT compilation. This counter is updated at the end of every JIT compilation phase. A JIT compilation phase occurs when a method and its dependencies are compiled.",
 		[]string{"process"},
 		nil,
 	)
-	c.standardJitFailures = prometheus.NewDesc(
+	c.standardJitFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrJIT+"_jit_standard_failures_total"),
 		"Displays the peak number of methods the JIT compiler has failed to compile since the application started. This failure can occur if the MSIL cannot be verified or if there is an internal error in the JIT compiler.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalNumberOfILBytesJitted = prometheus.NewDesc(
+	c.totalNumberOfILBytesJitted = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrJIT+"_jit_il_bytes_total"),
 		"Displays the total number of Microsoft intermediate language (MSIL) bytes compiled by the just-in-time (JIT) compiler since the application started",
 		[]string{"process"},
@@ -66,9 +66,9 @@ type Win32_PerfRawData_NETFramework_NETCLRJit struct {
 	TotalNumberofILBytesJitted uint32 `mi:"TotalNumberofILBytesJitted"`
 }
 
-func (c *Collector) collectClrJIT(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+func (c *Collector) collectClrJIT(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []Win32_PerfRawData_NETFramework_NETCLRJit
-	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, utils.Must(mi.NewQuery("SELECT * FROM Win32_PerfRawData_NETFramework_NETCLRJit")), maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootCIMv2, utils.Must(mi.NewQuery("SELECT * FROM Win32_PerfRawData_NETFramework_NETCLRJit"))); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
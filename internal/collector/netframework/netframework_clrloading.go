@@ -18,8 +18,8 @@
 package netframework
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -28,55 +28,55 @@ import (
 )
 
 func (c *Collector) buildClrLoading() {
-	c.bytesInLoaderHeap = prometheus.NewDesc(
+	c.bytesInLoaderHeap = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_loader_heap_size_bytes"),
 		"Displays the current size, in bytes, of the memory committed by the class loader across all application domains. Committed memory is the physical space reserved in the disk paging file.",
 		[]string{"process"},
 		nil,
 	)
-	c.currentAppDomains = prometheus.NewDesc(
+	c.currentAppDomains = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_appdomains_loaded_current"),
 		"Displays the current number of application domains loaded in this application.",
 		[]string{"process"},
 		nil,
 	)
-	c.currentAssemblies = prometheus.NewDesc(
+	c.currentAssemblies = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_assemblies_loaded_current"),
 		"Displays the current number of assemblies loaded across all application domains in the currently running application. If the assembly is loaded as domain-neutral from multiple application domains, this counter is incremented only once.",
 		[]string{"process"},
 		nil,
 	)
-	c.currentClassesLoaded = prometheus.NewDesc(
+	c.currentClassesLoaded = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_classes_loaded_current"),
 		"Displays the current number of classes loaded in all assemblies.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalAppDomains = prometheus.NewDesc(
+	c.totalAppDomains = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_appdomains_loaded_total"),
 		"Displays the peak number of application domains loaded since the application started.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalAppDomainsUnloaded = prometheus.NewDesc(
+	c.totalAppDomainsUnloaded = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_appdomains_unloaded_total"),
 		"Displays the total number of application domains unloaded since the application started. If an application domain is loaded and unloaded multiple times, this counter increments each time the application domain is unloaded.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalAssemblies = prometheus.NewDesc(
+	c.totalAssemblies = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_assemblies_loaded_total"),
 		"Displays the total number of assemblies loaded since the application started. If the assembly is loaded as domain-neutral from multiple application domains, this counter is incremented only once.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalClassesLoaded = prometheus.NewDesc(
+	c.totalClassesLoaded = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_classes_loaded_total"),
 		"Displays the cumulative number of classes loaded in all assemblies since the application started.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalNumberOfLoadFailures = prometheus.NewDesc(
+	c.totalNumberOfLoadFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLoading+"_class_load_failures_total"),
 		"Displays the peak number of classes that have failed to load since the application started.",
 		[]string{"process"},
@@ -105,9 +105,9 @@ type Win32_PerfRawData_NETFramework_NETCLRLoading struct {
 	TotalNumberofLoadFailures uint32 `mi:"TotalNumberofLoadFailures"`
 }
 
-func (c *Collector) collectClrLoading(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+func (c *Collector) collectClrLoading(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []Win32_PerfRawData_NETFramework_NETCLRLoading
-	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, utils.Must(mi.NewQuery("SELECT * FROM Win32_PerfRawData_NETFramework_NETCLRLoading")), maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootCIMv2, utils.Must(mi.NewQuery("SELECT * FROM Win32_PerfRawData_NETFramework_NETCLRLoading"))); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
@@ -18,8 +18,8 @@
 package netframework
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -28,43 +28,43 @@ import (
 )
 
 func (c *Collector) buildClrLocksAndThreads() {
-	c.currentQueueLength = prometheus.NewDesc(
+	c.currentQueueLength = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLocksAndThreads+"_current_queue_length"),
 		"Displays the total number of threads that are currently waiting to acquire a managed lock in the application.",
 		[]string{"process"},
 		nil,
 	)
-	c.numberOfCurrentLogicalThreads = prometheus.NewDesc(
+	c.numberOfCurrentLogicalThreads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLocksAndThreads+"_current_logical_threads"),
 		"Displays the number of current managed thread objects in the application. This counter maintains the count of both running and stopped threads. ",
 		[]string{"process"},
 		nil,
 	)
-	c.numberOfCurrentPhysicalThreads = prometheus.NewDesc(
+	c.numberOfCurrentPhysicalThreads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLocksAndThreads+"_physical_threads_current"),
 		"Displays the number of native operating system threads created and owned by the common language runtime to act as underlying threads for managed thread objects. This counter's value does not include the threads used by the runtime in its internal operations; it is a subset of the threads in the operating system process.",
 		[]string{"process"},
 		nil,
 	)
-	c.numberOfCurrentRecognizedThreads = prometheus.NewDesc(
+	c.numberOfCurrentRecognizedThreads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLocksAndThreads+"_recognized_threads_current"),
 		"Displays the number of threads that are currently recognized by the runtime. These threads are associated with a corresponding managed thread object. The runtime does not create these threads, but they have run inside the runtime at least once.",
 		[]string{"process"},
 		nil,
 	)
-	c.numberOfTotalRecognizedThreads = prometheus.NewDesc(
+	c.numberOfTotalRecognizedThreads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLocksAndThreads+"_recognized_threads_total"),
 		"Displays the total number of threads that have been recognized by the runtime since the application started. These threads are associated with a corresponding managed thread object. The runtime does not create these threads, but they have run inside the runtime at least once.",
 		[]string{"process"},
 		nil,
 	)
-	c.queueLengthPeak = prometheus.NewDesc(
+	c.queueLengthPeak = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLocksAndThreads+"_queue_length_total"),
 		"Displays the total number of threads that waited to acquire a managed lock since the application started.",
 		[]string{"process"},
 		nil,
 	)
-	c.totalNumberOfContentions = prometheus.NewDesc(
+	c.totalNumberOfContentions = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrLocksAndThreads+"_contentions_total"),
 		"Displays the total number of times that threads in the runtime have attempted to acquire a managed lock unsuccessfully.",
 		[]string{"process"},
@@ -87,9 +87,9 @@ type Win32_PerfRawData_NETFramework_NETCLRLocksAndThreads struct {
 	TotalNumberofContentions         uint32 `mi:"TotalNumberofContentions"`
 }
 
-func (c *Collector) collectClrLocksAndThreads(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+func (c *Collector) collectClrLocksAndThreads(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []Win32_PerfRawData_NETFramework_NETCLRLocksAndThreads
-	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, utils.Must(mi.NewQuery("SELECT * FROM Win32_PerfRawData_NETFramework_NETCLRLocksAndThreads")), maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootCIMv2, utils.Must(mi.NewQuery("SELECT * FROM Win32_PerfRawData_NETFramework_NETCLRLocksAndThreads"))); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
@@ -18,9 +18,9 @@
 package netframework
 
 import (
+	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -29,73 +29,73 @@ import (
 )
 
 func (c *Collector) buildClrMemory() {
-	c.allocatedBytes = prometheus.NewDesc(
+	c.allocatedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_allocated_bytes_total"),
 		"Displays the total number of bytes allocated on the garbage collection heap.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.finalizationSurvivors = prometheus.NewDesc(
+	c.finalizationSurvivors = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_finalization_survivors"),
 		"Displays the number of garbage-collected objects that survive a collection because they are waiting to be finalized.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.heapSize = prometheus.NewDesc(
+	c.heapSize = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_heap_size_bytes"),
 		"Displays the maximum bytes that can be allocated; it does not indicate the current number of bytes allocated.",
 		[]string{"process", "process_id", "area"},
 		nil,
 	)
-	c.promotedBytes = prometheus.NewDesc(
+	c.promotedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_promoted_bytes"),
 		"Displays the bytes that were promoted from the generation to the next one during the last GC. Memory is promoted when it survives a garbage collection.",
 		[]string{"process", "process_id", "area"},
 		nil,
 	)
-	c.numberGCHandles = prometheus.NewDesc(
+	c.numberGCHandles = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_number_gc_handles"),
 		"Displays the current number of garbage collection handles in use. Garbage collection handles are handles to resources external to the common language runtime and the managed environment.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.numberCollections = prometheus.NewDesc(
+	c.numberCollections = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_collections_total"),
 		"Displays the number of times the generation objects are garbage collected since the application started.",
 		[]string{"process", "process_id", "area"},
 		nil,
 	)
-	c.numberInducedGC = prometheus.NewDesc(
+	c.numberInducedGC = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_induced_gc_total"),
 		"Displays the peak number of times garbage collection was performed because of an explicit call to GC.Collect.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.numberOfPinnedObjects = prometheus.NewDesc(
+	c.numberOfPinnedObjects = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_number_pinned_objects"),
 		"Displays the number of pinned objects encountered in the last garbage collection.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.numberOfSinkBlocksInUse = prometheus.NewDesc(
+	c.numberOfSinkBlocksInUse = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_number_sink_blocksinuse"),
 		"Displays the current number of synchronization blocks in use. Synchronization blocks are per-object data structures allocated for storing synchronization information. They hold weak references to managed objects and must be scanned by the garbage collector.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.numberTotalCommittedBytes = prometheus.NewDesc(
+	c.numberTotalCommittedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_committed_bytes"),
 		"Displays the amount of virtual memory, in bytes, currently committed by the garbage collector. Committed memory is the physical memory for which space has been reserved in the disk paging file.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.numberTotalReservedBytes = prometheus.NewDesc(
+	c.numberTotalReservedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_reserved_bytes"),
 		"Displays the amount of virtual memory, in bytes, currently reserved by the garbage collector. Reserved memory is the virtual memory space reserved for the application when no disk or main memory pages have been used.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.timeInGC = prometheus.NewDesc(
+	c.timeInGC = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, collectorClrMemory+"_gc_time_percent"),
 		"Displays the percentage of time that was spent performing a garbage collection in the last sample.",
 		[]string{"process", "process_id"},
@@ -137,9 +137,9 @@ type Win32_PerfRawData_NETFramework_NETCLRMemory struct {
 	PromotedMemoryfromGen1             uint64 `mi:"PromotedMemoryfromGen1"`
 }
 
-func (c *Collector) collectClrMemory(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+func (c *Collector) collectClrMemory(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var dst []Win32_PerfRawData_NETFramework_NETCLRMemory
-	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, utils.Must(mi.NewQuery("SELECT * FROM Win32_PerfRawData_NETFramework_NETCLRMemory")), maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &dst, mi.NamespaceRootCIMv2, utils.Must(mi.NewQuery("SELECT * FROM Win32_PerfRawData_NETFramework_NETCLRMemory"))); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
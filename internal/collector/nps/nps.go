@@ -38,6 +38,8 @@ type Config struct{}
 var ConfigDefaults = Config{}
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	accessPerfDataCollector *pdh.Collector
@@ -97,152 +99,152 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.accessAccepts = prometheus.NewDesc(
+	c.accessAccepts = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_accepts"),
 		"(AccessAccepts)",
 		nil,
 		nil,
 	)
-	c.accessChallenges = prometheus.NewDesc(
+	c.accessChallenges = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_challenges"),
 		"(AccessChallenges)",
 		nil,
 		nil,
 	)
-	c.accessRejects = prometheus.NewDesc(
+	c.accessRejects = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_rejects"),
 		"(AccessRejects)",
 		nil,
 		nil,
 	)
-	c.accessRequests = prometheus.NewDesc(
+	c.accessRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_requests"),
 		"(AccessRequests)",
 		nil,
 		nil,
 	)
-	c.accessBadAuthenticators = prometheus.NewDesc(
+	c.accessBadAuthenticators = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_bad_authenticators"),
 		"(BadAuthenticators)",
 		nil,
 		nil,
 	)
-	c.accessDroppedPackets = prometheus.NewDesc(
+	c.accessDroppedPackets = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_dropped_packets"),
 		"(DroppedPackets)",
 		nil,
 		nil,
 	)
-	c.accessInvalidRequests = prometheus.NewDesc(
+	c.accessInvalidRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_invalid_requests"),
 		"(InvalidRequests)",
 		nil,
 		nil,
 	)
-	c.accessMalformedPackets = prometheus.NewDesc(
+	c.accessMalformedPackets = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_malformed_packets"),
 		"(MalformedPackets)",
 		nil,
 		nil,
 	)
-	c.accessPacketsReceived = prometheus.NewDesc(
+	c.accessPacketsReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_packets_received"),
 		"(PacketsReceived)",
 		nil,
 		nil,
 	)
-	c.accessPacketsSent = prometheus.NewDesc(
+	c.accessPacketsSent = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_packets_sent"),
 		"(PacketsSent)",
 		nil,
 		nil,
 	)
-	c.accessServerResetTime = prometheus.NewDesc(
+	c.accessServerResetTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_server_reset_time"),
 		"(ServerResetTime)",
 		nil,
 		nil,
 	)
-	c.accessServerUpTime = prometheus.NewDesc(
+	c.accessServerUpTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_server_up_time"),
 		"(ServerUpTime)",
 		nil,
 		nil,
 	)
-	c.accessUnknownType = prometheus.NewDesc(
+	c.accessUnknownType = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "access_unknown_type"),
 		"(UnknownType)",
 		nil,
 		nil,
 	)
 
-	c.accountingRequests = prometheus.NewDesc(
+	c.accountingRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_requests"),
 		"(AccountingRequests)",
 		nil,
 		nil,
 	)
-	c.accountingResponses = prometheus.NewDesc(
+	c.accountingResponses = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_responses"),
 		"(AccountingResponses)",
 		nil,
 		nil,
 	)
-	c.accountingBadAuthenticators = prometheus.NewDesc(
+	c.accountingBadAuthenticators = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_bad_authenticators"),
 		"(BadAuthenticators)",
 		nil,
 		nil,
 	)
-	c.accountingDroppedPackets = prometheus.NewDesc(
+	c.accountingDroppedPackets = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_dropped_packets"),
 		"(DroppedPackets)",
 		nil,
 		nil,
 	)
-	c.accountingInvalidRequests = prometheus.NewDesc(
+	c.accountingInvalidRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_invalid_requests"),
 		"(InvalidRequests)",
 		nil,
 		nil,
 	)
-	c.accountingMalformedPackets = prometheus.NewDesc(
+	c.accountingMalformedPackets = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_malformed_packets"),
 		"(MalformedPackets)",
 		nil,
 		nil,
 	)
-	c.accountingNoRecord = prometheus.NewDesc(
+	c.accountingNoRecord = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_no_record"),
 		"(NoRecord)",
 		nil,
 		nil,
 	)
-	c.accountingPacketsReceived = prometheus.NewDesc(
+	c.accountingPacketsReceived = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_packets_received"),
 		"(PacketsReceived)",
 		nil,
 		nil,
 	)
-	c.accountingPacketsSent = prometheus.NewDesc(
+	c.accountingPacketsSent = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_packets_sent"),
 		"(PacketsSent)",
 		nil,
 		nil,
 	)
-	c.accountingServerResetTime = prometheus.NewDesc(
+	c.accountingServerResetTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_server_reset_time"),
 		"(ServerResetTime)",
 		nil,
 		nil,
 	)
-	c.accountingServerUpTime = prometheus.NewDesc(
+	c.accountingServerUpTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_server_up_time"),
 		"(ServerUpTime)",
 		nil,
 		nil,
 	)
-	c.accountingUnknownType = prometheus.NewDesc(
+	c.accountingUnknownType = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "accounting_unknown_type"),
 		"(UnknownType)",
 		nil,
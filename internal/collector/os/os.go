@@ -43,6 +43,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for WMI metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	installTimeTimestamp float64
@@ -96,7 +98,7 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 		productName = strings.Replace(productName, " 10 ", " 11 ", 1)
 	}
 
-	c.osInformation = prometheus.NewDesc(
+	c.osInformation = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		`Contains full product name & version in labels. Note that the "major_version" for Windows 11 is \"10\"; a build number greater than 22000 represents Windows 11.`,
 		nil,
@@ -111,7 +113,7 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 		},
 	)
 
-	c.hostname = prometheus.NewDesc(
+	c.hostname = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "hostname"),
 		"Labelled system hostname information as provided by ComputerSystem.DNSHostName and ComputerSystem.Domain",
 		[]string{
@@ -122,7 +124,7 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
-	c.installTime = prometheus.NewDesc(
+	c.installTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "install_time_timestamp_seconds"),
 		"Unix timestamp of OS installation time",
 		nil,
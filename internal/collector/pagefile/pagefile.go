@@ -41,6 +41,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for WMI metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -77,14 +79,14 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.pagingLimitBytes = prometheus.NewDesc(
+	c.pagingLimitBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "limit_bytes"),
 		"Number of bytes that can be stored in the operating system paging files. 0 (zero) indicates that there are no paging files",
 		[]string{"file"},
 		nil,
 	)
 
-	c.pagingFreeBytes = prometheus.NewDesc(
+	c.pagingFreeBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "free_bytes"),
 		"Number of bytes that can be mapped into the operating system paging files without causing any other pages to be swapped out",
 		[]string{"file"},
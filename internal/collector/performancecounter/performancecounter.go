@@ -18,6 +18,7 @@
 package performancecounter
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -32,8 +33,8 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
-	"go.yaml.in/yaml/v3"
 )
 
 const Name = "performancecounter"
@@ -60,6 +61,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus collector for performance counter metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	logger *slog.Logger
@@ -104,10 +107,13 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 			return nil
 		}
 
-		if err := yaml.Unmarshal([]byte(objects), &c.config.Objects); err != nil {
+		parsedObjects, err := parseObjects(objects)
+		if err != nil {
 			return fmt.Errorf("failed to parse objects %s: %w", objects, err)
 		}
 
+		c.config.Objects = parsedObjects
+
 		return nil
 	})
 
@@ -231,13 +237,13 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		c.objects = append(c.objects, object)
 	}
 
-	c.subCollectorScrapeDurationDesc = prometheus.NewDesc(
+	c.subCollectorScrapeDurationDesc = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "collector_duration_seconds"),
 		"windows_exporter: Duration of an performancecounter child collection.",
 		[]string{"collector"},
 		nil,
 	)
-	c.subCollectorScrapeSuccessDesc = prometheus.NewDesc(
+	c.subCollectorScrapeSuccessDesc = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "collector_success"),
 		"windows_exporter: Whether a performancecounter child collector was successful.",
 		[]string{"collector"},
@@ -249,12 +255,26 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
-func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.CollectWithContext(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but skips the remaining objects once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var errs []error
 
 	for _, perfDataObject := range c.objects {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+
+			break
+		}
+
 		startTime := time.Now()
-		err := c.collectObject(ch, perfDataObject)
+		err := c.collectObject(ctx, ch, perfDataObject)
 		duration := time.Since(startTime)
 		success := 1.0
 
@@ -287,8 +307,8 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 	return errors.Join(errs...)
 }
 
-func (c *Collector) collectObject(ch chan<- prometheus.Metric, perfDataObject Object) error {
-	err := perfDataObject.collector.Collect(perfDataObject.perfDataObject)
+func (c *Collector) collectObject(ctx context.Context, ch chan<- prometheus.Metric, perfDataObject Object) error {
+	err := perfDataObject.collector.CollectWithContext(ctx, perfDataObject.perfDataObject)
 	if err != nil {
 		return fmt.Errorf("failed to collect data: %w", err)
 	}
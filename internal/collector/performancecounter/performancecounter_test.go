@@ -22,7 +22,10 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/collector/performancecounter"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/utils/testutils"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
 )
 
 func BenchmarkCollector(b *testing.B) {
@@ -32,3 +35,111 @@ func BenchmarkCollector(b *testing.B) {
 		app.GetFlag("collector.perfdata.objects").StringVar(&perfDataObjects)
 	})
 }
+
+func TestConfigUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	expected := []performancecounter.Object{
+		{
+			Name:          "cpu",
+			Object:        "Processor Information",
+			Type:          pdh.CounterTypeFormatted,
+			Instances:     []string{"*"},
+			InstanceLabel: "core",
+			Counters: []performancecounter.Counter{
+				{Name: "% Processor Time", Labels: map[string]string{"state": "active"}},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		config   string
+		expected []performancecounter.Object
+		err      string
+	}{
+		{
+			name: "list",
+			config: `
+objects:
+  - name: cpu
+    object: Processor Information
+    type: formatted
+    instances: ["*"]
+    instance_label: core
+    counters:
+      - name: "% Processor Time"
+        labels:
+          state: active
+`,
+			expected: expected,
+		},
+		{
+			name: "string",
+			config: `
+objects: |-
+  [{"name": "cpu", "object": "Processor Information", "type": "formatted", "instances": ["*"], "instance_label": "core",
+    "counters": [{"name": "% Processor Time", "labels": {"state": "active"}}]}]
+`,
+			expected: expected,
+		},
+		{
+			name: "unknown field",
+			config: `
+objects:
+  - name: cpu
+    object: Processor Information
+    counters:
+      - name: "% Processor Time"
+        metrics: windows_cpu_time
+`,
+			err: "line 7, column 9: field metrics not found in type performancecounter.Counter",
+		},
+		{
+			name: "invalid type",
+			config: `
+objects:
+  - name: cpu
+    object: Processor Information
+    type: cooked
+    counters:
+      - name: "% Processor Time"
+`,
+			err: "line 5, column 11: object cpu: invalid type cooked, must be raw or formatted",
+		},
+		{
+			name: "duplicate name",
+			config: `
+objects:
+  - name: cpu
+    object: Processor Information
+    counters: [{name: "% Processor Time"}]
+  - name: cpu
+    object: Processor
+    counters: [{name: "% Processor Time"}]
+`,
+			err: "line 6, column 5: object cpu: name is duplicated",
+		},
+		{
+			name:   "no list",
+			config: "objects: {name: cpu}",
+			err:    "line 1, column 10: objects must be a list, got !!map",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var config performancecounter.Config
+
+			err := yaml.Unmarshal([]byte(tc.config), &config)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, config.Objects)
+		})
+	}
+}
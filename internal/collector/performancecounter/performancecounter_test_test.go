@@ -33,7 +33,7 @@ import (
 )
 
 type collectorAdapter struct {
-	performancecounter.Collector
+	*performancecounter.Collector
 }
 
 // Describe implements the prometheus.Collector interface.
@@ -200,7 +200,7 @@ windows_performancecounter_processor_information_processor_time\{core="0,0",stat
 			require.NoError(t, err)
 
 			registry := prometheus.NewRegistry()
-			registry.MustRegister(collectorAdapter{*perfDataCollector})
+			registry.MustRegister(collectorAdapter{perfDataCollector})
 
 			rw := httptest.NewRecorder()
 			promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}).ServeHTTP(rw, &http.Request{})
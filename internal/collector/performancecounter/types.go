@@ -18,6 +18,10 @@
 package performancecounter
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"go.yaml.in/yaml/v3"
 )
@@ -43,6 +47,174 @@ type Counter struct {
 
 // https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/54691ebe11bb9ec32b4e35cd31fcb94a352de134/receiver/windowsperfcountersreceiver/README.md?plain=1#L150
 
-func (*Config) UnmarshalYAML(*yaml.Node) error {
+// UnmarshalYAML implements [yaml.Unmarshaler]. The objects are accepted as YAML list
+// or, as in the flag, as string containing the YAML or JSON list.
+// Errors report the line and column of the invalid value.
+func (c *Config) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return nodeError(node, "cannot unmarshal %s into performancecounter.Config", node.ShortTag())
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		if keyNode.Value != "objects" {
+			return nodeError(keyNode, "field %s not found in type performancecounter.Config", keyNode.Value)
+		}
+
+		objects, err := decodeObjects(valueNode)
+		if err != nil {
+			return err
+		}
+
+		c.Objects = objects
+	}
+
+	return nil
+}
+
+// parseObjects parses the objects from a YAML or JSON string.
+func parseObjects(data string) ([]Object, error) {
+	var node yaml.Node
+
+	if err := yaml.Unmarshal([]byte(data), &node); err != nil {
+		return nil, err
+	}
+
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+
+	return decodeObjects(node.Content[0])
+}
+
+// decodeObjects decodes and validates the list of objects.
+func decodeObjects(node *yaml.Node) ([]Object, error) {
+	if node.Kind == yaml.ScalarNode {
+		switch node.ShortTag() {
+		case "!!null":
+			return nil, nil
+		case "!!str":
+			// Lines and columns are relative to the string.
+			objects, err := parseObjects(node.Value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d, column %d: objects: %w", node.Line, node.Column, err)
+			}
+
+			return objects, nil
+		}
+	}
+
+	if node.Kind != yaml.SequenceNode {
+		return nil, nodeError(node, "objects must be a list, got %s", node.ShortTag())
+	}
+
+	objects := make([]Object, 0, len(node.Content))
+	names := make(map[string]struct{}, len(node.Content))
+
+	for _, objectNode := range node.Content {
+		object, err := decodeObject(objectNode)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := names[object.Name]; ok {
+			return nil, nodeError(objectNode, "object %s: name is duplicated", object.Name)
+		}
+
+		names[object.Name] = struct{}{}
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+func decodeObject(node *yaml.Node) (Object, error) {
+	var object Object
+
+	if err := checkFields(node, reflect.TypeFor[Object]()); err != nil {
+		return object, err
+	}
+
+	if err := node.Decode(&object); err != nil {
+		return object, err
+	}
+
+	switch {
+	case object.Name == "":
+		return object, nodeError(node, "object name is required")
+	case object.Object == "":
+		return object, nodeError(node, "object %s: object is required", object.Name)
+	case len(object.Counters) == 0:
+		return object, nodeError(node, "object %s: counters are required", object.Name)
+	}
+
+	switch object.Type {
+	case "", pdh.CounterTypeRaw, pdh.CounterTypeFormatted:
+	default:
+		return object, nodeError(mappingValue(node, "type"), "object %s: invalid type %s, must be %s or %s",
+			object.Name, object.Type, pdh.CounterTypeRaw, pdh.CounterTypeFormatted)
+	}
+
+	countersNode := mappingValue(node, "counters")
+
+	for i, counterNode := range countersNode.Content {
+		if err := checkFields(counterNode, reflect.TypeFor[Counter]()); err != nil {
+			return object, err
+		}
+
+		counter := object.Counters[i]
+
+		if counter.Name == "" {
+			return object, nodeError(counterNode, "object %s: counter name is required", object.Name)
+		}
+
+		switch counter.Type {
+		case "", "counter", "gauge":
+		default:
+			return object, nodeError(mappingValue(counterNode, "type"), "object %s: counter %s: invalid type %s, must be counter or gauge",
+				object.Name, counter.Name, counter.Type)
+		}
+	}
+
+	return object, nil
+}
+
+// checkFields returns an error, if the node is not a mapping or contains a key that is not a field of t.
+func checkFields(node *yaml.Node, t reflect.Type) error {
+	if node.Kind != yaml.MappingNode {
+		return nodeError(node, "cannot unmarshal %s into %s", node.ShortTag(), t)
+	}
+
+	fields := make(map[string]struct{}, t.NumField())
+
+	for i := range t.NumField() {
+		if field := t.Field(i); field.IsExported() {
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			fields[name] = struct{}{}
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if _, ok := fields[node.Content[i].Value]; !ok {
+			return nodeError(node.Content[i], "field %s not found in type %s", node.Content[i].Value, t)
+		}
+	}
+
 	return nil
 }
+
+// mappingValue returns the value of the key in the mapping node, or the mapping node, if the key doesn't exist.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return node
+}
+
+func nodeError(node *yaml.Node, format string, args ...any) error {
+	return fmt.Errorf("line %d, column %d: %s", node.Line, node.Column, fmt.Sprintf(format, args...))
+}
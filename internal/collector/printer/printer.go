@@ -18,6 +18,7 @@
 package printer
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -28,6 +29,7 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -58,6 +60,8 @@ var ConfigDefaults = Config{
 }
 
 type Collector struct {
+	types.DescRecorder
+
 	config             Config
 	miSession          *mi.Session
 	miQueryPrinterJobs mi.Query
@@ -129,19 +133,19 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
-	c.printerJobStatus = prometheus.NewDesc(
+	c.printerJobStatus = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "job_status"),
 		"A counter of printer jobs by status",
 		[]string{"printer", "status"},
 		nil,
 	)
-	c.printerStatus = prometheus.NewDesc(
+	c.printerStatus = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "status"),
 		"Printer status",
 		[]string{"printer", "status"},
 		nil,
 	)
-	c.printerJobCount = prometheus.NewDesc(
+	c.printerJobCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "job_count"),
 		"Number of jobs processed by the printer since the last reset",
 		[]string{"printer"},
@@ -185,13 +189,21 @@ type wmiPrintJob struct {
 }
 
 func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.CollectWithContext(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but cancels the WMI query once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var errs []error
 
 	if err := c.collectPrinterStatus(ch); err != nil {
 		errs = append(errs, fmt.Errorf("failed to collect printer status metrics: %w", err))
 	}
 
-	if err := c.collectPrinterJobStatus(ch, maxScrapeDuration); err != nil {
+	if err := c.collectPrinterJobStatus(ctx, ch); err != nil {
 		errs = append(errs, fmt.Errorf("failed to collect printer job status metrics: %w", err))
 	}
 
@@ -236,9 +248,9 @@ func (c *Collector) collectPrinterStatus(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
-func (c *Collector) collectPrinterJobStatus(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+func (c *Collector) collectPrinterJobStatus(ctx context.Context, ch chan<- prometheus.Metric) error {
 	var printJobs []wmiPrintJob
-	if err := c.miSession.Query(&printJobs, mi.NamespaceRootCIMv2, c.miQueryPrinterJobs, maxScrapeDuration); err != nil {
+	if err := c.miSession.QueryContext(ctx, &printJobs, mi.NamespaceRootCIMv2, c.miQueryPrinterJobs); err != nil {
 		return fmt.Errorf("WMI query failed: %w", err)
 	}
 
@@ -35,6 +35,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/pdh/registry"
 	pdhtypes "github.com/prometheus-community/windows_exporter/internal/pdh/types"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/windows"
 )
@@ -59,6 +60,8 @@ var ConfigDefaults = Config{
 }
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	logger *slog.Logger
@@ -220,98 +223,98 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 		logger.Warn("No filters specified for process collector. This will generate a very large number of metrics!")
 	}
 
-	c.info = prometheus.NewDesc(
+	c.info = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"Process information.",
 		[]string{"process", "process_id", "creating_process_id", "process_group_id", "owner", "cmdline"},
 		nil,
 	)
 
-	c.startTime = prometheus.NewDesc(
+	c.startTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "start_time_seconds_timestamp"),
 		"Time of process start.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.cpuTimeTotal = prometheus.NewDesc(
+	c.cpuTimeTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "cpu_time_total"),
 		"Returns elapsed time that all of the threads of this process used the processor to execute instructions by mode (privileged, user).",
 		[]string{"process", "process_id", "mode"},
 		nil,
 	)
-	c.handleCount = prometheus.NewDesc(
+	c.handleCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "handles"),
 		"Total number of handles the process has open. This number is the sum of the handles currently open by each thread in the process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.ioBytesTotal = prometheus.NewDesc(
+	c.ioBytesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "io_bytes_total"),
 		"Bytes issued to I/O operations in different modes (read, write, other).",
 		[]string{"process", "process_id", "mode"},
 		nil,
 	)
-	c.ioOperationsTotal = prometheus.NewDesc(
+	c.ioOperationsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "io_operations_total"),
 		"I/O operations issued in different modes (read, write, other).",
 		[]string{"process", "process_id", "mode"},
 		nil,
 	)
-	c.pageFaultsTotal = prometheus.NewDesc(
+	c.pageFaultsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "page_faults_total"),
 		"Page faults by the threads executing in this process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.pageFileBytes = prometheus.NewDesc(
+	c.pageFileBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "page_file_bytes"),
 		"Current number of bytes this process has used in the paging file(s).",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.poolBytes = prometheus.NewDesc(
+	c.poolBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pool_bytes"),
 		"Pool Bytes is the last observed number of bytes in the paged or nonpaged pool.",
 		[]string{"process", "process_id", "pool"},
 		nil,
 	)
-	c.priorityBase = prometheus.NewDesc(
+	c.priorityBase = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "priority_base"),
 		"Current base priority of this process. Threads within a process can raise and lower their own base priority relative to the process base priority of the process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.privateBytes = prometheus.NewDesc(
+	c.privateBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "private_bytes"),
 		"Current number of bytes this process has allocated that cannot be shared with other processes.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.threadCount = prometheus.NewDesc(
+	c.threadCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "threads"),
 		"Number of threads currently active in this process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.virtualBytes = prometheus.NewDesc(
+	c.virtualBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "virtual_bytes"),
 		"Current size, in bytes, of the virtual address space that the process is using.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.workingSetPrivate = prometheus.NewDesc(
+	c.workingSetPrivate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "working_set_private_bytes"),
 		"Size of the working set, in bytes, that is use for this process only and not shared nor shareable by other processes.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.workingSetPeak = prometheus.NewDesc(
+	c.workingSetPeak = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "working_set_peak_bytes"),
 		"Maximum size, in bytes, of the Working Set of this process at any point in time. The Working Set is the set of memory pages touched recently by the threads in the process.",
 		[]string{"process", "process_id"},
 		nil,
 	)
-	c.workingSet = prometheus.NewDesc(
+	c.workingSet = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "working_set_bytes"),
 		"Maximum number of bytes in the working set of this process at any point in time. The working set is the set of memory pages touched recently by the threads in the process.",
 		[]string{"process", "process_id"},
@@ -344,7 +347,15 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 }
 
 func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
-	return c.collect(ch, maxScrapeDuration)
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.collect(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but stops the collection once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
+	return c.collect(ctx, ch)
 }
 
 // ref: https://github.com/microsoft/hcsshim/blob/8beabacfc2d21767a07c20f8dd5f9f3932dbf305/internal/uvm/stats.go#L25
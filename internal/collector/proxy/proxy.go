@@ -0,0 +1,514 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/version"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+const Name = "proxy"
+
+const (
+	// sourceLabel is added to the metrics of each target.
+	sourceLabel = "source"
+	// exportedSourceLabel holds the source label of the target, if the target exposes one.
+	exportedSourceLabel = model.ExportedLabelPrefix + sourceLabel
+
+	acceptHeader = `text/plain;version=0.0.4;q=1,*/*;q=0.1`
+
+	// scrapeTimeoutOffset is subtracted from the remaining scrape time, so that
+	// targets that time out are reported before the collector times out.
+	scrapeTimeoutOffset = 500 * time.Millisecond
+
+	// maxResponseSize is the maximum size of the response of a target.
+	maxResponseSize = 64 << 20
+)
+
+type Config struct {
+	Targets []Target `yaml:"targets"`
+	// KeepExporterMetrics keeps the families of the targets with the prefixes of [exporterMetricPrefixes].
+	// It is set, if the metrics about windows_exporter itself are disabled by --web.disable-exporter-metrics.
+	KeepExporterMetrics bool `yaml:"-"`
+}
+
+// Target is a local exporter whose metrics are re-exposed by the collector.
+type Target struct {
+	// Source is the value of the source label added to the metrics of the target.
+	Source string `yaml:"source"`
+	URL    string `yaml:"url"`
+}
+
+// exporterMetricPrefixes are the prefixes of the metrics about windows_exporter itself, see --web.disable-exporter-metrics.
+// The families of the targets with these prefixes are skipped, unless [Config.KeepExporterMetrics] is set.
+// They collide with the families of windows_exporter, e.g. go_goroutines of a Go exporter, and fail the whole scrape,
+// if their help text differs.
+//
+//nolint:gochecknoglobals
+var exporterMetricPrefixes = []string{"go_", "process_", "promhttp_"}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	Targets: make([]Target, 0),
+}
+
+// A Collector is a Prometheus collector for metrics of other local exporters.
+type Collector struct {
+	types.DescRecorder
+
+	config Config
+	logger *slog.Logger
+
+	client *http.Client
+
+	// skipWarned holds the sources of the targets, whose skipped exporter metrics were logged already.
+	skipWarnedMu sync.Mutex
+	skipWarned   map[string]struct{}
+
+	upDesc             *prometheus.Desc
+	scrapeDurationDesc *prometheus.Desc
+}
+
+// targetResult is the result of the scrape of a target.
+type targetResult struct {
+	metricFamilies []*dto.MetricFamily
+	duration       time.Duration
+	err            error
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	if config.Targets == nil {
+		config.Targets = ConfigDefaults.Targets
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	var targets string
+
+	app.Flag(
+		"collector.proxy.targets",
+		"Local exporters to scrape, as YAML list of source and url, e.g. [{source: app, url: \"http://localhost:8080/metrics\"}]. By default, no targets are scraped.",
+	).Default("").StringVar(&targets)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		// The flag is registered by windows_exporter, not by the collector.
+		if flag := app.GetFlag("web.disable-exporter-metrics"); flag != nil {
+			c.config.KeepExporterMetrics = flag.Model().String() == "true"
+		}
+
+		if targets == "" {
+			return nil
+		}
+
+		if err := yaml.Unmarshal([]byte(targets), &c.config.Targets); err != nil {
+			return fmt.Errorf("failed to parse targets %s: %w", targets, err)
+		}
+
+		return nil
+	})
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) Close() error {
+	if c.client != nil {
+		c.client.CloseIdleConnections()
+	}
+
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	sources := make([]string, 0, len(c.config.Targets))
+
+	for _, target := range c.config.Targets {
+		if target.Source == "" {
+			return fmt.Errorf("target %s: source is required", target.URL)
+		}
+
+		if slices.Contains(sources, target.Source) {
+			return fmt.Errorf("target %s: source is duplicated", target.Source)
+		}
+
+		sources = append(sources, target.Source)
+
+		u, err := url.Parse(target.URL)
+		if err != nil {
+			return fmt.Errorf("target %s: invalid url: %w", target.Source, err)
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("target %s: unsupported url scheme %q", target.Source, u.Scheme)
+		}
+	}
+
+	c.client = &http.Client{}
+	c.skipWarned = make(map[string]struct{})
+
+	c.upDesc = c.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "up"),
+		"1 if the last scrape of the target was successful, 0 otherwise.",
+		[]string{sourceLabel},
+		nil,
+	)
+	c.scrapeDurationDesc = c.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "scrape_duration_seconds"),
+		"Duration of the last scrape of the target.",
+		[]string{sourceLabel},
+		nil,
+	)
+
+	return nil
+}
+
+// Collect sends the metric values for each metric to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.CollectWithContext(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but the scrapes of the targets are canceled once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
+	results := make([]targetResult, len(c.config.Targets))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(c.config.Targets))
+
+	for i, target := range c.config.Targets {
+		go func() {
+			defer wg.Done()
+
+			results[i] = c.scrapeTarget(ctx, target)
+		}()
+	}
+
+	wg.Wait()
+
+	var metricFamilies []*dto.MetricFamily
+
+	errs := make([]error, 0)
+
+	for i, target := range c.config.Targets {
+		result := results[i]
+
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("error scraping target %s: %w", target.Source, result.err))
+		} else {
+			metricFamilies = append(metricFamilies, addSourceLabel(result.metricFamilies, target.Source)...)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.upDesc,
+			prometheus.GaugeValue,
+			utils.BoolToFloat(result.err == nil),
+			target.Source,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.scrapeDurationDesc,
+			prometheus.GaugeValue,
+			result.duration.Seconds(),
+			target.Source,
+		)
+	}
+
+	// If duplicates are detected across *multiple* targets, no metrics of the targets are exposed.
+	if utils.DuplicateMetricEntry(metricFamilies) {
+		c.logger.Warn("duplicate metrics detected across multiple targets")
+
+		return errors.Join(errs...)
+	}
+
+	// Families with the same name must have the same type and help across targets.
+	// The first target defines them.
+	seen := make(map[string]*dto.MetricFamily)
+
+	for _, mf := range metricFamilies {
+		first, ok := seen[mf.GetName()]
+
+		switch {
+		case !ok:
+			seen[mf.GetName()] = mf
+		case first.GetType() != mf.GetType():
+			errs = append(errs, fmt.Errorf("metric %s has type %s, but type %s was exposed by another target",
+				mf.GetName(), mf.GetType(), first.GetType(),
+			))
+
+			continue
+		default:
+			mf.Help = first.Help
+		}
+
+		if err := convertMetricFamily(mf, ch); err != nil {
+			errs = append(errs, fmt.Errorf("error converting metric %s: %w", mf.GetName(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// scrapeTarget scrapes the target with a timeout derived from the deadline of the context.
+func (c *Collector) scrapeTarget(ctx context.Context, target Target) targetResult {
+	start := time.Now()
+
+	metricFamilies, err := c.scrape(ctx, target)
+
+	return targetResult{
+		metricFamilies: metricFamilies,
+		duration:       time.Since(start),
+		err:            err,
+	}
+}
+
+func (c *Collector) scrape(ctx context.Context, target Target) ([]*dto.MetricFamily, error) {
+	var timeout time.Duration
+
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+		if timeout > 2*scrapeTimeoutOffset {
+			timeout -= scrapeTimeoutOffset
+		} else {
+			timeout /= 2
+		}
+
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", acceptHeader)
+	req.Header.Set("User-Agent", "windows_exporter/"+version.Version)
+
+	if timeout > 0 {
+		req.Header.Set("X-Prometheus-Scrape-Timeout-Seconds", strconv.FormatFloat(timeout.Seconds(), 'f', -1, 64))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if len(data) > maxResponseSize {
+		return nil, fmt.Errorf("response exceeds %d bytes", maxResponseSize)
+	}
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+
+	parsedFamilies, err := parser.TextToMetricFamilies(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+
+	metricFamilies := make([]*dto.MetricFamily, 0, len(parsedFamilies))
+
+	var skipped []string
+
+	for _, name := range slices.Sorted(maps.Keys(parsedFamilies)) {
+		if !c.config.KeepExporterMetrics && isExporterMetric(name) {
+			skipped = append(skipped, name)
+
+			continue
+		}
+
+		metricFamilies = append(metricFamilies, parsedFamilies[name])
+	}
+
+	if len(skipped) > 0 && c.firstSkip(target.Source) {
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "skipping metrics of target, because they collide with the metrics of windows_exporter. Set --web.disable-exporter-metrics to keep them",
+			slog.String("source", target.Source),
+			slog.Any("metrics", skipped),
+		)
+	}
+
+	// If duplicate metrics are detected in a *single* target, skip processing of target metrics
+	if utils.DuplicateMetricEntry(metricFamilies) {
+		return nil, errors.New("duplicate metrics detected")
+	}
+
+	return metricFamilies, nil
+}
+
+// firstSkip reports whether exporter metrics of the target are skipped for the first time.
+func (c *Collector) firstSkip(source string) bool {
+	c.skipWarnedMu.Lock()
+	defer c.skipWarnedMu.Unlock()
+
+	if _, ok := c.skipWarned[source]; ok {
+		return false
+	}
+
+	c.skipWarned[source] = struct{}{}
+
+	return true
+}
+
+// isExporterMetric reports whether the metric name has the prefix of a metric about windows_exporter itself.
+func isExporterMetric(name string) bool {
+	return slices.ContainsFunc(exporterMetricPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(name, prefix)
+	})
+}
+
+// addSourceLabel adds the source label to all metrics. An existing source label is renamed to exported_source.
+func addSourceLabel(metricFamilies []*dto.MetricFamily, source string) []*dto.MetricFamily {
+	for _, mf := range metricFamilies {
+		for _, metric := range mf.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == sourceLabel {
+					label.Name = proto.String(exportedSourceLabel)
+				}
+			}
+
+			metric.Label = append(metric.Label, &dto.LabelPair{
+				Name:  proto.String(sourceLabel),
+				Value: proto.String(source),
+			})
+		}
+	}
+
+	return metricFamilies
+}
+
+func convertMetricFamily(metricFamily *dto.MetricFamily, ch chan<- prometheus.Metric) error {
+	for _, metric := range metricFamily.GetMetric() {
+		names := make([]string, 0, len(metric.GetLabel()))
+		values := make([]string, 0, len(metric.GetLabel()))
+
+		for _, label := range metric.GetLabel() {
+			names = append(names, label.GetName())
+			values = append(values, label.GetValue())
+		}
+
+		desc := prometheus.NewDesc(metricFamily.GetName(), metricFamily.GetHelp(), names, nil)
+
+		var (
+			m   prometheus.Metric
+			err error
+		)
+
+		switch metricFamily.GetType() {
+		case dto.MetricType_COUNTER:
+			m, err = prometheus.NewConstMetric(desc, prometheus.CounterValue, metric.GetCounter().GetValue(), values...)
+		case dto.MetricType_GAUGE:
+			m, err = prometheus.NewConstMetric(desc, prometheus.GaugeValue, metric.GetGauge().GetValue(), values...)
+		case dto.MetricType_UNTYPED:
+			m, err = prometheus.NewConstMetric(desc, prometheus.UntypedValue, metric.GetUntyped().GetValue(), values...)
+		case dto.MetricType_SUMMARY:
+			quantiles := make(map[float64]float64, len(metric.GetSummary().GetQuantile()))
+			for _, q := range metric.GetSummary().GetQuantile() {
+				quantiles[q.GetQuantile()] = q.GetValue()
+			}
+
+			m, err = prometheus.NewConstSummary(desc,
+				metric.GetSummary().GetSampleCount(),
+				metric.GetSummary().GetSampleSum(),
+				quantiles, values...,
+			)
+		case dto.MetricType_HISTOGRAM:
+			buckets := make(map[float64]uint64, len(metric.GetHistogram().GetBucket()))
+			for _, b := range metric.GetHistogram().GetBucket() {
+				buckets[b.GetUpperBound()] = b.GetCumulativeCount()
+			}
+
+			m, err = prometheus.NewConstHistogram(desc,
+				metric.GetHistogram().GetSampleCount(),
+				metric.GetHistogram().GetSampleSum(),
+				buckets, values...,
+			)
+		default:
+			return fmt.Errorf("unsupported metric type %s", metricFamily.GetType())
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if metric.TimestampMs != nil {
+			m = prometheus.NewMetricWithTimestamp(time.UnixMilli(metric.GetTimestampMs()), m)
+		}
+
+		ch <- m
+	}
+
+	return nil
+}
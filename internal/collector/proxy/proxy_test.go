@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package proxy_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/collector/proxy"
+	"github.com/prometheus-community/windows_exporter/internal/utils/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func BenchmarkCollector(b *testing.B) {
+	testutils.FuncBenchmarkCollector(b, proxy.Name, proxy.NewWithFlags)
+}
+
+func TestCollector(t *testing.T) {
+	testutils.TestCollector(t, proxy.New, nil)
+}
+
+func TestCollectTargets(t *testing.T) {
+	t.Parallel()
+
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`# HELP app_requests_total Requests handled by the app.
+# TYPE app_requests_total counter
+app_requests_total{source="api"} 42
+# HELP go_goroutines Number of goroutines of the app.
+# TYPE go_goroutines gauge
+go_goroutines 12
+`))
+	}))
+	t.Cleanup(app.Close)
+
+	duplicates := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`# TYPE sql_up gauge
+sql_up 1
+sql_up 1
+`))
+	}))
+	t.Cleanup(duplicates.Close)
+
+	c := proxy.New(&proxy.Config{
+		Targets: []proxy.Target{
+			{Source: "app", URL: app.URL},
+			{Source: "sql", URL: duplicates.URL},
+		},
+	})
+
+	require.NoError(t, c.Build(slog.New(slog.DiscardHandler), nil))
+	t.Cleanup(func() { require.NoError(t, c.Close()) })
+
+	var collectErr error
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collectorFunc(func(ch chan<- prometheus.Metric) {
+		collectErr = c.Collect(ch, 10*time.Second)
+	})))
+
+	err := testutil.GatherAndCompare(registry, strings.NewReader(`# HELP app_requests_total Requests handled by the app.
+# TYPE app_requests_total counter
+app_requests_total{exported_source="api",source="app"} 42
+# HELP windows_proxy_up 1 if the last scrape of the target was successful, 0 otherwise.
+# TYPE windows_proxy_up gauge
+windows_proxy_up{source="app"} 1
+windows_proxy_up{source="sql"} 0
+`), "app_requests_total", "go_goroutines", "windows_proxy_up")
+	require.NoError(t, err)
+	require.ErrorContains(t, collectErr, "error scraping target sql: duplicate metrics detected")
+}
+
+func TestCollectTargetsKeepExporterMetrics(t *testing.T) {
+	t.Parallel()
+
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`# HELP go_goroutines Number of goroutines of the app.
+# TYPE go_goroutines gauge
+go_goroutines 12
+`))
+	}))
+	t.Cleanup(app.Close)
+
+	c := proxy.New(&proxy.Config{
+		Targets:             []proxy.Target{{Source: "app", URL: app.URL}},
+		KeepExporterMetrics: true,
+	})
+
+	require.NoError(t, c.Build(slog.New(slog.DiscardHandler), nil))
+	t.Cleanup(func() { require.NoError(t, c.Close()) })
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collectorFunc(func(ch chan<- prometheus.Metric) {
+		_ = c.Collect(ch, 10*time.Second)
+	})))
+
+	err := testutil.GatherAndCompare(registry, strings.NewReader(`# HELP go_goroutines Number of goroutines of the app.
+# TYPE go_goroutines gauge
+go_goroutines{source="app"} 12
+`), "go_goroutines")
+	require.NoError(t, err)
+}
+
+// collectorFunc is an unchecked [prometheus.Collector].
+type collectorFunc func(ch chan<- prometheus.Metric)
+
+func (f collectorFunc) Describe(chan<- *prometheus.Desc) {}
+
+func (f collectorFunc) Collect(ch chan<- prometheus.Metric) {
+	f(ch)
+}
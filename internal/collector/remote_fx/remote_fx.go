@@ -45,6 +45,8 @@ var ConfigDefaults = Config{}
 // https://wutils.com/wmi/root/cimv2/win32_perfrawdata_counters_remotefxnetwork/
 // https://wutils.com/wmi/root/cimv2/win32_perfrawdata_counters_remotefxgraphics/
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollectorNetwork  *pdh.Collector
@@ -106,79 +108,79 @@ func (c *Collector) Close() error {
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	// net
-	c.baseTCPRTT = prometheus.NewDesc(
+	c.baseTCPRTT = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_base_tcp_rtt_seconds"),
 		"Base TCP round-trip time (RTT) detected in seconds",
 		[]string{"session_name"},
 		nil,
 	)
-	c.baseUDPRTT = prometheus.NewDesc(
+	c.baseUDPRTT = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_base_udp_rtt_seconds"),
 		"Base UDP round-trip time (RTT) detected in seconds.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.currentTCPBandwidth = prometheus.NewDesc(
+	c.currentTCPBandwidth = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_current_tcp_bandwidth"),
 		"TCP Bandwidth detected in bytes per second.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.currentTCPRTT = prometheus.NewDesc(
+	c.currentTCPRTT = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_current_tcp_rtt_seconds"),
 		"Average TCP round-trip time (RTT) detected in seconds.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.currentUDPBandwidth = prometheus.NewDesc(
+	c.currentUDPBandwidth = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_current_udp_bandwidth"),
 		"UDP Bandwidth detected in bytes per second.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.currentUDPRTT = prometheus.NewDesc(
+	c.currentUDPRTT = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_current_udp_rtt_seconds"),
 		"Average UDP round-trip time (RTT) detected in seconds.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.totalReceivedBytes = prometheus.NewDesc(
+	c.totalReceivedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_received_bytes_total"),
 		"(TotalReceivedBytes)",
 		[]string{"session_name"},
 		nil,
 	)
-	c.totalSentBytes = prometheus.NewDesc(
+	c.totalSentBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_sent_bytes_total"),
 		"(TotalSentBytes)",
 		[]string{"session_name"},
 		nil,
 	)
-	c.udpPacketsReceivedPerSec = prometheus.NewDesc(
+	c.udpPacketsReceivedPerSec = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_udp_packets_received_total"),
 		"Rate in packets per second at which packets are received over UDP.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.udpPacketsSentPerSec = prometheus.NewDesc(
+	c.udpPacketsSentPerSec = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_udp_packets_sent_total"),
 		"Rate in packets per second at which packets are sent over UDP.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.fecRate = prometheus.NewDesc(
+	c.fecRate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_fec_rate"),
 		"Forward Error Correction (FEC) percentage",
 		[]string{"session_name"},
 		nil,
 	)
-	c.lossRate = prometheus.NewDesc(
+	c.lossRate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_loss_rate"),
 		"Loss percentage",
 		[]string{"session_name"},
 		nil,
 	)
-	c.retransmissionRate = prometheus.NewDesc(
+	c.retransmissionRate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "net_retransmission_rate"),
 		"Percentage of packets that have been retransmitted",
 		[]string{"session_name"},
@@ -186,43 +188,43 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	)
 
 	// gfx
-	c.averageEncodingTime = prometheus.NewDesc(
+	c.averageEncodingTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_average_encoding_time_seconds"),
 		"Average frame encoding time in seconds",
 		[]string{"session_name"},
 		nil,
 	)
-	c.frameQuality = prometheus.NewDesc(
+	c.frameQuality = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_frame_quality"),
 		"Quality of the output frame expressed as a percentage of the quality of the source frame.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.framesSkippedPerSecondInsufficientResources = prometheus.NewDesc(
+	c.framesSkippedPerSecondInsufficientResources = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_frames_skipped_insufficient_resource_total"),
 		"Number of frames skipped per second due to insufficient client resources.",
 		[]string{"session_name", "resource"},
 		nil,
 	)
-	c.graphicsCompressionRatio = prometheus.NewDesc(
+	c.graphicsCompressionRatio = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_graphics_compression_ratio"),
 		"Ratio of the number of bytes encoded to the number of bytes input.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.inputFramesPerSecond = prometheus.NewDesc(
+	c.inputFramesPerSecond = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_input_frames_total"),
 		"Number of sources frames provided as input to RemoteFX graphics per second.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.outputFramesPerSecond = prometheus.NewDesc(
+	c.outputFramesPerSecond = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_output_frames_total"),
 		"Number of frames sent to the client per second.",
 		[]string{"session_name"},
 		nil,
 	)
-	c.sourceFramesPerSecond = prometheus.NewDesc(
+	c.sourceFramesPerSecond = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "gfx_source_frames_total"),
 		"Number of frames composed by the source (DWM) per second.",
 		[]string{"session_name"},
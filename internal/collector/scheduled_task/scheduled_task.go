@@ -18,6 +18,7 @@
 package scheduled_task
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -31,6 +32,7 @@ import (
 	"github.com/go-ole/go-ole/oleutil"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -48,6 +50,8 @@ var ConfigDefaults = Config{
 }
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	lastResult *prometheus.Desc
@@ -150,21 +154,21 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
-	c.lastResult = prometheus.NewDesc(
+	c.lastResult = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "last_result"),
 		"The result that was returned the last time the registered task was run",
 		[]string{"task"},
 		nil,
 	)
 
-	c.missedRuns = prometheus.NewDesc(
+	c.missedRuns = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "missed_runs"),
 		"The number of times the registered task missed a scheduled run",
 		[]string{"task"},
 		nil,
 	)
 
-	c.state = prometheus.NewDesc(
+	c.state = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "state"),
 		"The current state of a scheduled task",
 		[]string{"task", "state"},
@@ -174,15 +178,23 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 	return nil
 }
 
-func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
-	return c.collect(ch)
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	ctx, cancel := utils.ContextWithScrapeTimeout(maxScrapeDuration)
+	defer cancel()
+
+	return c.collect(ctx, ch)
+}
+
+// CollectWithContext is like Collect, but stops walking the task folders once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
+	return c.collect(ctx, ch)
 }
 
 //nolint:gochecknoglobals
 var TASK_STATES = []string{"disabled", "queued", "ready", "running", "unknown"}
 
-func (c *Collector) collect(ch chan<- prometheus.Metric) error {
-	scheduledTasks, err := getScheduledTasks()
+func (c *Collector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	scheduledTasks, err := getScheduledTasks(ctx)
 	if err != nil {
 		return fmt.Errorf("get scheduled tasks: %w", err)
 	}
@@ -241,7 +253,7 @@ const SCHEDULED_TASK_PROGRAM_ID = "Schedule.Service.1"
 // S_FALSE is returned by CoInitialize if it was already called on this thread.
 const S_FALSE = 0x00000001
 
-func getScheduledTasks() (ScheduledTasks, error) {
+func getScheduledTasks(ctx context.Context) (ScheduledTasks, error) {
 	var scheduledTasks ScheduledTasks
 
 	// The only way to run WMI queries in parallel while being thread-safe is to
@@ -289,12 +301,12 @@ func getScheduledTasks() (ScheduledTasks, error) {
 	rootFolderObj := res.ToIDispatch()
 	defer rootFolderObj.Release()
 
-	err = fetchTasksRecursively(rootFolderObj, &scheduledTasks)
+	err = fetchTasksRecursively(ctx, rootFolderObj, &scheduledTasks)
 
 	return scheduledTasks, err
 }
 
-func fetchTasksInFolder(folder *ole.IDispatch, scheduledTasks *ScheduledTasks) error {
+func fetchTasksInFolder(ctx context.Context, folder *ole.IDispatch, scheduledTasks *ScheduledTasks) error {
 	res, err := oleutil.CallMethod(folder, "GetTasks", 1)
 	if err != nil {
 		return err
@@ -307,6 +319,10 @@ func fetchTasksInFolder(folder *ole.IDispatch, scheduledTasks *ScheduledTasks) e
 		task := v.ToIDispatch()
 		defer task.Release()
 
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		parsedTask, err := parseTask(task)
 		if err != nil {
 			return err
@@ -320,8 +336,12 @@ func fetchTasksInFolder(folder *ole.IDispatch, scheduledTasks *ScheduledTasks) e
 	return err
 }
 
-func fetchTasksRecursively(folder *ole.IDispatch, scheduledTasks *ScheduledTasks) error {
-	if err := fetchTasksInFolder(folder, scheduledTasks); err != nil {
+func fetchTasksRecursively(ctx context.Context, folder *ole.IDispatch, scheduledTasks *ScheduledTasks) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := fetchTasksInFolder(ctx, folder, scheduledTasks); err != nil {
 		return err
 	}
 
@@ -337,7 +357,7 @@ func fetchTasksRecursively(folder *ole.IDispatch, scheduledTasks *ScheduledTasks
 		subFolder := v.ToIDispatch()
 		defer subFolder.Release()
 
-		return fetchTasksRecursively(subFolder, scheduledTasks)
+		return fetchTasksRecursively(ctx, subFolder, scheduledTasks)
 	})
 
 	return err
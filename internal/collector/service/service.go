@@ -56,6 +56,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus Collector for service metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	logger *slog.Logger
@@ -159,25 +161,25 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.queryAllServicesBuffer = make([]byte, 1024*200)
 
-	c.info = prometheus.NewDesc(
+	c.info = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"A metric with a constant '1' value labeled with service information",
 		[]string{"name", "display_name", "run_as", "path_name"},
 		nil,
 	)
-	c.state = prometheus.NewDesc(
+	c.state = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "state"),
 		"The state of the service (State)",
 		[]string{"name", "state"},
 		nil,
 	)
-	c.startMode = prometheus.NewDesc(
+	c.startMode = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "start_mode"),
 		"The start mode of the service (StartMode)",
 		[]string{"name", "start_mode"},
 		nil,
 	)
-	c.processID = prometheus.NewDesc(
+	c.processID = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process"),
 		"Process of started service. The value is the creation time of the process as a unix timestamp.",
 		[]string{"name", "process_id"},
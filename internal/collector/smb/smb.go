@@ -37,6 +37,8 @@ type Config struct{}
 var ConfigDefaults = Config{}
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -79,49 +81,49 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.currentOpenFileCount = prometheus.NewDesc(
+	c.currentOpenFileCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_current_open_file_count"),
 		"Current total count open files on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.treeConnectCount = prometheus.NewDesc(
+	c.treeConnectCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_tree_connect_count"),
 		"Count of user connections to the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.receivedBytes = prometheus.NewDesc(
+	c.receivedBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_received_bytes_total"),
 		"Received bytes on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.writeRequests = prometheus.NewDesc(
+	c.writeRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_write_requests_count_total"),
 		"Writes requests on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.readRequests = prometheus.NewDesc(
+	c.readRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_read_requests_count_total"),
 		"Read requests on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.metadataRequests = prometheus.NewDesc(
+	c.metadataRequests = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_metadata_requests_count_total"),
 		"Metadata requests on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.sentBytes = prometheus.NewDesc(
+	c.sentBytes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_sent_bytes_total"),
 		"Sent bytes on the SMB Server Share",
 		[]string{"share"},
 		nil,
 	)
-	c.filesOpened = prometheus.NewDesc(
+	c.filesOpened = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "server_shares_files_opened_count_total"),
 		"Files opened on the SMB Server Share",
 		[]string{"share"},
@@ -40,6 +40,8 @@ type Config struct{}
 var ConfigDefaults = Config{}
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -96,7 +98,7 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	// desc creates a new prometheus description
 	desc := func(metricName string, description string, labels []string) *prometheus.Desc {
-		return prometheus.NewDesc(
+		return c.NewDesc(
 			prometheus.BuildFQName(types.Namespace, Name, metricName),
 			description,
 			labels,
@@ -39,6 +39,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for WMI metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	bootTimeTimestamp float64
@@ -83,50 +85,50 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.bootTime = prometheus.NewDesc(
+	c.bootTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "boot_time_timestamp"),
 		"Unix timestamp of system boot time",
 		nil,
 		nil,
 	)
-	c.contextSwitchesTotal = prometheus.NewDesc(
+	c.contextSwitchesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "context_switches_total"),
 		"Total number of context switches (WMI source: PerfOS_System.ContextSwitchesPersec)",
 		nil,
 		nil,
 	)
-	c.exceptionDispatchesTotal = prometheus.NewDesc(
+	c.exceptionDispatchesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "exception_dispatches_total"),
 		"Total number of exceptions dispatched (WMI source: PerfOS_System.ExceptionDispatchesPersec)",
 		nil,
 		nil,
 	)
-	c.processes = prometheus.NewDesc(
+	c.processes = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processes"),
 		"Current number of processes (WMI source: PerfOS_System.Processes)",
 		nil,
 		nil,
 	)
-	c.processesLimit = prometheus.NewDesc(
+	c.processesLimit = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processes_limit"),
 		"Maximum number of processes.",
 		nil,
 		nil,
 	)
 
-	c.processorQueueLength = prometheus.NewDesc(
+	c.processorQueueLength = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processor_queue_length"),
 		"Length of processor queue (WMI source: PerfOS_System.ProcessorQueueLength)",
 		nil,
 		nil,
 	)
-	c.systemCallsTotal = prometheus.NewDesc(
+	c.systemCallsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "system_calls_total"),
 		"Total number of system calls (WMI source: PerfOS_System.SystemCallsPersec)",
 		nil,
 		nil,
 	)
-	c.threads = prometheus.NewDesc(
+	c.threads = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "threads"),
 		"Current number of threads (WMI source: PerfOS_System.Threads)",
 		nil,
@@ -58,6 +58,8 @@ var ConfigDefaults = Config{
 
 // A Collector is a Prometheus Collector for WMI Win32_PerfRawData_Tcpip_TCPv{4,6} metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector4 *pdh.Collector
@@ -131,61 +133,61 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	labels := []string{"af"}
 
-	c.connectionFailures = prometheus.NewDesc(
+	c.connectionFailures = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connection_failures_total"),
 		"(TCP.ConnectionFailures)",
 		labels,
 		nil,
 	)
-	c.connectionsActive = prometheus.NewDesc(
+	c.connectionsActive = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connections_active_total"),
 		"(TCP.ConnectionsActive)",
 		labels,
 		nil,
 	)
-	c.connectionsEstablished = prometheus.NewDesc(
+	c.connectionsEstablished = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connections_established"),
 		"(TCP.ConnectionsEstablished)",
 		labels,
 		nil,
 	)
-	c.connectionsPassive = prometheus.NewDesc(
+	c.connectionsPassive = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connections_passive_total"),
 		"(TCP.ConnectionsPassive)",
 		labels,
 		nil,
 	)
-	c.connectionsReset = prometheus.NewDesc(
+	c.connectionsReset = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connections_reset_total"),
 		"(TCP.ConnectionsReset)",
 		labels,
 		nil,
 	)
-	c.segmentsTotal = prometheus.NewDesc(
+	c.segmentsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "segments_total"),
 		"(TCP.SegmentsTotal)",
 		labels,
 		nil,
 	)
-	c.segmentsReceivedTotal = prometheus.NewDesc(
+	c.segmentsReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "segments_received_total"),
 		"(TCP.SegmentsReceivedTotal)",
 		labels,
 		nil,
 	)
-	c.segmentsRetransmittedTotal = prometheus.NewDesc(
+	c.segmentsRetransmittedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "segments_retransmitted_total"),
 		"(TCP.SegmentsRetransmittedTotal)",
 		labels,
 		nil,
 	)
-	c.segmentsSentTotal = prometheus.NewDesc(
+	c.segmentsSentTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "segments_sent_total"),
 		"(TCP.SegmentsSentTotal)",
 		labels,
 		nil,
 	)
-	c.connectionsStateCount = prometheus.NewDesc(
+	c.connectionsStateCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "connections_state_count"),
 		"Number of TCP connections by state and address family",
 		[]string{"af", "state"},
@@ -24,7 +24,6 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-	"reflect"
 	"slices"
 	"sort"
 	"strings"
@@ -34,6 +33,7 @@ import (
 	"github.com/dimchansky/utfbom"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
@@ -52,6 +52,8 @@ var ConfigDefaults = Config{
 }
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 	logger *slog.Logger
 
@@ -111,7 +113,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.logger.Info("textfile directories: " + strings.Join(c.config.TextFileDirectories, ","))
 
-	c.modTimeDesc = prometheus.NewDesc(
+	c.modTimeDesc = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, "textfile", "mtime_seconds"),
 		"Unixtime mtime of textfiles successfully read.",
 		[]string{"file"},
@@ -121,36 +123,6 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	return nil
 }
 
-// Given a slice of metric families, determine if any two entries are duplicates.
-// Duplicates will be detected where the metric name, labels and label values are identical.
-func duplicateMetricEntry(metricFamilies []*dto.MetricFamily) bool {
-	uniqueMetrics := make(map[string]map[string]string)
-
-	for _, metricFamily := range metricFamilies {
-		metricName := metricFamily.GetName()
-
-		for _, metric := range metricFamily.GetMetric() {
-			metricLabels := metric.GetLabel()
-			labels := make(map[string]string)
-
-			for _, label := range metricLabels {
-				labels[label.GetName()] = label.GetValue()
-			}
-			// Check if key is present before appending
-			_, mapContainsKey := uniqueMetrics[metricName]
-
-			// Duplicate metric found with identical labels & label values
-			if mapContainsKey && reflect.DeepEqual(uniqueMetrics[metricName], labels) {
-				return true
-			}
-
-			uniqueMetrics[metricName] = labels
-		}
-	}
-
-	return false
-}
-
 func (c *Collector) convertMetricFamily(logger *slog.Logger, metricFamily *dto.MetricFamily, ch chan<- prometheus.Metric) {
 	var valType prometheus.ValueType
 
@@ -358,7 +330,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 	c.exportMTimes(mTimes, ch)
 
 	// If duplicates are detected across *multiple* files, return error.
-	if duplicateMetricEntry(metricFamilies) {
+	if utils.DuplicateMetricEntry(metricFamilies) {
 		c.logger.Warn("duplicate metrics detected across multiple files")
 	} else {
 		for _, mf := range metricFamilies {
@@ -414,7 +386,7 @@ func scrapeFile(path string, logger *slog.Logger) ([]*dto.MetricFamily, error) {
 	}
 
 	// If duplicate metrics are detected in a *single* file, skip processing of file metrics
-	if duplicateMetricEntry(families_array) {
+	if utils.DuplicateMetricEntry(families_array) {
 		return nil, errors.New("duplicate metrics detected")
 	}
 
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/dimchansky/utfbom"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	dto "github.com/prometheus/client_model/go"
 )
 
@@ -123,7 +124,7 @@ func TestDuplicateMetricEntry(t *testing.T) {
 	duplicateFamily = append(duplicateFamily, &duplicate)
 
 	// Ensure detection for duplicate metrics
-	if !duplicateMetricEntry(duplicateFamily) {
+	if !utils.DuplicateMetricEntry(duplicateFamily) {
 		t.Errorf("Duplicate not found in duplicateFamily")
 	}
 
@@ -150,7 +151,7 @@ func TestDuplicateMetricEntry(t *testing.T) {
 	duplicateFamily = append(duplicateFamily, &differentLabels)
 
 	// Additional label on second metric should not be cause for duplicate detection
-	if duplicateMetricEntry(duplicateFamily) {
+	if utils.DuplicateMetricEntry(duplicateFamily) {
 		t.Errorf("Unexpected duplicate found in differentLabels")
 	}
 
@@ -175,7 +176,7 @@ func TestDuplicateMetricEntry(t *testing.T) {
 	duplicateFamily = append(duplicateFamily, &differentValues)
 
 	// Additional label with different values metric should not be cause for duplicate detection
-	if duplicateMetricEntry(duplicateFamily) {
+	if utils.DuplicateMetricEntry(duplicateFamily) {
 		t.Errorf("Unexpected duplicate found in differentValues")
 	}
 }
@@ -38,6 +38,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for WMI Win32_PerfRawData_Counters_ThermalZoneInformation metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -77,7 +79,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		slog.String("collector", c.GetName()),
 	)
 
-	c.temperature = prometheus.NewDesc(
+	c.temperature = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "temperature_celsius"),
 		"(Temperature)",
 		[]string{
@@ -85,7 +87,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		},
 		nil,
 	)
-	c.percentPassiveLimit = prometheus.NewDesc(
+	c.percentPassiveLimit = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "percent_passive_limit"),
 		"(PercentPassiveLimit)",
 		[]string{
@@ -93,7 +95,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		},
 		nil,
 	)
-	c.throttleReasons = prometheus.NewDesc(
+	c.throttleReasons = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "throttle_reasons"),
 		"(ThrottleReasons)",
 		[]string{
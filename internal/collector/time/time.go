@@ -59,6 +59,8 @@ var ConfigDefaults = Config{
 
 // Collector is a Prometheus Collector for Perflib counter metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -142,61 +144,61 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	// https://github.com/prometheus-community/windows_exporter/issues/1891
 	c.ppbCounterPresent = osversion.Build() >= osversion.LTSC2019
 
-	c.currentTime = prometheus.NewDesc(
+	c.currentTime = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_timestamp_seconds"),
 		"Current time as reported by the operating system, in unix time.",
 		nil,
 		nil,
 	)
-	c.timezone = prometheus.NewDesc(
+	c.timezone = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "timezone"),
 		"Current timezone as reported by the operating system.",
 		[]string{"timezone"},
 		nil,
 	)
-	c.clockSource = prometheus.NewDesc(
+	c.clockSource = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "clock_sync_source"),
 		"This value reflects the sync source of the system clock.",
 		[]string{"type"},
 		nil,
 	)
-	c.clockFrequencyAdjustment = prometheus.NewDesc(
+	c.clockFrequencyAdjustment = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "clock_frequency_adjustment"),
 		"This value reflects the adjustment made to the local system clock frequency by W32Time in nominal clock units. This counter helps visualize the finer adjustments being made by W32time to synchronize the local clock.",
 		nil,
 		nil,
 	)
-	c.clockFrequencyAdjustmentPPB = prometheus.NewDesc(
+	c.clockFrequencyAdjustmentPPB = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "clock_frequency_adjustment_ppb"),
 		"This value reflects the adjustment made to the local system clock frequency by W32Time in Parts Per Billion (PPB) units. 1 PPB adjustment imples the system clock was adjusted at a rate of 1 nanosecond per second. The smallest possible adjustment can vary and can be expected to be in the order of 100&apos;s of PPB. This counter helps visualize the finer actions being taken by W32time to synchronize the local clock.",
 		nil,
 		nil,
 	)
-	c.computedTimeOffset = prometheus.NewDesc(
+	c.computedTimeOffset = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "computed_time_offset_seconds"),
 		"Absolute time offset between the system clock and the chosen time source, in seconds",
 		nil,
 		nil,
 	)
-	c.ntpClientTimeSourceCount = prometheus.NewDesc(
+	c.ntpClientTimeSourceCount = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ntp_client_time_sources"),
 		"Active number of NTP Time sources being used by the client",
 		nil,
 		nil,
 	)
-	c.ntpRoundTripDelay = prometheus.NewDesc(
+	c.ntpRoundTripDelay = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ntp_round_trip_delay_seconds"),
 		"Roundtrip delay experienced by the NTP client in receiving a response from the server for the most recent request, in seconds",
 		nil,
 		nil,
 	)
-	c.ntpServerOutgoingResponsesTotal = prometheus.NewDesc(
+	c.ntpServerOutgoingResponsesTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ntp_server_outgoing_responses_total"),
 		"Total number of requests responded to by NTP server",
 		nil,
 		nil,
 	)
-	c.ntpServerIncomingRequestsTotal = prometheus.NewDesc(
+	c.ntpServerIncomingRequestsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ntp_server_incoming_requests_total"),
 		"Total number of requests received by NTP server",
 		nil,
@@ -39,6 +39,8 @@ var ConfigDefaults = Config{}
 
 // A Collector is a Prometheus Collector for WMI Win32_PerfRawData_Tcpip_TCPv{4,6} metrics.
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	perfDataCollector4 *pdh.Collector
@@ -84,25 +86,25 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
-	c.datagramsNoPortTotal = prometheus.NewDesc(
+	c.datagramsNoPortTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datagram_no_port_total"),
 		"Number of received UDP datagrams for which there was no application at the destination port",
 		[]string{"af"},
 		nil,
 	)
-	c.datagramsReceivedTotal = prometheus.NewDesc(
+	c.datagramsReceivedTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datagram_received_total"),
 		"UDP datagrams are delivered to UDP users",
 		[]string{"af"},
 		nil,
 	)
-	c.datagramsReceivedErrorsTotal = prometheus.NewDesc(
+	c.datagramsReceivedErrorsTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datagram_received_errors_total"),
 		"Number of received UDP datagrams that could not be delivered for reasons other than the lack of an application at the destination port",
 		[]string{"af"},
 		nil,
 	)
-	c.datagramsSentTotal = prometheus.NewDesc(
+	c.datagramsSentTotal = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datagram_sent_total"),
 		"UDP datagrams are sent from the entity",
 		[]string{"af"},
@@ -55,6 +55,8 @@ var (
 )
 
 type Collector struct {
+	types.DescRecorder
+
 	config Config
 
 	mu          sync.RWMutex
@@ -122,28 +124,28 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		return fmt.Errorf("failed to initialize Windows Update collector: %w", err)
 	}
 
-	c.pendingUpdate = prometheus.NewDesc(
+	c.pendingUpdate = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pending_info"),
 		"Expose information for a single pending update item",
 		[]string{"id", "revision", "category", "severity", "title"},
 		nil,
 	)
 
-	c.pendingUpdateLastPublished = prometheus.NewDesc(
+	c.pendingUpdateLastPublished = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pending_published_timestamp"),
 		"Expose last published timestamp for a single pending update item",
 		[]string{"id", "revision"},
 		nil,
 	)
 
-	c.queryDurationSeconds = prometheus.NewDesc(
+	c.queryDurationSeconds = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "scrape_query_duration_seconds"),
 		"Duration of the last scrape query to the Windows Update API",
 		nil,
 		nil,
 	)
 
-	c.lastScrapeMetric = prometheus.NewDesc(
+	c.lastScrapeMetric = c.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "scrape_timestamp_seconds"),
 		"Timestamp of the last scrape",
 		nil,
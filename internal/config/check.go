@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+	"go.yaml.in/yaml/v3"
+)
+
+// errorLocationRe matches the location at the start of the errors of the YAML decoder and the unmarshalers.
+//
+//nolint:gochecknoglobals
+var errorLocationRe = regexp.MustCompile(`(?s)^(?:yaml: )?line (\d+)(?:, column (\d+))?: (.*)$`)
+
+// Diagnostic is a problem of a configuration file found by [Check].
+type Diagnostic struct {
+	File string
+	// Line and Column are 0, if the location is unknown.
+	Line    int
+	Column  int
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	switch {
+	case d.Line == 0:
+		return fmt.Sprintf("%s: %s", d.File, d.Message)
+	case d.Column == 0:
+		return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+	default:
+		return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+	}
+}
+
+// Check validates the configuration file and the files of the configuration directory and returns the problems found.
+// Besides the structure, it checks the collector names of collectors.enabled and collectors.disabled
+// against the available collectors and the regular expressions of the include and exclude filters.
+// The files are checked on their own, conflicts between the files are reported by [MergeConfigFiles].
+func Check(configFile, configDir string, available []string) ([]Diagnostic, error) {
+	files, err := configFiles(configFile, configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnostics []Diagnostic
+
+	for _, file := range files {
+		data, err := readConfigFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		diagnostics = append(diagnostics, checkFile(file, data, available)...)
+	}
+
+	return diagnostics, nil
+}
+
+func checkFile(file string, data []byte, available []string) []Diagnostic {
+	var root yaml.Node
+
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return diagnosticsFromError(file, err)
+	}
+
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	var diagnostics []Diagnostic
+
+	if _, err := expandNode(&root, true, false); err != nil {
+		diagnostics = diagnosticsFromError(file, err)
+	}
+
+	document := root.Content[0]
+
+	diagnostics = append(diagnostics, checkCollectorNames(file, document, available)...)
+	diagnostics = append(diagnostics, checkFilters(file, document)...)
+
+	var configFileStructure configFile
+
+	// Invalid regular expressions stop the decoder and are returned without location. They are already reported
+	// by checkFilters, type errors after them are found once they are fixed.
+	if err := decodeConfig(document, &configFileStructure); err != nil && !errors.As(err, new(*syntax.Error)) {
+		diagnostics = append(diagnostics, diagnosticsFromError(file, err)...)
+	}
+
+	slices.SortStableFunc(diagnostics, func(a, b Diagnostic) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+
+	return diagnostics
+}
+
+// checkCollectorNames checks the names of collectors.enabled and collectors.disabled.
+func checkCollectorNames(file string, document *yaml.Node, available []string) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	for _, key := range []string{"enabled", "disabled"} {
+		node := lookupNode(document, "collectors", key)
+		if node == nil || node.Kind != yaml.ScalarNode {
+			continue
+		}
+
+		for _, name := range strings.Split(node.Value, ",") {
+			if slices.Contains(available, name) || (key == "enabled" && name == "[defaults]") {
+				continue
+			}
+
+			diagnostics = append(diagnostics, Diagnostic{
+				File:    file,
+				Line:    node.Line,
+				Column:  node.Column,
+				Message: fmt.Sprintf("collectors.%s: unknown collector %q", key, name),
+			})
+		}
+	}
+
+	return diagnostics
+}
+
+// checkFilters checks the regular expressions of the collector options, e.g., collector.service.include.
+// The regular expressions are compiled like the collectors do.
+func checkFilters(file string, document *yaml.Node) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	regexpType := reflect.TypeFor[*regexp.Regexp]()
+	collectorConfigType := reflect.TypeFor[collector.Config]()
+
+	for i := range collectorConfigType.NumField() {
+		collectorField := collectorConfigType.Field(i)
+
+		collectorName := yamlName(collectorField)
+		if collectorName == "" || collectorField.Type.Kind() != reflect.Struct {
+			continue
+		}
+
+		for j := range collectorField.Type.NumField() {
+			field := collectorField.Type.Field(j)
+			if field.Type != regexpType {
+				continue
+			}
+
+			node := lookupNode(document, "collector", collectorName, yamlName(field))
+			if node == nil || node.Kind != yaml.ScalarNode {
+				continue
+			}
+
+			if _, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", node.Value)); err != nil {
+				diagnostics = append(diagnostics, Diagnostic{
+					File:    file,
+					Line:    node.Line,
+					Column:  node.Column,
+					Message: fmt.Sprintf("collector.%s.%s: invalid regular expression: %s", collectorName, yamlName(field), err),
+				})
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+// diagnosticsFromError returns the diagnostics of a decoding or expansion error.
+// The location is parsed from the error messages.
+func diagnosticsFromError(file string, err error) []Diagnostic {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var diagnostics []Diagnostic
+
+		for _, err := range joined.Unwrap() {
+			diagnostics = append(diagnostics, diagnosticsFromError(file, err)...)
+		}
+
+		return diagnostics
+	}
+
+	messages := []string{err.Error()}
+
+	var typeError *yaml.TypeError
+	if errors.As(err, &typeError) {
+		messages = typeError.Errors
+	}
+
+	diagnostics := make([]Diagnostic, 0, len(messages))
+
+	for _, message := range messages {
+		diagnostic := Diagnostic{File: file, Message: message}
+
+		if matches := errorLocationRe.FindStringSubmatch(strings.TrimSpace(message)); matches != nil {
+			diagnostic.Line, _ = strconv.Atoi(matches[1])
+			diagnostic.Column, _ = strconv.Atoi(matches[2])
+			diagnostic.Message = matches[3]
+		}
+
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	return diagnostics
+}
+
+// lookupNode returns the node of the path of mapping keys, or nil, if the path doesn't exist.
+func lookupNode(node *yaml.Node, path ...string) *yaml.Node {
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		var value *yaml.Node
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				value = node.Content[i+1]
+			}
+		}
+
+		if value == nil {
+			return nil
+		}
+
+		node = value
+	}
+
+	return node
+}
+
+// yamlName returns the name of the field in YAML.
+func yamlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	configFile := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+collectors:
+  enabled: "[defaults],iss"
+  disabled: "[defaults]"
+collector:
+  service:
+    include: "windows_exporter("
+  process:
+    include: w3wp
+log:
+  levl: debug
+`), 0o600))
+
+	diagnostics, err := Check(configFile, "", []string{"iis", "process", "service"})
+	require.NoError(t, err)
+
+	messages := make([]string, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		messages = append(messages, diagnostic.String())
+	}
+
+	require.Equal(t, []string{
+		configFile + `:3:12: collectors.enabled: unknown collector "iss"`,
+		configFile + `:4:13: collectors.disabled: unknown collector "[defaults]"`,
+		configFile + ":7:14: collector.service.include: invalid regular expression: error parsing regexp: missing closing ): `^(?:windows_exporter()$`",
+	}, messages)
+
+	require.NoError(t, os.WriteFile(configFile, []byte("collector:\n  service:\n    include: windows_exporter\nlog:\n  levl: debug\n"), 0o600))
+
+	diagnostics, err = Check(configFile, "", []string{"service"})
+	require.NoError(t, err)
+	require.Len(t, diagnostics, 1)
+	require.Equal(t, Diagnostic{File: configFile, Line: 5, Message: diagnostics[0].Message}, diagnostics[0])
+	require.Contains(t, diagnostics[0].Message, "field levl not found")
+}
@@ -20,11 +20,12 @@ package config
 import (
 	"errors"
 	"fmt"
-	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/relabel"
 	"github.com/prometheus-community/windows_exporter/pkg/collector"
 	"go.yaml.in/yaml/v3"
 )
@@ -36,20 +37,61 @@ type configFile struct {
 		Enabled bool `yaml:"enabled"`
 	} `yaml:"debug"`
 	Collectors struct {
-		Enabled string `yaml:"enabled"`
+		Enabled  string `yaml:"enabled"`
+		Disabled string `yaml:"disabled"`
 	} `yaml:"collectors"`
 	Collector collector.Config `yaml:"collector"`
-	Log       struct {
+	Global    struct {
+		Labels        map[string]string `yaml:"labels"`
+		HostLabels    []string          `yaml:"host-labels"`
+		LabelConflict string            `yaml:"label-conflict"`
+	} `yaml:"global"`
+	Log struct {
 		Level  string `yaml:"level"`
 		Format string `yaml:"format"`
 		File   string `yaml:"file"`
 	} `yaml:"log"`
+	OTLP struct {
+		Endpoint      string `yaml:"endpoint"`
+		Protocol      string `yaml:"protocol"`
+		Interval      string `yaml:"interval"`
+		Timeout       string `yaml:"timeout"`
+		QueueCapacity int    `yaml:"queue-capacity"`
+		BasicAuth     struct {
+			Username     string `yaml:"username"`
+			Password     string `yaml:"password"`
+			PasswordFile string `yaml:"password-file"`
+		} `yaml:"basic-auth"`
+		BearerToken     string `yaml:"bearer-token"`
+		BearerTokenFile string `yaml:"bearer-token-file"`
+	} `yaml:"otlp"`
 	Process struct {
 		Priority    string `yaml:"priority"`
 		MemoryLimit string `yaml:"memory-limit"`
 	} `yaml:"process"`
+	Push struct {
+		Buffer struct {
+			Directory string `yaml:"directory"`
+			MaxSize   string `yaml:"max-size"`
+			MaxAge    string `yaml:"max-age"`
+		} `yaml:"buffer"`
+		RemoteWrite struct {
+			URL           string `yaml:"url"`
+			Interval      string `yaml:"interval"`
+			Timeout       string `yaml:"timeout"`
+			QueueCapacity int    `yaml:"queue-capacity"`
+			BasicAuth     struct {
+				Username     string `yaml:"username"`
+				Password     string `yaml:"password"`
+				PasswordFile string `yaml:"password-file"`
+			} `yaml:"basic-auth"`
+			BearerToken     string `yaml:"bearer-token"`
+			BearerTokenFile string `yaml:"bearer-token-file"`
+		} `yaml:"remote-write"`
+	} `yaml:"push"`
 	Scrape struct {
-		TimeoutMargin string `yaml:"timeout-margin"`
+		TimeoutMargin        string           `yaml:"timeout-margin"`
+		MetricRelabelConfigs []relabel.Config `yaml:"metric-relabel-configs"`
 	} `yaml:"scrape"`
 	Telemetry struct {
 		Path string `yaml:"path"`
@@ -60,9 +102,17 @@ type configFile struct {
 		Config                 struct {
 			File string `yaml:"file"`
 		} `yaml:"config"`
+		UnixSocket struct {
+			SDDL string `yaml:"sddl"`
+		} `yaml:"unix-socket"`
 	} `yaml:"web"`
 }
 
+// mapFlags are the flags holding a mapping. The entries of the mapping are passed as name=value pairs.
+//
+//nolint:gochecknoglobals
+var mapFlags = []string{"global.labels"}
+
 type getFlagger interface {
 	GetFlag(name string) *kingpin.FlagClause
 }
@@ -70,46 +120,86 @@ type getFlagger interface {
 // Resolver represents a configuration file resolver for kingpin.
 type Resolver struct {
 	flags map[string]string
+	// secrets holds the flattened keys of the values expanded from a file.
+	secrets map[string]struct{}
 }
 
-// Parse parses the command line arguments and configuration files.
-func Parse(app *kingpin.Application, args []string) error {
+// Parse parses the command line arguments and configuration files and returns the selected command
+// and the resolved values of the flags. If remote is not nil, its last good configuration is used
+// instead of a configuration file.
+func Parse(app *kingpin.Application, args []string, remote *Remote) (string, *Resolved, error) {
+	var (
+		resolver *Resolver
+		err      error
+	)
+
 	configFile := ParseConfigFile(args)
-	if configFile != "" {
-		resolver, err := NewConfigFileResolver(configFile)
-		if err != nil {
-			return fmt.Errorf("failed to load configuration file: %w", err)
-		}
+	configDir := ParseConfigDir(args)
+
+	switch {
+	case remote != nil && (configFile != "" || configDir != ""):
+		return "", nil, errors.New("--config.url is mutually exclusive with --config.file and --config.dir")
+	case remote != nil:
+		resolver, err = remote.resolver()
+	case configDir != "":
+		resolver, err = NewConfigDirResolver(configFile, configDir)
+	case configFile != "":
+		resolver, err = NewConfigFileResolver(configFile)
+	}
 
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load configuration file: %w", err)
+	}
+
+	if resolver != nil {
 		if err = resolver.Bind(app, args); err != nil {
-			return fmt.Errorf("failed to bind configuration: %w", err)
+			return "", nil, fmt.Errorf("failed to bind configuration: %w", err)
 		}
 	}
 
-	if _, err := app.Parse(args); err != nil {
-		return fmt.Errorf("failed to parse flags: %w", err)
+	command, err := app.Parse(args)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse flags: %w", err)
 	}
 
-	return nil
+	resolved, err := resolveValues(app, args, resolver)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve flags: %w", err)
+	}
+
+	return command, resolved, nil
 }
 
 // ParseConfigFile manually parses the configuration file from the command line arguments.
 func ParseConfigFile(args []string) string {
-	for i, cliFlag := range args {
-		if configFile, ok := strings.CutPrefix(cliFlag, "--config.file="); ok {
-			return configFile
-		}
+	return parseFlagValue(args, "config.file")
+}
+
+// ParseConfigDir manually parses the configuration directory from the command line arguments.
+func ParseConfigDir(args []string) string {
+	return parseFlagValue(args, "config.dir")
+}
+
+// ParseConfigURL manually parses the configuration URL and its cache file from the command line arguments.
+func ParseConfigURL(args []string) (string, string) {
+	return parseFlagValue(args, "config.url"), parseFlagValue(args, "config.url.cache-file")
+}
 
-		if configFile, ok := strings.CutPrefix(cliFlag, "-config.file="); ok {
-			return configFile
+// parseFlagValue returns the value of the flag with the given name from the command line arguments.
+// The value is either separated by "=" or passed as next argument.
+func parseFlagValue(args []string, name string) string {
+	for i, arg := range args {
+		flagName := strings.TrimLeft(arg, "-")
+		if flagName == arg {
+			continue
 		}
 
-		if strings.HasSuffix(cliFlag, "-config.file") {
-			if len(os.Args) <= i+1 {
-				return ""
-			}
+		if value, ok := strings.CutPrefix(flagName, name+"="); ok {
+			return value
+		}
 
-			return os.Args[i+1]
+		if flagName == name && len(args) > i+1 {
+			return args[i+1]
 		}
 	}
 
@@ -118,40 +208,44 @@ func ParseConfigFile(args []string) string {
 
 // NewConfigFileResolver returns a Resolver structure.
 func NewConfigFileResolver(filePath string) (*Resolver, error) {
-	flags := map[string]string{}
-
-	file, err := os.Open(filePath)
+	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open configuration file: %w", err)
 	}
 
-	defer func() {
-		_ = file.Close()
-	}()
+	return newResolver(data, true)
+}
 
-	var configFileStructure configFile
+// newResolver returns a Resolver of the YAML configuration. If expandPlaceholders is true, placeholders are expanded,
+// see [expand]. The configuration is validated against the structure of the configuration file.
+func newResolver(data []byte, expandPlaceholders bool) (*Resolver, error) {
+	flags := map[string]string{}
 
-	decoder := yaml.NewDecoder(file)
-	decoder.KnownFields(true)
+	var root yaml.Node
 
-	if err = decoder.Decode(&configFileStructure); err != nil {
-		// Handle EOF error gracefully, indicating no configuration was found.
-		if errors.Is(err, io.EOF) {
-			return &Resolver{flags: flags}, nil
-		}
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file: %w", err)
+	}
 
-		return nil, fmt.Errorf("configuration file validation error: %w", err)
+	// Handle empty documents gracefully, indicating no configuration was found.
+	if len(root.Content) == 0 {
+		return &Resolver{flags: flags}, nil
 	}
 
-	_, err = file.Seek(0, io.SeekStart)
+	secrets, err := expandNode(&root, expandPlaceholders, false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to rewind file: %w", err)
+		return nil, fmt.Errorf("failed to expand configuration file: %w", err)
+	}
+
+	var configFileStructure configFile
+
+	if err = decodeConfig(root.Content[0], &configFileStructure); err != nil {
+		return nil, fmt.Errorf("configuration file validation error: %w", err)
 	}
 
 	var rawValues map[string]any
 
-	decoder = yaml.NewDecoder(file)
-	if err = decoder.Decode(&rawValues); err != nil {
+	if err = root.Decode(&rawValues); err != nil {
 		return nil, fmt.Errorf("failed to parse configuration file: %w", err)
 	}
 
@@ -163,14 +257,31 @@ func NewConfigFileResolver(filePath string) (*Resolver, error) {
 		}
 	}
 
-	return &Resolver{flags: flags}, nil
+	return &Resolver{flags: flags, secrets: secrets}, nil
 }
 
 func (c *Resolver) setDefault(v getFlagger) {
+	// Mappings like global.labels are flattened to one key per entry.
+	// They are passed as name=value pairs to the map flag.
+	mapValues := map[string][]string{}
+
 	for name, value := range c.flags {
 		if f := v.GetFlag(name); f != nil {
 			f.Default(value)
+
+			continue
 		}
+
+		for _, mapFlag := range mapFlags {
+			if key, ok := strings.CutPrefix(name, mapFlag+"."); ok && v.GetFlag(mapFlag) != nil {
+				mapValues[mapFlag] = append(mapValues[mapFlag], key+"="+value)
+			}
+		}
+	}
+
+	for name, values := range mapValues {
+		slices.Sort(values)
+		v.GetFlag(name).Default(values...)
 	}
 }
 
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverBind(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		flags    map[string]string
+		logLevel string
+		labels   map[string]string
+	}{
+		{
+			name:     "map flag",
+			flags:    map[string]string{"log.level": "debug", "global.labels.site": "fra1", "global.labels.role": "dc"},
+			logLevel: "debug",
+			labels:   map[string]string{"site": "fra1", "role": "dc"},
+		},
+		{
+			name:     "command flag",
+			flags:    map[string]string{"log.level": "debug", "push.url": "http://localhost:9091"},
+			logLevel: "debug",
+			labels:   map[string]string{},
+		},
+		{
+			name:     "key of a flag without mapping is ignored",
+			flags:    map[string]string{"log.level.extra": "debug"},
+			logLevel: "info",
+			labels:   map[string]string{},
+		},
+		{
+			name:     "key without flag is ignored",
+			flags:    map[string]string{"log.unknown": "debug", "push.unknown.key": "value"},
+			logLevel: "info",
+			labels:   map[string]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := kingpin.New("test", "")
+			logLevel := app.Flag("log.level", "").Default("info").String()
+			labels := app.Flag("global.labels", "").StringMap()
+			app.Command("serve", "").Default()
+			app.Command("push", "").Flag("push.url", "").String()
+
+			resolver := &Resolver{flags: tc.flags}
+
+			require.NoError(t, resolver.Bind(app, []string{"push"}))
+
+			_, err := app.Parse([]string{"push"})
+			require.NoError(t, err)
+
+			require.Equal(t, tc.logLevel, *logLevel)
+			require.Equal(t, tc.labels, *labels)
+		})
+	}
+}
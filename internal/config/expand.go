@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+	"go.yaml.in/yaml/v3"
+)
+
+// redactedValue replaces the values of secrets in configuration dumps.
+const redactedValue = "<secret>"
+
+// expandNode expands the placeholders in the scalar values of the node, see [expand], if expandPlaceholders is true.
+// It returns the flattened keys of the values that contain a secret, i.e., the content of a file.
+// If redact is true, secrets and the values of credential keys, see [isSecretFlag], are replaced by <secret>
+// and the credentials of URLs are redacted, see [redactURLs].
+// All errors are returned with the line and column of the value.
+func expandNode(node *yaml.Node, expandPlaceholders, redact bool) (map[string]struct{}, error) {
+	secrets := map[string]struct{}{}
+
+	var errs []error
+
+	var walk func(key string, node *yaml.Node)
+
+	walk = func(key string, node *yaml.Node) {
+		switch node.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, child := range node.Content {
+				walk(key, child)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				childKey := node.Content[i].Value
+				if key != "" {
+					childKey = key + "." + childKey
+				}
+
+				walk(childKey, node.Content[i+1])
+			}
+		case yaml.ScalarNode:
+			value, secret := node.Value, false
+
+			if expandPlaceholders {
+				var err error
+
+				if value, secret, err = expand(node.Value); err != nil {
+					errs = append(errs, fmt.Errorf("line %d, column %d: %w", node.Line, node.Column, err))
+
+					return
+				}
+			}
+
+			if secret {
+				secrets[key] = struct{}{}
+			}
+
+			if redact && value != "" && (secret || isSecretFlag(key)) {
+				node.Value, node.Style, node.Tag = redactedValue, yaml.DoubleQuotedStyle, "!!str"
+
+				return
+			}
+
+			if redact {
+				value = redactURLs(value)
+			}
+
+			if value == node.Value {
+				return
+			}
+
+			node.Value = value
+
+			// The tag of plain values is resolved again, e.g., ${QUEUE_CAPACITY} may expand to an integer.
+			if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				node.Tag = ""
+			}
+		case yaml.AliasNode:
+		}
+	}
+
+	walk("", node)
+
+	return secrets, errors.Join(errs...)
+}
+
+// envNameRegexp matches the names of environment variables of placeholders.
+var envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// expand replaces ${NAME} with the value of the environment variable NAME and ${file:path} with the content
+// of the file, without trailing line breaks. $${ is replaced with a literal ${. Undefined environment variables
+// and unreadable files are an error. Other ${...}, e.g. the ${1} of a relabel replacement, are kept unchanged.
+// It reports whether a file was expanded.
+func expand(s string) (string, bool, error) {
+	if !strings.Contains(s, "${") {
+		return s, false, nil
+	}
+
+	var (
+		result strings.Builder
+		secret bool
+	)
+
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			result.WriteString(s)
+
+			return result.String(), secret, nil
+		}
+
+		// Escaped placeholder.
+		if i > 0 && s[i-1] == '$' {
+			result.WriteString(s[:i-1] + "${")
+			s = s[i+2:]
+
+			continue
+		}
+
+		result.WriteString(s[:i])
+
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			result.WriteString(s[i:])
+
+			return result.String(), secret, nil
+		}
+
+		name := s[i+2 : i+end]
+		s = s[i+end+1:]
+
+		if path, ok := strings.CutPrefix(name, "file:"); ok {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", false, fmt.Errorf("failed to read file of placeholder ${%s}: %w", name, err)
+			}
+
+			result.WriteString(strings.TrimRight(string(data), "\r\n"))
+
+			secret = true
+
+			continue
+		}
+
+		if !envNameRegexp.MatchString(name) {
+			result.WriteString("${" + name + "}")
+
+			continue
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", false, fmt.Errorf("environment variable %s of placeholder ${%s} is not defined", name, name)
+		}
+
+		result.WriteString(value)
+	}
+}
+
+// decodeConfig decodes the expanded configuration into the structure of the configuration file.
+// Unknown fields are an error, like with [yaml.Decoder.KnownFields].
+func decodeConfig(document *yaml.Node, out *configFile) error {
+	unknown := unknownFields(document, reflect.TypeFor[configFile]())
+
+	err := document.Decode(out)
+
+	var typeError *yaml.TypeError
+
+	switch {
+	case err == nil && len(unknown) == 0:
+		return nil
+	case err == nil:
+		return &yaml.TypeError{Errors: unknown}
+	case errors.As(err, &typeError):
+		return &yaml.TypeError{Errors: append(unknown, typeError.Errors...)}
+	default:
+		return err
+	}
+}
+
+// unknownFields returns an error message for each key of the node, that is not a field of t.
+// Types implementing [yaml.Unmarshaler] check their fields themselves.
+func unknownFields(node *yaml.Node, t reflect.Type) []string {
+	for node.Kind == yaml.DocumentNode || node.Kind == yaml.AliasNode {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		} else if len(node.Content) > 0 {
+			node = node.Content[0]
+		} else {
+			return nil
+		}
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// The sections of the collectors accept the settings of every collector besides their own fields.
+	if t == reflect.TypeFor[collector.Config]() {
+		return collector.CheckConfigFields(node, unknownFields)
+	}
+
+	pt := reflect.PointerTo(t)
+	if pt.Implements(reflect.TypeFor[yaml.Unmarshaler]()) || pt.Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) {
+		return nil
+	}
+
+	var messages []string
+
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+
+			field, ok := structField(t, keyNode.Value)
+			if !ok {
+				messages = append(messages, fmt.Sprintf("line %d: field %s not found in type %s", keyNode.Line, keyNode.Value, t))
+
+				continue
+			}
+
+			messages = append(messages, unknownFields(node.Content[i+1], field.Type)...)
+		}
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && node.Kind == yaml.SequenceNode:
+		for _, child := range node.Content {
+			messages = append(messages, unknownFields(child, t.Elem())...)
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			messages = append(messages, unknownFields(node.Content[i+1], t.Elem())...)
+		}
+	}
+
+	return messages
+}
+
+// structField returns the field of the struct with the YAML name.
+func structField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		if field := t.Field(i); field.IsExported() && yamlName(field) == name {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// RedactedConfig returns the configuration with the secrets replaced by <secret>, e.g., to print the merged configuration.
+// If expandPlaceholders is true, the placeholders are expanded. Secrets are the values read from files and the values
+// of credential keys, e.g., push.remote-write.basic-auth.password.
+func RedactedConfig(data []byte, expandPlaceholders bool) ([]byte, error) {
+	var root yaml.Node
+
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	if _, err := expandNode(&root, expandPlaceholders, true); err != nil {
+		return nil, err
+	}
+
+	data, err := yaml.Marshal(&root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+
+	return data, nil
+}
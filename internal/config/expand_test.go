@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest
+func TestExpand(t *testing.T) {
+	t.Setenv("WINDOWS_EXPORTER_TEST_DATACENTER", "fra1")
+	t.Setenv("WINDOWS_EXPORTER_TEST_QUEUE_CAPACITY", "500")
+	t.Setenv("WINDOWS_EXPORTER_TEST_BEARER_TOKEN", "t0k3n")
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("s3cr3t: #1\r\n"), 0o600))
+
+	config := []byte(`
+global:
+  labels:
+    datacenter: ${WINDOWS_EXPORTER_TEST_DATACENTER}
+    site: "dc-${WINDOWS_EXPORTER_TEST_DATACENTER}"
+    template: $${HOSTNAME}
+push:
+  remote-write:
+    queue-capacity: ${WINDOWS_EXPORTER_TEST_QUEUE_CAPACITY}
+    basic-auth:
+      password: ${file:` + passwordFile + `}
+    bearer-token: ${WINDOWS_EXPORTER_TEST_BEARER_TOKEN}
+otlp:
+  basic-auth:
+    username: windows
+    password: pl41n
+`)
+
+	resolver, err := newResolver(config, true)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"global.labels.datacenter":              "fra1",
+		"global.labels.site":                    "dc-fra1",
+		"global.labels.template":                "${HOSTNAME}",
+		"push.remote-write.queue-capacity":      "500",
+		"push.remote-write.basic-auth.password": "s3cr3t: #1",
+		"push.remote-write.bearer-token":        "t0k3n",
+		"otlp.basic-auth.username":              "windows",
+		"otlp.basic-auth.password":              "pl41n",
+	}, resolver.flags)
+
+	redacted, err := RedactedConfig(config, true)
+	require.NoError(t, err)
+	require.Contains(t, string(redacted), `password: "<secret>"`)
+	require.Contains(t, string(redacted), "datacenter: fra1")
+	require.Contains(t, string(redacted), `bearer-token: "<secret>"`)
+	require.Contains(t, string(redacted), "username: windows")
+	require.NotContains(t, string(redacted), "s3cr3t")
+	require.NotContains(t, string(redacted), "t0k3n")
+	require.NotContains(t, string(redacted), "pl41n")
+
+	// Without expansion, the placeholders are kept, but the credentials are redacted.
+	redacted, err = RedactedConfig(config, false)
+	require.NoError(t, err)
+	require.Contains(t, string(redacted), "datacenter: ${WINDOWS_EXPORTER_TEST_DATACENTER}")
+	require.Contains(t, string(redacted), `bearer-token: "<secret>"`)
+	require.NotContains(t, string(redacted), "pl41n")
+
+	_, err = newResolver([]byte("global:\n  labels:\n    datacenter: ${WINDOWS_EXPORTER_TEST_UNDEFINED}\n"), true)
+	require.EqualError(t, err, "failed to expand configuration file: line 3, column 17: environment variable WINDOWS_EXPORTER_TEST_UNDEFINED of placeholder ${WINDOWS_EXPORTER_TEST_UNDEFINED} is not defined")
+}
+
+func TestExpandKeepsRelabelReplacements(t *testing.T) {
+	t.Parallel()
+
+	resolver, err := newResolver([]byte(`
+scrape:
+  metric-relabel-configs:
+    - source_labels: [volume]
+      regex: "(.+):"
+      target_label: drive
+      replacement: ${1}
+      action: replace
+`), true)
+	require.NoError(t, err)
+	require.Contains(t, resolver.flags["scrape.metric-relabel-configs"], "replacement: ${1}")
+
+	for value, expected := range map[string]string{
+		"${1}-${2}":      "${1}-${2}",
+		"${}":            "${}",
+		"${not-an-env}":  "${not-an-env}",
+		"prefix ${open":  "prefix ${open",
+		"$${HOSTNAME}":   "${HOSTNAME}",
+		"no placeholder": "no placeholder",
+	} {
+		expanded, secret, err := expand(value)
+		require.NoError(t, err)
+		require.False(t, secret)
+		require.Equal(t, expected, expanded, value)
+	}
+}
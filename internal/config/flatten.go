@@ -20,6 +20,8 @@ package config
 import (
 	"fmt"
 	"strings"
+
+	"go.yaml.in/yaml/v3"
 )
 
 // convertMap converts a map with any comparable key type to a map with string keys.
@@ -57,6 +59,15 @@ func flattenHelper(prefix string, data map[string]any, result map[string]string)
 		case map[string]any:
 			flattenHelper(fullKey, val, result)
 		case []any:
+			// Lists of mappings, e.g. relabel configs, are passed as YAML.
+			if containsMap(val) {
+				if out, err := yaml.Marshal(val); err == nil {
+					result[fullKey] = string(out)
+
+					continue
+				}
+			}
+
 			strSlice := make([]string, len(val))
 			for i, elem := range val {
 				strSlice[i] = fmt.Sprint(elem)
@@ -68,3 +79,14 @@ func flattenHelper(prefix string, data map[string]any, result map[string]string)
 		}
 	}
 }
+
+func containsMap(values []any) bool {
+	for _, value := range values {
+		switch value.(type) {
+		case map[any]any, map[string]any:
+			return true
+		}
+	}
+
+	return false
+}
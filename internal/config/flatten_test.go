@@ -53,3 +53,31 @@ func TestConfigFlattening(t *testing.T) {
 		t.Errorf("Flattened values do not match!\nExpected result: %s\nActual result: %s", expectedResult, flattenedValues)
 	}
 }
+
+// Lists of mappings are flattened to YAML.
+func TestConfigFlatteningListOfMappings(t *testing.T) {
+	t.Parallel()
+
+	goodYamlConfig := []byte(`---
+
+    scrape:
+      metric-relabel-configs:
+        - regex: core
+          action: labeldrop`)
+
+	var data map[string]any
+
+	err := yaml.Unmarshal(goodYamlConfig, &data)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expectedResult := map[string]string{
+		"scrape.metric-relabel-configs": "- action: labeldrop\n  regex: core\n",
+	}
+	flattenedValues := flatten(data)
+
+	if !reflect.DeepEqual(expectedResult, flattenedValues) {
+		t.Errorf("Flattened values do not match!\nExpected result: %s\nActual result: %s", expectedResult, flattenedValues)
+	}
+}
@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// commaListKeys are the keys of comma-separated lists, that are merged like YAML lists.
+//
+//nolint:gochecknoglobals
+var commaListKeys = []string{"collectors.enabled", "collectors.disabled"}
+
+// NewConfigDirResolver returns a Resolver of the configuration file merged with the files of the configuration directory.
+func NewConfigDirResolver(configFile, configDir string) (*Resolver, error) {
+	data, err := MergeConfigFiles(configFile, configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return newResolver(data, true)
+}
+
+// MergeConfigFiles merges the configuration file and the *.yml and *.yaml files of the configuration directory
+// into one YAML document. The files of the directory are merged in lexical order on top of the configuration file.
+// Mappings are merged recursively and lists are concatenated without duplicates. A value that is set
+// to different scalar values by multiple files is a conflict and returns an error. Both paths are optional.
+func MergeConfigFiles(configFile, configDir string) ([]byte, error) {
+	files, err := configFiles(configFile, configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &merger{
+		merged:  map[string]any{},
+		sources: map[string]string{},
+	}
+
+	for _, file := range files {
+		data, err := readConfigFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		// Each file is validated on its own, so errors refer to the lines of the file.
+		if _, err = newResolver(data, true); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		var values map[string]any
+
+		if err = yaml.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: failed to parse configuration file: %w", file, err)
+		}
+
+		if err = m.merge("", m.merged, values, file); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(m.merged) == 0 {
+		return nil, nil
+	}
+
+	data, err := yaml.Marshal(m.merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merged configuration: %w", err)
+	}
+
+	return data, nil
+}
+
+// configFiles returns the configuration file followed by the *.yml and *.yaml files of the configuration directory
+// in lexical order.
+func configFiles(configFile, configDir string) ([]string, error) {
+	var files []string
+
+	if configFile != "" {
+		files = append(files, configFile)
+	}
+
+	if configDir != "" {
+		entries, err := os.ReadDir(configDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration directory: %w", err)
+		}
+
+		// The entries are sorted by file name.
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
+			if ext := strings.ToLower(filepath.Ext(entry.Name())); ext == ".yml" || ext == ".yaml" {
+				files = append(files, filepath.Join(configDir, entry.Name()))
+			}
+		}
+	}
+
+	return files, nil
+}
+
+func readConfigFile(file string) ([]byte, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open configuration file: %w", err)
+	}
+
+	return data, nil
+}
+
+// merger merges configuration files and remembers the file that set a value.
+type merger struct {
+	merged  map[string]any
+	sources map[string]string
+}
+
+func (m *merger) merge(prefix string, dst, src map[string]any, source string) error {
+	for _, name := range slices.Sorted(maps.Keys(src)) {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		srcValue := src[name]
+
+		dstValue, ok := dst[name]
+		if !ok || dstValue == nil {
+			dst[name] = srcValue
+			m.sources[key] = source
+
+			continue
+		}
+
+		if srcValue == nil {
+			continue
+		}
+
+		switch srcValue := srcValue.(type) {
+		case map[string]any:
+			dstMap, ok := dstValue.(map[string]any)
+			if !ok {
+				return m.conflict(key, dstValue, srcValue, source)
+			}
+
+			if err := m.merge(key, dstMap, srcValue, source); err != nil {
+				return err
+			}
+		case []any:
+			dstList, ok := dstValue.([]any)
+			if !ok {
+				return m.conflict(key, dstValue, srcValue, source)
+			}
+
+			dst[name] = appendUnique(dstList, srcValue...)
+		default:
+			dstString, dstOk := dstValue.(string)
+			srcString, srcOk := srcValue.(string)
+
+			if dstOk && srcOk && slices.Contains(commaListKeys, key) {
+				dst[name] = mergeCommaLists(dstString, srcString)
+
+				continue
+			}
+
+			if !reflect.DeepEqual(dstValue, srcValue) {
+				return m.conflict(key, dstValue, srcValue, source)
+			}
+		}
+	}
+
+	return nil
+}
+
+// conflict returns the error of a value that is set by multiple files.
+func (m *merger) conflict(key string, dstValue, srcValue any, source string) error {
+	// The source is recorded for the key that was set first, which may be a parent of key.
+	dstSource := ""
+
+	for k := key; dstSource == ""; {
+		dstSource = m.sources[k]
+
+		i := strings.LastIndex(k, ".")
+		if i < 0 {
+			break
+		}
+
+		k = k[:i]
+	}
+
+	return fmt.Errorf("conflicting values for %s: %v in %s and %v in %s", key, dstValue, dstSource, srcValue, source)
+}
+
+func appendUnique(values []any, elems ...any) []any {
+	for _, elem := range elems {
+		if !slices.ContainsFunc(values, func(value any) bool { return reflect.DeepEqual(value, elem) }) {
+			values = append(values, elem)
+		}
+	}
+
+	return values
+}
+
+func mergeCommaLists(a, b string) string {
+	values := strings.Split(a, ",")
+
+	for _, value := range strings.Split(b, ",") {
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeConfigFiles(t *testing.T) {
+	t.Parallel()
+
+	writeFile := func(t *testing.T, path, data string) {
+		t.Helper()
+
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	}
+
+	baseFile := filepath.Join(t.TempDir(), "config.yml")
+	writeFile(t, baseFile, `
+collectors:
+  enabled: "[defaults]"
+collector:
+  textfile:
+    directories: ['C:\textfile']
+log:
+  level: info
+`)
+
+	t.Run("merge", func(t *testing.T) {
+		t.Parallel()
+
+		configDir := t.TempDir()
+		writeFile(t, filepath.Join(configDir, "20-mssql.yaml"), `
+collectors:
+  enabled: mssql
+collector:
+  textfile:
+    directories: ['C:\textfile', 'C:\mssql']
+log:
+  level: info
+`)
+		writeFile(t, filepath.Join(configDir, "10-iis.yml"), `
+collectors:
+  enabled: iis,process
+collector:
+  process:
+    include: w3wp
+`)
+		writeFile(t, filepath.Join(configDir, "README.txt"), "not a configuration file")
+
+		data, err := MergeConfigFiles(baseFile, configDir)
+		require.NoError(t, err)
+		require.YAMLEq(t, `
+collectors:
+  enabled: "[defaults],iis,process,mssql"
+collector:
+  process:
+    include: w3wp
+  textfile:
+    directories: ['C:\textfile', 'C:\mssql']
+log:
+  level: info
+`, string(data))
+
+		resolver, err := NewConfigDirResolver(baseFile, configDir)
+		require.NoError(t, err)
+		require.Equal(t, "[defaults],iis,process,mssql", resolver.flags["collectors.enabled"])
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		t.Parallel()
+
+		configDir := t.TempDir()
+		conflictFile := filepath.Join(configDir, "10-debug.yml")
+		writeFile(t, conflictFile, "log:\n  level: debug\n")
+
+		_, err := MergeConfigFiles(baseFile, configDir)
+		require.EqualError(t, err, "conflicting values for log.level: info in "+baseFile+" and debug in "+conflictFile)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		t.Parallel()
+
+		configDir := t.TempDir()
+		invalidFile := filepath.Join(configDir, "10-invalid.yml")
+		writeFile(t, invalidFile, "log:\n  levels: debug\n")
+
+		_, err := MergeConfigFiles(baseFile, configDir)
+		require.ErrorContains(t, err, invalidFile+": configuration file validation error: yaml: unmarshal errors:\n  line 2: field levels not found")
+	})
+}
@@ -0,0 +1,313 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/version"
+)
+
+const (
+	// remoteFetchTimeout is the timeout of a request to the configuration URL.
+	remoteFetchTimeout = 30 * time.Second
+	// maxRemoteConfigSize is the maximum size of the configuration returned by the configuration URL.
+	maxRemoteConfigSize = 10 << 20
+)
+
+// Interface guard.
+var _ prometheus.Collector = (*Remote)(nil)
+
+// Remote is a configuration source that fetches the YAML configuration from a URL.
+// The last good configuration is cached on disk and used, if the URL can't be fetched.
+type Remote struct {
+	url       string
+	cacheFile string
+	client    *http.Client
+
+	mu sync.Mutex
+	// data is the last good configuration.
+	data         []byte
+	etag         string
+	lastModified string
+	// fromCache is true, if data was read from the cache file and not fetched yet.
+	fromCache bool
+
+	lastFetchSuccess     bool
+	lastFetchSuccessTime time.Time
+	fetchFailures        float64
+
+	lastFetchSuccessfulDesc       *prometheus.Desc
+	lastFetchSuccessTimestampDesc *prometheus.Desc
+	fetchFailuresDesc             *prometheus.Desc
+	usingCacheDesc                *prometheus.Desc
+}
+
+// NewRemote returns a Remote for the configuration URL. If the cache file exists and is valid,
+// it is used until the configuration is fetched. An empty cache file defaults to
+// config_url_cache.yml next to the executable.
+func NewRemote(url, cacheFile string) (*Remote, error) {
+	if cacheFile == "" {
+		execPath, err := os.Executable()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get path of executable: %w", err)
+		}
+
+		cacheFile = filepath.Join(filepath.Dir(execPath), "config_url_cache.yml")
+	}
+
+	r := &Remote{
+		url:       url,
+		cacheFile: cacheFile,
+		client:    &http.Client{Timeout: remoteFetchTimeout},
+		lastFetchSuccessfulDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "config_url_last_fetch_successful"),
+			"windows_exporter: Whether the last fetch of the configuration URL was successful.",
+			nil,
+			nil,
+		),
+		lastFetchSuccessTimestampDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "config_url_last_fetch_success_timestamp_seconds"),
+			"windows_exporter: Timestamp of the last successful fetch of the configuration URL.",
+			nil,
+			nil,
+		),
+		fetchFailuresDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "config_url_fetch_failures_total"),
+			"windows_exporter: Total number of failed fetches of the configuration URL, including invalid configurations.",
+			nil,
+			nil,
+		),
+		usingCacheDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "config_url_using_cache"),
+			"windows_exporter: Whether the configuration was read from the cache file, because the configuration URL couldn't be fetched since the start.",
+			nil,
+			nil,
+		),
+	}
+
+	data, err := os.ReadFile(cacheFile)
+	if err == nil {
+		if _, err = newResolver(data, false); err == nil {
+			r.data = data
+			r.fromCache = true
+		}
+	}
+
+	return r, nil
+}
+
+// Fetch fetches the configuration and reports whether it changed. The request is conditional
+// on the ETag and the Last-Modified header of the last response.
+// Invalid configurations are rejected and the last good configuration is kept.
+// A changed configuration is written to the cache file. It is used even if the cache file can't be written,
+// the error is returned nonetheless.
+func (r *Remote) Fetch(ctx context.Context) (bool, error) {
+	changed, err := r.fetch(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastFetchSuccess = err == nil || changed
+
+	if r.lastFetchSuccess {
+		r.lastFetchSuccessTime = time.Now()
+		r.fromCache = false
+	} else {
+		r.fetchFailures++
+	}
+
+	if err != nil {
+		return changed, fmt.Errorf("failed to fetch configuration from %s: %w", r.url, err)
+	}
+
+	return changed, nil
+}
+
+func (r *Remote) fetch(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	r.mu.Lock()
+	if r.etag != "" {
+		req.Header.Set("If-None-Match", r.etag)
+	}
+
+	if r.lastModified != "" {
+		req.Header.Set("If-Modified-Since", r.lastModified)
+	}
+	r.mu.Unlock()
+
+	req.Header.Set("Accept", "application/yaml, text/yaml, */*")
+	req.Header.Set("User-Agent", "windows_exporter/"+version.Version)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return false, nil
+	case http.StatusOK:
+	default:
+		return false, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteConfigSize+1))
+	if err != nil {
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if len(data) > maxRemoteConfigSize {
+		return false, fmt.Errorf("configuration exceeds %d bytes", maxRemoteConfigSize)
+	}
+
+	if _, err = newResolver(data, false); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.etag = resp.Header.Get("ETag")
+	r.lastModified = resp.Header.Get("Last-Modified")
+
+	if bytes.Equal(data, r.data) {
+		return false, nil
+	}
+
+	r.data = data
+
+	if err = writeCacheFile(r.cacheFile, data); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// writeCacheFile writes the configuration to a temporary file first, so that the cache file is never truncated.
+func writeCacheFile(path string, data []byte) error {
+	tmpFile := path + ".tmp"
+
+	if err := os.WriteFile(tmpFile, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, path); err != nil {
+		return errors.Join(fmt.Errorf("failed to write cache file: %w", err), os.Remove(tmpFile))
+	}
+
+	return nil
+}
+
+// resolver returns a Resolver of the last good configuration.
+func (r *Remote) resolver() (*Resolver, error) {
+	r.mu.Lock()
+	data := r.data
+	r.mu.Unlock()
+
+	if data == nil {
+		return nil, errors.New("configuration URL was not fetched successfully yet and no cached configuration exists")
+	}
+
+	return newResolver(data, false)
+}
+
+// Data returns the last good configuration.
+func (r *Remote) Data() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.data
+}
+
+// UsingCache reports whether the configuration was read from the cache file and not fetched yet.
+func (r *Remote) UsingCache() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.fromCache
+}
+
+func (r *Remote) Describe(ch chan<- *prometheus.Desc) {
+	ch <- r.lastFetchSuccessfulDesc
+	ch <- r.lastFetchSuccessTimestampDesc
+	ch <- r.fetchFailuresDesc
+	ch <- r.usingCacheDesc
+}
+
+func (r *Remote) Collect(ch chan<- prometheus.Metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var successValue, usingCacheValue float64
+	if r.lastFetchSuccess {
+		successValue = 1
+	}
+
+	if r.fromCache {
+		usingCacheValue = 1
+	}
+
+	var lastSuccessTimestamp float64
+	if !r.lastFetchSuccessTime.IsZero() {
+		lastSuccessTimestamp = float64(r.lastFetchSuccessTime.Unix())
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		r.lastFetchSuccessfulDesc,
+		prometheus.GaugeValue,
+		successValue,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		r.lastFetchSuccessTimestampDesc,
+		prometheus.GaugeValue,
+		lastSuccessTimestamp,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		r.fetchFailuresDesc,
+		prometheus.CounterValue,
+		r.fetchFailures,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		r.usingCacheDesc,
+		prometheus.GaugeValue,
+		usingCacheValue,
+	)
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemote(t *testing.T) {
+	t.Parallel()
+
+	var (
+		body      atomic.Value
+		available atomic.Bool
+	)
+
+	body.Store(`{"collectors":{"enabled":"os"}}`)
+	available.Store(true)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		etag := `"` + body.Load().(string) + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(body.Load().(string)))
+	}))
+	t.Cleanup(server.Close)
+
+	cacheFile := filepath.Join(t.TempDir(), "cache.yml")
+
+	remote, err := NewRemote(server.URL, cacheFile)
+	require.NoError(t, err)
+	require.False(t, remote.UsingCache())
+
+	changed, err := remote.Fetch(t.Context())
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.FileExists(t, cacheFile)
+
+	// The second fetch is answered with 304 Not Modified.
+	changed, err = remote.Fetch(t.Context())
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// Unknown fields are rejected and the last good configuration is kept.
+	body.Store(`{"collectors":{"enabled":"os"},"unknown":true}`)
+
+	changed, err = remote.Fetch(t.Context())
+	require.ErrorContains(t, err, "field unknown not found")
+	require.False(t, changed)
+
+	resolver, err := remote.resolver()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"collectors.enabled": "os"}, resolver.flags)
+
+	// The cached configuration is used, if the server is unreachable on start.
+	available.Store(false)
+
+	remote, err = NewRemote(server.URL, cacheFile)
+	require.NoError(t, err)
+	require.True(t, remote.UsingCache())
+
+	_, err = remote.Fetch(t.Context())
+	require.ErrorContains(t, err, "unexpected status code 503")
+
+	resolver, err = remote.resolver()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"collectors.enabled": "os"}, resolver.flags)
+
+	err = testutil.CollectAndCompare(remote, strings.NewReader(`# HELP windows_exporter_config_url_fetch_failures_total windows_exporter: Total number of failed fetches of the configuration URL, including invalid configurations.
+# TYPE windows_exporter_config_url_fetch_failures_total counter
+windows_exporter_config_url_fetch_failures_total 1
+# HELP windows_exporter_config_url_last_fetch_successful windows_exporter: Whether the last fetch of the configuration URL was successful.
+# TYPE windows_exporter_config_url_last_fetch_successful gauge
+windows_exporter_config_url_last_fetch_successful 0
+# HELP windows_exporter_config_url_using_cache windows_exporter: Whether the configuration was read from the cache file, because the configuration URL couldn't be fetched since the start.
+# TYPE windows_exporter_config_url_using_cache gauge
+windows_exporter_config_url_using_cache 1
+`), "windows_exporter_config_url_fetch_failures_total", "windows_exporter_config_url_last_fetch_successful", "windows_exporter_config_url_using_cache")
+	require.NoError(t, err)
+
+	// A new configuration replaces the cache file.
+	available.Store(true)
+	body.Store(`{"collectors":{"enabled":"os,memory"}}`)
+
+	changed, err = remote.Fetch(t.Context())
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.False(t, remote.UsingCache())
+
+	cached, err := os.ReadFile(cacheFile)
+	require.NoError(t, err)
+	require.Equal(t, `{"collectors":{"enabled":"os,memory"}}`, string(cached))
+
+	// Placeholders of a remote configuration are not expanded, so the server can't read local files.
+	body.Store(`{"collectors":{"enabled":"os"},"global":{"labels":{"secret":"${file:` + filepath.ToSlash(cacheFile) + `}"}}}`)
+
+	_, err = remote.Fetch(t.Context())
+	require.NoError(t, err)
+
+	resolver, err = remote.resolver()
+	require.NoError(t, err)
+	require.Equal(t, "${file:"+filepath.ToSlash(cacheFile)+"}", resolver.flags["global.labels.secret"])
+}
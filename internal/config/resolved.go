@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+// Source is the source of a configuration value.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceFlag    Source = "flag"
+)
+
+// secretFlagSuffixes are the suffixes of flags holding credentials. Their values are redacted.
+//
+//nolint:gochecknoglobals
+var secretFlagSuffixes = []string{".password", ".bearer-token"}
+
+// urlRegexp matches the URLs in values, e.g. push.remote-write.url or the YAML list of collector.proxy.targets.
+var urlRegexp = regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^\s"'<>,\]}]+`)
+
+// Value is the resolved value of a flag.
+type Value struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+}
+
+// Resolved holds the resolved values of all flags after parsing, see [Parse].
+type Resolved struct {
+	values []Value
+}
+
+// ResolvedConfig is the resolved configuration of the exporter.
+type ResolvedConfig struct {
+	// Flags are the values of the flags that don't belong to a collector.
+	Flags []Value `json:"flags"`
+	// Collectors are the values of the flags of the enabled collectors by collector name.
+	Collectors map[string][]Value `json:"collectors"`
+}
+
+// resolveValues returns the values of the flags of the application and the selected command after parsing.
+// The source of a value is the command line, the configuration or the default. Secrets are redacted.
+func resolveValues(app *kingpin.Application, args []string, resolver *Resolver) (*Resolved, error) {
+	pc, err := app.ParseContext(args)
+	if err != nil {
+		return nil, err
+	}
+
+	setFlags := map[string]struct{}{}
+
+	for _, element := range pc.Elements {
+		if flag, ok := element.Clause.(*kingpin.FlagClause); ok {
+			setFlags[flag.Model().Name] = struct{}{}
+		}
+	}
+
+	flags := app.Model().Flags
+	if pc.SelectedCommand != nil {
+		flags = append(slices.Clone(flags), pc.SelectedCommand.Model().Flags...)
+	}
+
+	if resolver == nil {
+		resolver = &Resolver{}
+	}
+
+	values := make([]Value, 0, len(flags))
+
+	for _, flag := range flags {
+		if flag.Hidden || flag.Name == "help" || flag.Name == "version" {
+			continue
+		}
+
+		value := Value{Name: flag.Name, Value: flag.String(), Source: SourceDefault}
+
+		if _, ok := setFlags[flag.Name]; ok {
+			value.Source = SourceFlag
+		} else if hasKey(resolver.flags, flag.Name) {
+			value.Source = SourceFile
+		}
+
+		if value.Value != "" && (isSecretFlag(flag.Name) || hasKey(resolver.secrets, flag.Name)) {
+			value.Value = redactedValue
+		} else {
+			value.Value = redactURLs(value.Value)
+		}
+
+		values = append(values, value)
+	}
+
+	slices.SortFunc(values, func(a, b Value) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return &Resolved{values: values}, nil
+}
+
+// Config returns the resolved configuration. Flags of collectors that are not enabled are omitted.
+func (r *Resolved) Config(enabledCollectors []string) ResolvedConfig {
+	config := ResolvedConfig{
+		Flags:      []Value{},
+		Collectors: make(map[string][]Value, len(enabledCollectors)),
+	}
+
+	for _, name := range enabledCollectors {
+		config.Collectors[name] = []Value{}
+	}
+
+	for _, value := range r.values {
+		option, ok := strings.CutPrefix(value.Name, "collector.")
+		if !ok {
+			config.Flags = append(config.Flags, value)
+
+			continue
+		}
+
+		collectorName, _, _ := strings.Cut(option, ".")
+		if _, ok := config.Collectors[collectorName]; ok {
+			config.Collectors[collectorName] = append(config.Collectors[collectorName], value)
+		}
+	}
+
+	return config
+}
+
+// hasKey reports whether the map holds the flag, or a key of a mapping flag, e.g., global.labels.role.
+func hasKey[V any](m map[string]V, name string) bool {
+	if _, ok := m[name]; ok {
+		return true
+	}
+
+	for key := range m {
+		if strings.HasPrefix(key, name+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSecretFlag(name string) bool {
+	return slices.ContainsFunc(secretFlagSuffixes, func(suffix string) bool {
+		return strings.HasSuffix(name, suffix)
+	})
+}
+
+// redactURLs redacts the passwords and the query parameter values of the URLs in s as xxxxx,
+// like [url.URL.Redacted]. Query parameters often hold tokens, e.g. the signature of a pre-signed URL.
+func redactURLs(s string) string {
+	return urlRegexp.ReplaceAllStringFunc(s, func(match string) string {
+		u, err := url.Parse(match)
+		if err != nil {
+			return match
+		}
+
+		if _, ok := u.User.Password(); !ok && u.RawQuery == "" {
+			return match
+		}
+
+		params := strings.Split(u.RawQuery, "&")
+		for i, param := range params {
+			if key, _, ok := strings.Cut(param, "="); ok {
+				params[i] = key + "=xxxxx"
+			}
+		}
+
+		u.RawQuery = strings.Join(params, "&")
+
+		return u.Redacted()
+	})
+}
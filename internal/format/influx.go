@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package format
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+//nolint:gochecknoglobals
+var (
+	influxMeasurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `, "\n", `\n`)
+	influxKeyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`)
+)
+
+// WriteInflux writes the metric families in the InfluxDB line protocol, like the prometheus parser of Telegraf
+// with metric_version 1. The measurement is the metric name and the labels are tags. Counters, gauges and
+// untyped metrics have a single field named counter, gauge or value. Summaries and histograms have the fields
+// count, sum and one field per quantile or bucket. NaN and infinite values are skipped, because the line protocol
+// doesn't support them. Metrics without timestamp get the given timestamp.
+func WriteInflux(w io.Writer, metricFamilies []*dto.MetricFamily, timestamp time.Time) error {
+	bw := bufio.NewWriter(w)
+
+	for _, mf := range metricFamilies {
+		measurement := influxMeasurementEscaper.Replace(mf.GetName())
+
+		for _, m := range mf.GetMetric() {
+			var fields []string
+
+			addField := func(key string, value float64) {
+				if math.IsNaN(value) || math.IsInf(value, 0) {
+					return
+				}
+
+				fields = append(fields, influxKeyEscaper.Replace(key)+"="+strconv.FormatFloat(value, 'g', -1, 64))
+			}
+
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				addField("counter", m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				addField("gauge", m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				addField("value", m.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				addField("count", float64(m.GetSummary().GetSampleCount()))
+				addField("sum", m.GetSummary().GetSampleSum())
+
+				for _, q := range m.GetSummary().GetQuantile() {
+					addField(FormatFloat(q.GetQuantile()), q.GetValue())
+				}
+			case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				addField("count", float64(m.GetHistogram().GetSampleCount()))
+				addField("sum", m.GetHistogram().GetSampleSum())
+
+				for _, b := range m.GetHistogram().GetBucket() {
+					addField(FormatFloat(b.GetUpperBound()), float64(b.GetCumulativeCount()))
+				}
+			}
+
+			if len(fields) == 0 {
+				continue
+			}
+
+			labels := slices.Clone(m.GetLabel())
+			slices.SortFunc(labels, func(a, b *dto.LabelPair) int {
+				return strings.Compare(a.GetName(), b.GetName())
+			})
+
+			_, _ = bw.WriteString(measurement)
+
+			for _, lp := range labels {
+				// The line protocol doesn't support empty tag values.
+				if lp.GetValue() == "" {
+					continue
+				}
+
+				_, _ = bw.WriteString("," + influxKeyEscaper.Replace(lp.GetName()) + "=" + influxKeyEscaper.Replace(lp.GetValue()))
+			}
+
+			timestampNs := timestamp.UnixNano()
+			if m.TimestampMs != nil {
+				timestampNs = time.UnixMilli(m.GetTimestampMs()).UnixNano()
+			}
+
+			_, _ = bw.WriteString(" " + strings.Join(fields, ",") + " " + strconv.FormatInt(timestampNs, 10) + "\n")
+		}
+	}
+
+	return bw.Flush()
+}
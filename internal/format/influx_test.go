@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package format_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/format"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWriteInflux(t *testing.T) {
+	t.Parallel()
+
+	metricFamilies := []*dto.MetricFamily{
+		{
+			Name: proto.String("windows_cpu_time_total"),
+			Type: dto.MetricType_COUNTER.Enum(),
+			Metric: []*dto.Metric{{
+				Label: []*dto.LabelPair{
+					{Name: proto.String("mode"), Value: proto.String("idle")},
+					{Name: proto.String("core"), Value: proto.String("0,0")},
+				},
+				Counter: &dto.Counter{Value: proto.Float64(42.5)},
+			}},
+		},
+		{
+			Name: proto.String("windows_service_info"),
+			Type: dto.MetricType_GAUGE.Enum(),
+			Metric: []*dto.Metric{
+				{
+					Label: []*dto.LabelPair{
+						{Name: proto.String("display_name"), Value: proto.String("Windows Update")},
+						{Name: proto.String("path_name"), Value: proto.String("")},
+					},
+					Gauge: &dto.Gauge{Value: proto.Float64(1)},
+				},
+				{Gauge: &dto.Gauge{Value: proto.Float64(math.NaN())}},
+			},
+		},
+		{
+			Name: proto.String("windows_exporter_collector_duration_seconds"),
+			Type: dto.MetricType_HISTOGRAM.Enum(),
+			Metric: []*dto.Metric{{Histogram: &dto.Histogram{
+				SampleCount: proto.Uint64(3),
+				SampleSum:   proto.Float64(1.5),
+				Bucket: []*dto.Bucket{
+					{UpperBound: proto.Float64(1), CumulativeCount: proto.Uint64(2)},
+					{UpperBound: proto.Float64(math.Inf(+1)), CumulativeCount: proto.Uint64(3)},
+				},
+			}}},
+		},
+	}
+
+	var output strings.Builder
+
+	require.NoError(t, format.WriteInflux(&output, metricFamilies, time.Unix(1700000000, 0)))
+	require.Equal(t, `windows_cpu_time_total,core=0\,0,mode=idle counter=42.5 1700000000000000000
+windows_service_info,display_name=Windows\ Update gauge=1 1700000000000000000
+windows_exporter_collector_duration_seconds count=3,sum=1.5,1=2,+Inf=3 1700000000000000000
+`, output.String())
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package format converts gathered metric families into exposition formats
+// that are not supported by github.com/prometheus/common/expfmt.
+package format
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// MetricFamily is the JSON representation of a metric family. It is compatible with the output of prom2json.
+// Values are formatted as strings, because JSON can't represent NaN and infinity.
+type MetricFamily struct {
+	Name    string   `json:"name"`
+	Help    string   `json:"help"`
+	Type    string   `json:"type"`
+	Unit    string   `json:"unit,omitempty"`
+	Metrics []Metric `json:"metrics"`
+}
+
+// Metric is the JSON representation of a metric. Value is set for counters, gauges and untyped metrics,
+// Quantiles for summaries, and Buckets for histograms. Count and Sum are set for summaries and histograms.
+type Metric struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	TimestampMs string            `json:"timestamp_ms,omitempty"`
+	Value       string            `json:"value,omitempty"`
+	Quantiles   map[string]string `json:"quantiles,omitempty"`
+	Buckets     map[string]string `json:"buckets,omitempty"`
+	Count       string            `json:"count,omitempty"`
+	Sum         string            `json:"sum,omitempty"`
+}
+
+// ToJSON converts the metric families into their JSON representation.
+func ToJSON(metricFamilies []*dto.MetricFamily) []MetricFamily {
+	families := make([]MetricFamily, 0, len(metricFamilies))
+
+	for _, mf := range metricFamilies {
+		family := MetricFamily{
+			Name:    mf.GetName(),
+			Help:    mf.GetHelp(),
+			Type:    strings.ToLower(mf.GetType().String()),
+			Unit:    mf.GetUnit(),
+			Metrics: make([]Metric, 0, len(mf.GetMetric())),
+		}
+
+		for _, m := range mf.GetMetric() {
+			metric := Metric{}
+
+			if len(m.GetLabel()) > 0 {
+				metric.Labels = make(map[string]string, len(m.GetLabel()))
+				for _, lp := range m.GetLabel() {
+					metric.Labels[lp.GetName()] = lp.GetValue()
+				}
+			}
+
+			if m.TimestampMs != nil {
+				metric.TimestampMs = strconv.FormatInt(m.GetTimestampMs(), 10)
+			}
+
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				metric.Value = FormatFloat(m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				metric.Value = FormatFloat(m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				metric.Value = FormatFloat(m.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				metric.Quantiles = make(map[string]string, len(m.GetSummary().GetQuantile()))
+				for _, q := range m.GetSummary().GetQuantile() {
+					metric.Quantiles[FormatFloat(q.GetQuantile())] = FormatFloat(q.GetValue())
+				}
+
+				metric.Count = strconv.FormatUint(m.GetSummary().GetSampleCount(), 10)
+				metric.Sum = FormatFloat(m.GetSummary().GetSampleSum())
+			case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				metric.Buckets = make(map[string]string, len(m.GetHistogram().GetBucket()))
+				for _, b := range m.GetHistogram().GetBucket() {
+					metric.Buckets[FormatFloat(b.GetUpperBound())] = strconv.FormatUint(b.GetCumulativeCount(), 10)
+				}
+
+				metric.Count = strconv.FormatUint(m.GetHistogram().GetSampleCount(), 10)
+				metric.Sum = FormatFloat(m.GetHistogram().GetSampleSum())
+			}
+
+			family.Metrics = append(family.Metrics, metric)
+		}
+
+		families = append(families, family)
+	}
+
+	return families
+}
+
+// FormatFloat formats the value like the Prometheus text format.
+func FormatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, +1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package format_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/format"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestToJSON(t *testing.T) {
+	t.Parallel()
+
+	metricFamilies := []*dto.MetricFamily{
+		{
+			Name: proto.String("windows_logical_disk_free_bytes"),
+			Help: proto.String("Free space in bytes"),
+			Type: dto.MetricType_GAUGE.Enum(),
+			Metric: []*dto.Metric{
+				{Label: []*dto.LabelPair{{Name: proto.String("volume"), Value: proto.String("C:")}}, Gauge: &dto.Gauge{Value: proto.Float64(1024)}},
+				{Label: []*dto.LabelPair{{Name: proto.String("volume"), Value: proto.String("D:")}}, Gauge: &dto.Gauge{Value: proto.Float64(math.NaN())}},
+			},
+		},
+		{
+			Name: proto.String("windows_exporter_collector_duration_seconds"),
+			Help: proto.String("Duration of a collection."),
+			Type: dto.MetricType_HISTOGRAM.Enum(),
+			Metric: []*dto.Metric{{Histogram: &dto.Histogram{
+				SampleCount: proto.Uint64(3),
+				SampleSum:   proto.Float64(1.5),
+				Bucket: []*dto.Bucket{
+					{UpperBound: proto.Float64(1), CumulativeCount: proto.Uint64(2)},
+					{UpperBound: proto.Float64(math.Inf(+1)), CumulativeCount: proto.Uint64(3)},
+				},
+			}}},
+		},
+	}
+
+	require.Equal(t, []format.MetricFamily{
+		{
+			Name: "windows_logical_disk_free_bytes",
+			Help: "Free space in bytes",
+			Type: "gauge",
+			Metrics: []format.Metric{
+				{Labels: map[string]string{"volume": "C:"}, Value: "1024"},
+				{Labels: map[string]string{"volume": "D:"}, Value: "NaN"},
+			},
+		},
+		{
+			Name: "windows_exporter_collector_duration_seconds",
+			Help: "Duration of a collection.",
+			Type: "histogram",
+			Metrics: []format.Metric{
+				{Buckets: map[string]string{"1": "2", "+Inf": "3"}, Count: "3", Sum: "1.5"},
+			},
+		},
+	}, format.ToJSON(metricFamilies))
+}
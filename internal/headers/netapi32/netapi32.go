@@ -52,6 +52,7 @@ var (
 	netapi32             = windows.NewLazySystemDLL("netapi32")
 	procNetWkstaGetInfo  = netapi32.NewProc("NetWkstaGetInfo")
 	procNetApiBufferFree = netapi32.NewProc("NetApiBufferFree")
+	procDsGetSiteNameW   = netapi32.NewProc("DsGetSiteNameW")
 )
 
 // NetApiStatus is a map of Network Management Error Codes.
@@ -130,3 +131,18 @@ func GetWorkstationInfo() (WorkstationInfo, error) {
 
 	return workstationInfo, nil
 }
+
+// DsGetSiteName returns the name of the Active Directory site of the local computer.
+// https://learn.microsoft.com/en-us/windows/win32/api/dsgetdc/nf-dsgetdc-dsgetsitenamew
+func DsGetSiteName() (string, error) {
+	var siteName *uint16
+
+	r1, _, _ := procDsGetSiteNameW.Call(0, uintptr(unsafe.Pointer(&siteName)))
+	if r1 != 0 {
+		return "", windows.Errno(r1)
+	}
+
+	defer procNetApiBufferFree.Call(uintptr(unsafe.Pointer(siteName))) //nolint:errcheck
+
+	return windows.UTF16PtrToString(siteName), nil
+}
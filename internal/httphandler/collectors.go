@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+)
+
+// Interface guard.
+var _ http.Handler = (*CollectorsHandler)(nil)
+
+// CollectorsHandler serves the diagnostic status of all known collectors as JSON.
+type CollectorsHandler struct {
+	metricsHandler *MetricsHTTPHandler
+}
+
+type collectorsResponse struct {
+	Collectors []collector.CollectorStatus `json:"collectors"`
+}
+
+// NewCollectorsHandler returns a CollectorsHandler for the collection of the metrics handler.
+func NewCollectorsHandler(metricsHandler *MetricsHTTPHandler) CollectorsHandler {
+	return CollectorsHandler{metricsHandler: metricsHandler}
+}
+
+func (h CollectorsHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(collectorsResponse{
+		Collectors: h.metricsHandler.Collection().Status(),
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error encoding JSON: %s", err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Interface guard.
+var _ http.Handler = (*ConfigHandler)(nil)
+
+// ConfigHandler serves the resolved configuration of the exporter and the enabled collectors as JSON,
+// including the source of each value. Secrets are redacted.
+type ConfigHandler struct {
+	metricsHandler *MetricsHTTPHandler
+}
+
+// NewConfigHandler returns a ConfigHandler for the configuration of the metrics handler.
+func NewConfigHandler(metricsHandler *MetricsHTTPHandler) ConfigHandler {
+	return ConfigHandler{metricsHandler: metricsHandler}
+}
+
+func (h ConfigHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	state := h.metricsHandler.acquire()
+	resolved := state.options.Config
+	enabledCollectors := state.metricCollectors.Enabled()
+	state.inFlight.Done()
+
+	if resolved == nil {
+		http.Error(w, "configuration is not available", http.StatusNotFound)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resolved.Config(enabledCollectors)); err != nil {
+		http.Error(w, fmt.Sprintf("error encoding JSON: %s", err), http.StatusInternalServerError)
+	}
+}
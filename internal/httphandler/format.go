@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/format"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Formats of the metrics endpoint in addition to the formats negotiated by promhttp.
+const (
+	formatJSON   = "json"
+	formatInflux = "influx"
+)
+
+// formatHandler serves the gathered metrics in the JSON format or in the InfluxDB line protocol.
+// Like promhttp with [promhttp.ContinueOnError], the metrics that could be gathered are served on error.
+type formatHandler struct {
+	logger   *slog.Logger
+	gatherer prometheus.Gatherer
+	format   string
+}
+
+func (h formatHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	timestamp := time.Now()
+
+	metricFamilies, err := h.gatherer.Gather()
+	if err != nil {
+		h.logger.LogAttrs(r.Context(), slog.LevelError, "error gathering metrics",
+			slog.Any("err", err),
+		)
+
+		if len(metricFamilies) == 0 {
+			http.Error(w, fmt.Sprintf("error gathering metrics: %s", err), http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	switch h.format {
+	case formatJSON:
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(format.ToJSON(metricFamilies))
+	case formatInflux:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+		err = format.WriteInflux(w, metricFamilies, timestamp)
+	}
+
+	if err != nil {
+		h.logger.LogAttrs(r.Context(), slog.LevelError, "error encoding metrics",
+			slog.String("format", h.format),
+			slog.Any("err", err),
+		)
+	}
+}
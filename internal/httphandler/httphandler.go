@@ -18,17 +18,22 @@
 package httphandler
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/prometheus-community/windows_exporter/internal/config"
+	"github.com/prometheus-community/windows_exporter/internal/relabel"
 	"github.com/prometheus-community/windows_exporter/pkg/collector"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/collectors/version"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
 )
 
 // Interface guard.
@@ -37,18 +42,35 @@ var _ http.Handler = (*MetricsHTTPHandler)(nil)
 const defaultScrapeTimeout = 10.0
 
 type MetricsHTTPHandler struct {
-	metricCollectors *collector.Collection
+	// mu guards state. It is only held to swap or acquire the state, not while a request is served.
+	mu    sync.Mutex
+	state *handlerState
 	// exporterMetricsRegistry is a separate registry for the metrics about
 	// the exporter itself.
 	exporterMetricsRegistry *prometheus.Registry
+	// uncheckedOnce logs once, that the collection is registered unchecked.
+	uncheckedOnce sync.Once
 
-	logger  *slog.Logger
-	options Options
+	logger *slog.Logger
+}
+
+// handlerState is the collection and the options of the handler between two reloads.
+type handlerState struct {
+	metricCollectors *collector.Collection
+	options          Options
+	// inFlight counts the requests using the state, so a replaced collection is closed only once they are served.
+	inFlight sync.WaitGroup
 }
 
 type Options struct {
 	DisableExporterMetrics bool
 	TimeoutMargin          float64
+	// MetricRelabelConfigs are applied to all metrics before exposition.
+	MetricRelabelConfigs []relabel.Config
+	// Collectors are additional collectors exposed with the metrics.
+	Collectors []prometheus.Collector
+	// Config is the resolved configuration served by the [ConfigHandler].
+	Config *config.Resolved
 }
 
 func New(logger *slog.Logger, metricCollectors *collector.Collection, options *Options) *MetricsHTTPHandler {
@@ -60,9 +82,8 @@ func New(logger *slog.Logger, metricCollectors *collector.Collection, options *O
 	}
 
 	handler := &MetricsHTTPHandler{
-		metricCollectors: metricCollectors,
-		logger:           logger,
-		options:          *options,
+		state:  &handlerState{metricCollectors: metricCollectors, options: *options},
+		logger: logger,
 	}
 
 	if !options.DisableExporterMetrics {
@@ -77,14 +98,58 @@ func New(logger *slog.Logger, metricCollectors *collector.Collection, options *O
 	return handler
 }
 
+// Reload replaces the collection and the options of the handler and returns the previous collection.
+// New requests use the new collection immediately. Reload waits until all requests using the previous
+// collection are served, so it can be closed.
+// DisableExporterMetrics and Collectors can't be changed by a reload.
+func (c *MetricsHTTPHandler) Reload(metricCollectors *collector.Collection, options *Options) *collector.Collection {
+	c.mu.Lock()
+
+	previous := c.state
+
+	state := &handlerState{metricCollectors: metricCollectors, options: previous.options}
+	state.options.TimeoutMargin = options.TimeoutMargin
+	state.options.MetricRelabelConfigs = options.MetricRelabelConfigs
+	state.options.Config = options.Config
+
+	c.state = state
+
+	c.mu.Unlock()
+
+	previous.inFlight.Wait()
+
+	return previous.metricCollectors
+}
+
+// acquire returns the current state of the handler. The caller must call inFlight.Done, once it is served.
+func (c *MetricsHTTPHandler) acquire() *handlerState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.state.inFlight.Add(1)
+
+	return c.state
+}
+
+// Collection returns the current collection of the handler.
+func (c *MetricsHTTPHandler) Collection() *collector.Collection {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.state.metricCollectors
+}
+
 func (c *MetricsHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	state := c.acquire()
+	defer state.inFlight.Done()
+
 	logger := c.logger.With(
 		slog.String("remote", r.RemoteAddr),
 	)
 
-	scrapeTimeout := c.getScrapeTimeout(logger, r)
+	scrapeTimeout := c.getScrapeTimeout(logger, r, state)
 
-	handler, err := c.handlerFactory(logger, scrapeTimeout, r.URL.Query()["collect[]"])
+	handler, err := c.handlerFactory(logger, state, scrapeTimeout, r.URL.Query()["collect[]"], r.URL.Query().Get("format"))
 	if err != nil {
 		logger.WarnContext(r.Context(), "Couldn't create filtered metrics handler",
 			slog.Any("err", err),
@@ -99,7 +164,19 @@ func (c *MetricsHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	handler.ServeHTTP(w, r)
 }
 
-func (c *MetricsHTTPHandler) getScrapeTimeout(logger *slog.Logger, r *http.Request) time.Duration {
+// metadata returns the metric metadata of the current collection, using the scrape timeout of the request.
+func (c *MetricsHTTPHandler) metadata(r *http.Request) ([]collector.MetricMetadata, error) {
+	state := c.acquire()
+	defer state.inFlight.Done()
+
+	logger := c.logger.With(
+		slog.String("remote", r.RemoteAddr),
+	)
+
+	return state.metricCollectors.Metadata(logger, c.getScrapeTimeout(logger, r, state))
+}
+
+func (c *MetricsHTTPHandler) getScrapeTimeout(logger *slog.Logger, r *http.Request, state *handlerState) time.Duration {
 	var timeoutSeconds float64
 
 	if v := r.Header.Get("X-Prometheus-Scrape-Timeout-Seconds"); v != "" {
@@ -115,35 +192,40 @@ func (c *MetricsHTTPHandler) getScrapeTimeout(logger *slog.Logger, r *http.Reque
 		timeoutSeconds = defaultScrapeTimeout
 	}
 
-	timeoutSeconds -= c.options.TimeoutMargin
+	timeoutSeconds -= state.options.TimeoutMargin
 
 	return time.Duration(timeoutSeconds*1e9) * time.Nanosecond
 }
 
-func (c *MetricsHTTPHandler) handlerFactory(logger *slog.Logger, scrapeTimeout time.Duration, requestedCollectors []string) (http.Handler, error) {
-	reg := prometheus.NewRegistry()
-	reg.MustRegister(version.NewCollector("windows_exporter"))
-
-	collectionHandler, err := c.metricCollectors.NewHandler(scrapeTimeout, c.logger, requestedCollectors)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't create collector handler: %w", err)
+// handlerFactory returns the handler of a request. If format is empty, the format is negotiated by promhttp.
+func (c *MetricsHTTPHandler) handlerFactory(logger *slog.Logger, state *handlerState, scrapeTimeout time.Duration, requestedCollectors []string, outputFormat string) (http.Handler, error) {
+	if outputFormat != "" && outputFormat != formatJSON && outputFormat != formatInflux {
+		return nil, fmt.Errorf("unknown format %q, must be one of %s or %s", outputFormat, formatJSON, formatInflux)
 	}
 
-	if err := reg.Register(collectionHandler); err != nil {
-		return nil, fmt.Errorf("couldn't register Prometheus collector: %w", err)
+	gatherer, err := c.gatherer(state, scrapeTimeout, requestedCollectors)
+	if err != nil {
+		return nil, err
 	}
 
 	var regHandler http.Handler
-	if c.exporterMetricsRegistry != nil {
+
+	switch {
+	case outputFormat != "":
+		regHandler = formatHandler{logger: logger, gatherer: gatherer, format: outputFormat}
+
+		if c.exporterMetricsRegistry != nil {
+			regHandler = promhttp.InstrumentMetricHandler(c.exporterMetricsRegistry, regHandler)
+		}
+	case c.exporterMetricsRegistry != nil:
 		regHandler = promhttp.HandlerFor(
-			prometheus.Gatherers{c.exporterMetricsRegistry, reg},
+			gatherer,
 			promhttp.HandlerOpts{
-				ErrorLog:            slog.NewLogLogger(logger.Handler(), slog.LevelError),
-				ErrorHandling:       promhttp.ContinueOnError,
-				MaxRequestsInFlight: 1,
-				Registry:            c.exporterMetricsRegistry,
-				EnableOpenMetrics:   true,
-				ProcessStartTime:    c.metricCollectors.GetStartTime(),
+				ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelError),
+				ErrorHandling:     promhttp.ContinueOnError,
+				Registry:          c.exporterMetricsRegistry,
+				EnableOpenMetrics: true,
+				ProcessStartTime:  state.metricCollectors.GetStartTime(),
 			},
 		)
 
@@ -152,18 +234,79 @@ func (c *MetricsHTTPHandler) handlerFactory(logger *slog.Logger, scrapeTimeout t
 		regHandler = promhttp.InstrumentMetricHandler(
 			c.exporterMetricsRegistry, regHandler,
 		)
-	} else {
+	default:
 		regHandler = promhttp.HandlerFor(
-			reg,
+			gatherer,
 			promhttp.HandlerOpts{
-				ErrorLog:            slog.NewLogLogger(logger.Handler(), slog.LevelError),
-				ErrorHandling:       promhttp.ContinueOnError,
-				MaxRequestsInFlight: 1,
-				EnableOpenMetrics:   true,
-				ProcessStartTime:    c.metricCollectors.GetStartTime(),
+				ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelError),
+				ErrorHandling:     promhttp.ContinueOnError,
+				EnableOpenMetrics: true,
+				ProcessStartTime:  state.metricCollectors.GetStartTime(),
 			},
 		)
 	}
 
 	return regHandler, nil
 }
+
+// gatherer returns the gatherer of the metrics exposed by the handler, including the exporter metrics.
+func (c *MetricsHTTPHandler) gatherer(state *handlerState, scrapeTimeout time.Duration, requestedCollectors []string) (prometheus.Gatherer, error) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(version.NewCollector("windows_exporter"))
+	reg.MustRegister(state.options.Collectors...)
+
+	collectionHandler, err := state.metricCollectors.NewHandler(scrapeTimeout, c.logger, requestedCollectors)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create collector handler: %w", err)
+	}
+
+	// The descriptors of the collection are checked against each other on build, but not against
+	// the descriptors of the additional collectors. On conflict, the collection is registered unchecked.
+	if err := reg.Register(collectionHandler); err != nil {
+		c.uncheckedOnce.Do(func() {
+			c.logger.LogAttrs(context.Background(), slog.LevelWarn, "metric descriptors conflict with the descriptors of the exporter, metrics are not checked on registration",
+				slog.Any("err", err),
+			)
+		})
+
+		if err := reg.Register(uncheckedCollector{collectionHandler}); err != nil {
+			return nil, fmt.Errorf("couldn't register Prometheus collector: %w", err)
+		}
+	}
+
+	if c.exporterMetricsRegistry != nil {
+		return relabelGatherer(state, prometheus.Gatherers{c.exporterMetricsRegistry, reg}), nil
+	}
+
+	return relabelGatherer(state, reg), nil
+}
+
+// Gather gathers the metrics of all enabled collectors the same way as a scrape of the metrics endpoint,
+// including the exporter metrics and the metric relabeling. It is used to push the metrics.
+func (c *MetricsHTTPHandler) Gather(scrapeTimeout time.Duration) ([]*dto.MetricFamily, error) {
+	state := c.acquire()
+	defer state.inFlight.Done()
+
+	gatherer, err := c.gatherer(state, scrapeTimeout, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return gatherer.Gather()
+}
+
+// relabelGatherer applies the metric relabel configs to the gathered metrics, if any.
+func relabelGatherer(state *handlerState, gatherer prometheus.Gatherer) prometheus.Gatherer {
+	if len(state.options.MetricRelabelConfigs) == 0 {
+		return gatherer
+	}
+
+	return relabel.NewGatherer(gatherer, state.options.MetricRelabelConfigs)
+}
+
+// uncheckedCollector registers a collector without descriptors, so that the registry doesn't check its metrics.
+type uncheckedCollector struct {
+	prometheus.Collector
+}
+
+func (u uncheckedCollector) Describe(chan<- *prometheus.Desc) {}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+)
+
+// Interface guard.
+var _ http.Handler = (*MetadataHandler)(nil)
+
+// MetadataHandler serves the metadata of all metric families exposed by the enabled collectors as JSON.
+type MetadataHandler struct {
+	metricsHandler *MetricsHTTPHandler
+}
+
+type metadataResponse struct {
+	Metrics []collector.MetricMetadata `json:"metrics"`
+}
+
+// NewMetadataHandler returns a MetadataHandler for the collection of the metrics handler.
+func NewMetadataHandler(metricsHandler *MetricsHTTPHandler) MetadataHandler {
+	return MetadataHandler{metricsHandler: metricsHandler}
+}
+
+func (h MetadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	metadata, err := h.metricsHandler.metadata(r)
+	if err != nil {
+		// The metadata of the metrics gathered successfully is served anyway.
+		h.metricsHandler.logger.LogAttrs(r.Context(), slog.LevelWarn, "error gathering metric metadata",
+			slog.Any("err", err),
+		)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err = json.NewEncoder(w).Encode(metadataResponse{Metrics: metadata}); err != nil {
+		http.Error(w, fmt.Sprintf("error encoding JSON: %s", err), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Interface guards.
+var (
+	_ http.Handler         = (*ReloadHandler)(nil)
+	_ prometheus.Collector = (*ReloadHandler)(nil)
+)
+
+// ReloadHandler reloads the configuration on request and exposes the result of the last reload.
+type ReloadHandler struct {
+	logger   *slog.Logger
+	reloadFn func(ctx context.Context) error
+
+	// reloadMu serializes the reloads.
+	reloadMu sync.Mutex
+
+	mu                 sync.Mutex
+	lastReloadSuccess  bool
+	lastReloadSuccTime time.Time
+
+	lastReloadSuccessfulDesc       *prometheus.Desc
+	lastReloadSuccessTimestampDesc *prometheus.Desc
+}
+
+// NewReloadHandler returns a new ReloadHandler. The configuration is reloaded by reloadFn.
+func NewReloadHandler(logger *slog.Logger, reloadFn func(ctx context.Context) error) *ReloadHandler {
+	return &ReloadHandler{
+		logger:             logger,
+		reloadFn:           reloadFn,
+		lastReloadSuccess:  true,
+		lastReloadSuccTime: time.Now(),
+		lastReloadSuccessfulDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "config_last_reload_successful"),
+			"windows_exporter: Whether the last configuration reload attempt was successful.",
+			nil,
+			nil,
+		),
+		lastReloadSuccessTimestampDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "config_last_reload_success_timestamp_seconds"),
+			"windows_exporter: Timestamp of the last successful configuration reload.",
+			nil,
+			nil,
+		),
+	}
+}
+
+// Reload reloads the configuration. On failure, the previous configuration is kept.
+func (h *ReloadHandler) Reload(ctx context.Context) error {
+	h.reloadMu.Lock()
+	defer h.reloadMu.Unlock()
+
+	err := h.reloadFn(ctx)
+
+	h.mu.Lock()
+	h.lastReloadSuccess = err == nil
+
+	if err == nil {
+		h.lastReloadSuccTime = time.Now()
+	}
+	h.mu.Unlock()
+
+	if err != nil {
+		h.logger.LogAttrs(ctx, slog.LevelError, "failed to reload configuration, keeping the previous configuration",
+			slog.Any("err", err),
+		)
+
+		return err
+	}
+
+	h.logger.LogAttrs(ctx, slog.LevelInfo, "configuration reloaded")
+
+	return nil
+}
+
+func (h *ReloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h.Reload(r.Context()); err != nil {
+		http.Error(w, fmt.Sprintf("failed to reload configuration: %s", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
+
+func (h *ReloadHandler) Describe(ch chan<- *prometheus.Desc) {
+	ch <- h.lastReloadSuccessfulDesc
+	ch <- h.lastReloadSuccessTimestampDesc
+}
+
+func (h *ReloadHandler) Collect(ch chan<- prometheus.Metric) {
+	h.mu.Lock()
+	lastReloadSuccess, lastReloadSuccTime := h.lastReloadSuccess, h.lastReloadSuccTime
+	h.mu.Unlock()
+
+	var successValue float64
+	if lastReloadSuccess {
+		successValue = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		h.lastReloadSuccessfulDesc,
+		prometheus.GaugeValue,
+		successValue,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		h.lastReloadSuccessTimestampDesc,
+		prometheus.GaugeValue,
+		float64(lastReloadSuccTime.Unix()),
+	)
+}
@@ -18,6 +18,7 @@
 package mi
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -190,6 +191,13 @@ func (s *Session) QueryInstances(flags OperationFlags, operationOptions *Operati
 func (s *Session) QueryUnmarshal(dst any,
 	flags OperationFlags, operationOptions *OperationOptions,
 	namespaceName Namespace, queryDialect QueryDialect, queryExpression Query,
+) error {
+	return s.queryUnmarshal(context.Background(), dst, flags, operationOptions, namespaceName, queryDialect, queryExpression)
+}
+
+func (s *Session) queryUnmarshal(ctx context.Context, dst any,
+	flags OperationFlags, operationOptions *OperationOptions,
+	namespaceName Namespace, queryDialect QueryDialect, queryExpression Query,
 ) error {
 	if s == nil || s.ft == nil {
 		return ErrNotInitialized
@@ -237,9 +245,28 @@ func (s *Session) QueryUnmarshal(dst any,
 		_ = operation.Close()
 	}()
 
+	// Cancel the operation, if the context is done before all instances are received.
+	// The operation must not be closed while the cancellation is in progress.
+	canceledCh := make(chan struct{})
+	stopCancel := context.AfterFunc(ctx, func() {
+		defer close(canceledCh)
+
+		_ = operation.Cancel()
+	})
+
+	defer func() {
+		if !stopCancel() {
+			<-canceledCh
+		}
+	}()
+
 	for {
 		instance, moreResults, err := operation.GetInstance()
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return fmt.Errorf("failed to get instance: %w", ctxErr)
+			}
+
 			return fmt.Errorf("failed to get instance: %w", err)
 		}
 
@@ -334,3 +361,39 @@ func (s *Session) Query(dst any, namespaceName Namespace, queryExpression Query,
 
 	return s.QueryUnmarshal(dst, OperationFlagsStandardRTTI, operationOptions, namespaceName, QueryDialectWQL, queryExpression)
 }
+
+// QueryContext queries for a set of instances based on a query expression.
+// The deadline of the context is used as operation timeout. If the context is done
+// before all instances are received, the operation is canceled.
+func (s *Session) QueryContext(ctx context.Context, dst any, namespaceName Namespace, queryExpression Query) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	app, err := s.GetApplication()
+	if err != nil {
+		return fmt.Errorf("failed to get application: %w", err)
+	}
+
+	operationOptions, err := app.NewOperationOptions()
+	if err != nil {
+		return fmt.Errorf("failed to create operation options: %w", err)
+	}
+
+	defer func() {
+		_ = operationOptions.Delete()
+	}()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		queryTimeout := time.Until(deadline)
+		if queryTimeout <= 0 {
+			return context.DeadlineExceeded
+		}
+
+		if err = operationOptions.SetTimeout(queryTimeout); err != nil {
+			return fmt.Errorf("failed to set timeout: %w", err)
+		}
+	}
+
+	return s.queryUnmarshal(ctx, dst, OperationFlagsStandardRTTI, operationOptions, namespaceName, QueryDialectWQL, queryExpression)
+}
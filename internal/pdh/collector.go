@@ -18,6 +18,7 @@
 package pdh
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -53,10 +54,18 @@ type Collector struct {
 	nameIndexValue        int
 	metricsTypeIndexValue int
 
-	collectCh chan any
+	collectCh chan collectRequest
 	errorCh   chan error
 }
 
+// collectRequest is passed to the collect worker.
+//
+//nolint:containedctx
+type collectRequest struct {
+	ctx context.Context
+	dst any
+}
+
 type Counter struct {
 	Name       string
 	Desc       string
@@ -240,7 +249,7 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 		return nil, errors.New("no counters configured")
 	}
 
-	collector.collectCh = make(chan any)
+	collector.collectCh = make(chan collectRequest)
 	collector.errorCh = make(chan error)
 
 	if resultType == CounterTypeRaw {
@@ -276,6 +285,12 @@ func (c *Collector) Describe() map[string]string {
 }
 
 func (c *Collector) Collect(dst any) error {
+	return c.CollectWithContext(context.Background(), dst)
+}
+
+// CollectWithContext collects the counter values into dst.
+// The worker stops processing the counters once the context is done.
+func (c *Collector) CollectWithContext(ctx context.Context, dst any) error {
 	if c == nil {
 		return ErrPerformanceCounterNotInitialized
 	}
@@ -287,7 +302,11 @@ func (c *Collector) Collect(dst any) error {
 		return ErrPerformanceCounterNotInitialized
 	}
 
-	c.collectCh <- dst
+	select {
+	case c.collectCh <- collectRequest{ctx: ctx, dst: dst}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 
 	return <-c.errorCh
 }
@@ -302,13 +321,17 @@ func (c *Collector) collectWorkerRaw() {
 
 	buf := make([]byte, 1)
 
-	for data := range c.collectCh {
+	for req := range c.collectCh {
 		err = (func() error {
+			if err := req.ctx.Err(); err != nil {
+				return err
+			}
+
 			if ret := CollectQueryData(c.handle); ret != ErrorSuccess {
 				return fmt.Errorf("failed to collect query data: %w", NewPdhError(ret))
 			}
 
-			dv := reflect.ValueOf(data)
+			dv := reflect.ValueOf(req.dst)
 			if dv.Kind() != reflect.Pointer || dv.IsNil() {
 				return fmt.Errorf("expected a pointer, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
 			}
@@ -337,6 +360,10 @@ func (c *Collector) collectWorkerRaw() {
 			stringMap := map[*uint16]string{}
 
 			for _, counter := range c.counters {
+				if err := req.ctx.Err(); err != nil {
+					return err
+				}
+
 				for _, instance := range counter.Instances {
 					// Get the info with the current buffer size
 					bytesNeeded = uint32(cap(buf))
@@ -469,13 +496,17 @@ func (c *Collector) collectWorkerFormatted() {
 
 	buf := make([]byte, 1)
 
-	for data := range c.collectCh {
+	for req := range c.collectCh {
 		err = (func() error {
+			if err := req.ctx.Err(); err != nil {
+				return err
+			}
+
 			if ret := CollectQueryData(c.handle); ret != ErrorSuccess {
 				return fmt.Errorf("failed to collect query data: %w", NewPdhError(ret))
 			}
 
-			dv := reflect.ValueOf(data)
+			dv := reflect.ValueOf(req.dst)
 			if dv.Kind() != reflect.Pointer || dv.IsNil() {
 				return fmt.Errorf("expected a pointer, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
 			}
@@ -504,6 +535,10 @@ func (c *Collector) collectWorkerFormatted() {
 			stringMap := map[*uint16]string{}
 
 			for _, counter := range c.counters {
+				if err := req.ctx.Err(); err != nil {
+					return err
+				}
+
 				for _, instance := range counter.Instances {
 					// Get the info with the current buffer size
 					bytesNeeded = uint32(cap(buf))
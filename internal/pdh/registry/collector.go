@@ -18,6 +18,7 @@
 package registry
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -101,6 +102,16 @@ func (c *Collector) Describe() map[string]string {
 	return map[string]string{}
 }
 
+// CollectWithContext collects the counter values into data.
+// The registry is read in a single call, so the context is only checked upfront.
+func (c *Collector) CollectWithContext(ctx context.Context, data any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return c.Collect(data)
+}
+
 func (c *Collector) Collect(data any) error {
 	dv := reflect.ValueOf(data)
 	if dv.Kind() != reflect.Pointer || dv.IsNil() {
@@ -17,7 +17,10 @@
 
 package types
 
+import "context"
+
 type Collector interface {
 	Collect(dst any) error
+	CollectWithContext(ctx context.Context, dst any) error
 	Close()
 }
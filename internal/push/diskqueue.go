@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package push
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Interface guard.
+var _ ExpiringQueue = (*DiskQueue)(nil)
+
+const (
+	// diskQueueFileExt is the extension of the files holding a payload.
+	// The file name consists of the sequence number and the creation time in Unix milliseconds.
+	diskQueueFileExt = ".payload"
+	// diskQueueHeaderSize is the size of the CRC32 checksum that precedes the payload.
+	diskQueueHeaderSize = 4
+)
+
+//nolint:gochecknoglobals
+var diskQueueCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// diskQueueEntry is a payload stored in a file of a [DiskQueue].
+type diskQueueEntry struct {
+	seq     uint64
+	created time.Time
+	size    int64
+}
+
+// DiskQueue is a [Queue] that writes each payload to a file in a directory before it is sent.
+// Payloads that were not sent before the exporter stopped are sent in order after a restart.
+// The oldest payloads are dropped, if the payloads exceed the maximum size or age.
+type DiskQueue struct {
+	mu      sync.Mutex
+	dir     string
+	maxSize int64
+	maxAge  time.Duration
+	entries []diskQueueEntry
+	size    int64
+	nextSeq uint64
+	peeked  uint64
+}
+
+// NewDiskQueue returns a DiskQueue that stores the payloads in dir. Payloads found in dir are queued again.
+// A maxSize or maxAge of 0 disables the respective limit.
+func NewDiskQueue(dir string, maxSize int64, maxAge time.Duration) (*DiskQueue, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create buffer directory: %w", err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read buffer directory: %w", err)
+	}
+
+	q := &DiskQueue{dir: dir, maxSize: maxSize, maxAge: maxAge}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		entry, ok := parseDiskQueueFileName(file.Name())
+		if !ok {
+			// Leftover of a write that was interrupted.
+			if strings.HasSuffix(file.Name(), diskQueueFileExt+".tmp") {
+				_ = os.Remove(filepath.Join(dir, file.Name()))
+			}
+
+			continue
+		}
+
+		info, err := file.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read buffer file: %w", err)
+		}
+
+		entry.size = max(info.Size()-diskQueueHeaderSize, 0)
+		q.entries = append(q.entries, entry)
+		q.size += entry.size
+	}
+
+	slices.SortFunc(q.entries, func(a, b diskQueueEntry) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	if len(q.entries) > 0 {
+		q.nextSeq = q.entries[len(q.entries)-1].seq + 1
+	}
+
+	return q, nil
+}
+
+func (q *DiskQueue) Push(payload []byte) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entry := diskQueueEntry{seq: q.nextSeq, created: time.Now(), size: int64(len(payload))}
+
+	data := make([]byte, diskQueueHeaderSize, diskQueueHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(data, crc32.Checksum(payload, diskQueueCRCTable))
+	data = append(data, payload...)
+
+	if err := writeFileSync(q.path(entry), data); err != nil {
+		return 0, err
+	}
+
+	q.nextSeq++
+	q.entries = append(q.entries, entry)
+	q.size += entry.size
+
+	var (
+		dropped int
+		errs    []error
+	)
+
+	// The new payload is kept, even if it exceeds the maximum size on its own.
+	for q.maxSize > 0 && q.size > q.maxSize && len(q.entries) > 1 {
+		errs = append(errs, q.removeHead())
+		dropped++
+	}
+
+	return dropped, errors.Join(errs...)
+}
+
+func (q *DiskQueue) Peek() ([]byte, bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.entries) == 0 {
+		return nil, false, nil
+	}
+
+	entry := q.entries[0]
+	q.peeked = entry.seq
+
+	data, err := os.ReadFile(q.path(entry))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read buffered payload: %w", err)
+	}
+
+	if len(data) < diskQueueHeaderSize {
+		return nil, false, fmt.Errorf("buffered payload %s is truncated: %w", q.path(entry), ErrCorruptedPayload)
+	}
+
+	payload := data[diskQueueHeaderSize:]
+
+	if binary.LittleEndian.Uint32(data) != crc32.Checksum(payload, diskQueueCRCTable) {
+		return nil, false, fmt.Errorf("buffered payload %s has an invalid checksum: %w", q.path(entry), ErrCorruptedPayload)
+	}
+
+	return payload, true, nil
+}
+
+func (q *DiskQueue) Pop() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.entries) > 0 && q.entries[0].seq == q.peeked {
+		return q.removeHead()
+	}
+
+	return nil
+}
+
+func (q *DiskQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.entries)
+}
+
+func (q *DiskQueue) Size() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.size
+}
+
+func (q *DiskQueue) Expire() (int, error) {
+	if q.maxAge <= 0 {
+		return 0, nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var (
+		expired int
+		errs    []error
+	)
+
+	deadline := time.Now().Add(-q.maxAge)
+
+	for len(q.entries) > 0 && q.entries[0].created.Before(deadline) {
+		errs = append(errs, q.removeHead())
+		expired++
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+// removeHead removes the oldest payload. q.mu must be held.
+// The payload is removed from the queue, even if the file can't be deleted.
+func (q *DiskQueue) removeHead() error {
+	entry := q.entries[0]
+	q.entries = q.entries[1:]
+	q.size -= entry.size
+
+	if err := os.Remove(q.path(entry)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove buffered payload: %w", err)
+	}
+
+	return nil
+}
+
+func (q *DiskQueue) path(entry diskQueueEntry) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d-%d%s", entry.seq, entry.created.UnixMilli(), diskQueueFileExt))
+}
+
+// parseDiskQueueFileName parses the sequence number and the creation time from the name of a payload file.
+func parseDiskQueueFileName(name string) (diskQueueEntry, bool) {
+	name, ok := strings.CutSuffix(name, diskQueueFileExt)
+	if !ok {
+		return diskQueueEntry{}, false
+	}
+
+	seqString, createdString, ok := strings.Cut(name, "-")
+	if !ok {
+		return diskQueueEntry{}, false
+	}
+
+	seq, err := strconv.ParseUint(seqString, 10, 64)
+	if err != nil {
+		return diskQueueEntry{}, false
+	}
+
+	created, err := strconv.ParseInt(createdString, 10, 64)
+	if err != nil {
+		return diskQueueEntry{}, false
+	}
+
+	return diskQueueEntry{seq: seq, created: time.UnixMilli(created)}, true
+}
+
+// writeFileSync writes the file to a temporary file first and renames it after it was synced,
+// so a crash never leaves a partially written payload file.
+func writeFileSync(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create buffer file: %w", err)
+	}
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmpPath)
+
+		return fmt.Errorf("failed to write buffer file: %w", err)
+	}
+
+	return nil
+}
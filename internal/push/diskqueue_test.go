@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package push_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskQueueReplaysInOrder(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	queue, err := push.NewDiskQueue(dir, 0, 0)
+	require.NoError(t, err)
+
+	for _, payload := range []string{"a", "b", "c"} {
+		_, err = queue.Push([]byte(payload))
+		require.NoError(t, err)
+	}
+
+	payload, ok, err := queue.Peek()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "a", string(payload))
+	require.NoError(t, queue.Pop())
+
+	// A write interrupted by a crash leaves a temporary file behind.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00000000000000000003-0.payload.tmp"), []byte("d"), 0o600))
+
+	// The exporter restarts.
+	queue, err = push.NewDiskQueue(dir, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, queue.Len())
+	require.Equal(t, int64(2), queue.Size())
+
+	_, err = queue.Push([]byte("e"))
+	require.NoError(t, err)
+
+	for _, expected := range []string{"b", "c", "e"} {
+		payload, ok, err = queue.Peek()
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expected, string(payload))
+		require.NoError(t, queue.Pop())
+	}
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestDiskQueueLimits(t *testing.T) {
+	t.Parallel()
+
+	queue, err := push.NewDiskQueue(t.TempDir(), 4, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	for _, payload := range []string{"aa", "bb"} {
+		dropped, err := queue.Push([]byte(payload))
+		require.NoError(t, err)
+		require.Zero(t, dropped)
+	}
+
+	// The oldest payload is dropped, once the maximum size is exceeded.
+	dropped, err := queue.Push([]byte("cc"))
+	require.NoError(t, err)
+	require.Equal(t, 1, dropped)
+
+	payload, ok, err := queue.Peek()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "bb", string(payload))
+
+	expired, err := queue.Expire()
+	require.NoError(t, err)
+	require.Zero(t, expired)
+
+	time.Sleep(100 * time.Millisecond)
+
+	expired, err = queue.Expire()
+	require.NoError(t, err)
+	require.Equal(t, 2, expired)
+	require.Zero(t, queue.Len())
+	require.Zero(t, queue.Size())
+}
+
+func TestDiskQueueCorruptedPayload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	queue, err := push.NewDiskQueue(dir, 0, 0)
+	require.NoError(t, err)
+
+	for _, payload := range []string{"a", "b"} {
+		_, err = queue.Push([]byte(payload))
+		require.NoError(t, err)
+	}
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	// The checksum of the first payload doesn't match anymore.
+	first := filepath.Join(dir, files[0].Name())
+	data, err := os.ReadFile(first)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(first, data, 0o600))
+
+	_, _, err = queue.Peek()
+	require.ErrorIs(t, err, push.ErrCorruptedPayload)
+	require.NoError(t, queue.Pop())
+
+	// Read errors are not a corruption, so that the payload is retried.
+	require.NoError(t, os.Remove(filepath.Join(dir, files[1].Name())))
+
+	_, _, err = queue.Peek()
+	require.Error(t, err)
+	require.NotErrorIs(t, err, push.ErrCorruptedPayload)
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package push
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/common/config"
+)
+
+// HTTPAuth is the authentication of the HTTP client of a push exporter.
+type HTTPAuth struct {
+	Username        string
+	Password        string
+	PasswordFile    string
+	BearerToken     string
+	BearerTokenFile string
+}
+
+// flagger is implemented by [kingpin.Application] and [kingpin.CmdClause].
+type flagger interface {
+	Flag(name, help string) *kingpin.FlagClause
+}
+
+// NewHTTPAuthWithFlags registers the authentication flags with the given prefix, e.g., push.remote-write.
+func NewHTTPAuthWithFlags(app flagger, prefix string) *HTTPAuth {
+	auth := &HTTPAuth{}
+
+	app.Flag(prefix+".basic-auth.username", "Username for basic authentication.").
+		Default("").StringVar(&auth.Username)
+	app.Flag(prefix+".basic-auth.password", "Password for basic authentication. Prefer --"+prefix+".basic-auth.password-file.").
+		Default("").StringVar(&auth.Password)
+	app.Flag(prefix+".basic-auth.password-file", "File containing the password for basic authentication.").
+		Default("").StringVar(&auth.PasswordFile)
+	app.Flag(prefix+".bearer-token", "Bearer token for authentication. Prefer --"+prefix+".bearer-token-file.").
+		Default("").StringVar(&auth.BearerToken)
+	app.Flag(prefix+".bearer-token-file", "File containing the bearer token for authentication.").
+		Default("").StringVar(&auth.BearerTokenFile)
+
+	return auth
+}
+
+// NewHTTPClient returns an HTTP client with the authentication and the timeout.
+func NewHTTPClient(name string, auth HTTPAuth, timeout time.Duration) (*http.Client, error) {
+	httpConfig := config.DefaultHTTPClientConfig
+
+	if auth.Username != "" {
+		httpConfig.BasicAuth = &config.BasicAuth{
+			Username:     auth.Username,
+			Password:     config.Secret(auth.Password),
+			PasswordFile: auth.PasswordFile,
+		}
+	}
+
+	if auth.BearerToken != "" || auth.BearerTokenFile != "" {
+		if httpConfig.BasicAuth != nil {
+			return nil, errors.New("at most one of basic authentication and bearer token must be configured")
+		}
+
+		httpConfig.Authorization = &config.Authorization{
+			Type:            "Bearer",
+			Credentials:     config.Secret(auth.BearerToken),
+			CredentialsFile: auth.BearerTokenFile,
+		}
+	}
+
+	if err := httpConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid HTTP client configuration: %w", err)
+	}
+
+	client, err := config.NewClientFromConfig(httpConfig, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	client.Timeout = timeout
+
+	return client, nil
+}
+
+// RoundTripper wraps the round tripper with the authentication. It is used by clients that need control
+// over the transport, e.g., to use HTTP/2 without TLS.
+func (a HTTPAuth) RoundTripper(rt http.RoundTripper) (http.RoundTripper, error) {
+	hasBearerToken := a.BearerToken != "" || a.BearerTokenFile != ""
+
+	switch {
+	case a.Username != "" && hasBearerToken:
+		return nil, errors.New("at most one of basic authentication and bearer token must be configured")
+	case a.Username != "":
+		return config.NewBasicAuthRoundTripper(config.NewInlineSecret(a.Username), secretReader(a.Password, a.PasswordFile), rt), nil
+	case hasBearerToken:
+		return config.NewAuthorizationCredentialsRoundTripper("Bearer", secretReader(a.BearerToken, a.BearerTokenFile), rt), nil
+	default:
+		return rt, nil
+	}
+}
+
+// secretReader returns a reader for the inline secret, or for the file if it is set.
+func secretReader(secret, file string) config.SecretReader {
+	if file != "" {
+		return config.NewFileSecret(file)
+	}
+
+	return config.NewInlineSecret(secret)
+}
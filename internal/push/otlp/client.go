@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	"github.com/prometheus/common/version"
+)
+
+// Interface guards.
+var (
+	_ push.Client = (*HTTPClient)(nil)
+	_ push.Client = (*GRPCClient)(nil)
+)
+
+// maxErrorBodySize is the maximum number of bytes of a response body included in an error.
+const maxErrorBodySize = 512
+
+// exportMethod is the path of the gRPC method that receives the metrics.
+const exportMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+
+// retryableGRPCCodes are the gRPC status codes that are retried according to the OTLP specification.
+//
+//nolint:gochecknoglobals
+var retryableGRPCCodes = []int{
+	1,  // CANCELLED
+	4,  // DEADLINE_EXCEEDED
+	8,  // RESOURCE_EXHAUSTED
+	10, // ABORTED
+	11, // OUT_OF_RANGE
+	14, // UNAVAILABLE
+	15, // DATA_LOSS
+}
+
+// HTTPClient sends requests encoded by [NewEncoder] to an OTLP/HTTP receiver.
+type HTTPClient struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewHTTPClient returns a new HTTPClient for the URL of the metrics endpoint, e.g., http://localhost:4318/v1/metrics.
+func NewHTTPClient(url string, httpClient *http.Client) *HTTPClient {
+	return &HTTPClient{url: url, httpClient: httpClient}
+}
+
+// Send sends the gzip-compressed request. Network errors and the HTTP status codes 429, 502, 503 and 504
+// are recoverable.
+func (c *HTTPClient) Send(ctx context.Context, payload []byte) error {
+	var body bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&body)
+
+	if _, err := gzipWriter.Write(payload); err != nil {
+		return fmt.Errorf("failed to compress request: %w", err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to compress request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "windows_exporter/"+version.Version)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return push.RecoverableError{Err: fmt.Errorf("failed to send OTLP request: %w", err)}
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("OTLP receiver returned HTTP status %s: %s", resp.Status, bytes.TrimSpace(respBody))
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return push.RecoverableError{Err: err}
+	default:
+		return err
+	}
+}
+
+// GRPCClient sends requests encoded by [NewEncoder] to an OTLP/gRPC receiver.
+// The unary call is implemented on top of HTTP/2 of net/http.
+type GRPCClient struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewGRPCClient returns a new GRPCClient for the endpoint, e.g., http://localhost:4317.
+// The connection is encrypted with TLS, if the scheme of the endpoint is https.
+func NewGRPCClient(endpoint string, auth push.HTTPAuth, timeout time.Duration) (*GRPCClient, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OTLP endpoint: %w", err)
+	}
+
+	protocols := &http.Protocols{}
+
+	switch endpointURL.Scheme {
+	case "http":
+		protocols.SetUnencryptedHTTP2(true)
+	case "https":
+		protocols.SetHTTP2(true)
+	default:
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: scheme must be http or https", endpoint)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.Protocols = protocols
+
+	roundTripper, err := auth.RoundTripper(transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GRPCClient{
+		url:        endpointURL.JoinPath(exportMethod).String(),
+		httpClient: &http.Client{Transport: roundTripper, Timeout: timeout},
+	}, nil
+}
+
+// Send sends the request as uncompressed gRPC message. Network errors and the retryable gRPC status codes
+// of the OTLP specification, e.g., UNAVAILABLE, are recoverable.
+func (c *GRPCClient) Send(ctx context.Context, payload []byte) error {
+	// A gRPC message is prefixed with the compressed flag and its length.
+	message := make([]byte, 5, 5+len(payload))
+	binary.BigEndian.PutUint32(message[1:], uint32(len(payload)))
+	message = append(message, payload...)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(message))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+	req.Header.Set("User-Agent", "windows_exporter/"+version.Version)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return push.RecoverableError{Err: fmt.Errorf("failed to send OTLP request: %w", err)}
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// The trailers are available after the body was read.
+	if _, err = io.Copy(io.Discard, resp.Body); err != nil {
+		return push.RecoverableError{Err: fmt.Errorf("failed to read OTLP response: %w", err)}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("OTLP receiver returned HTTP status %s", resp.Status)
+
+		if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+			return push.RecoverableError{Err: err}
+		}
+
+		return err
+	}
+
+	status := resp.Trailer.Get("Grpc-Status")
+	statusMessage := resp.Trailer.Get("Grpc-Message")
+
+	// A response without body carries the status in the headers.
+	if status == "" {
+		status = resp.Header.Get("Grpc-Status")
+		statusMessage = resp.Header.Get("Grpc-Message")
+	}
+
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return fmt.Errorf("OTLP receiver returned invalid gRPC status %q", status)
+	}
+
+	if code == 0 {
+		return nil
+	}
+
+	if unescaped, err := url.PathUnescape(statusMessage); err == nil {
+		statusMessage = unescaped
+	}
+
+	err = fmt.Errorf("OTLP receiver returned gRPC status %d: %s", code, statusMessage)
+
+	if slices.Contains(retryableGRPCCodes, code) {
+		return push.RecoverableError{Err: err}
+	}
+
+	return err
+}
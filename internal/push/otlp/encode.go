@@ -0,0 +1,389 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package otlp
+
+import (
+	"math"
+	"os"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/version"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the OTLP metrics protobuf messages, see opentelemetry/proto/metrics/v1/metrics.proto
+// and opentelemetry/proto/collector/metrics/v1/metrics_service.proto. The messages are encoded by hand
+// to avoid depending on the OpenTelemetry SDK.
+const (
+	exportRequestResourceMetrics = 1
+
+	resourceMetricsResource     = 1
+	resourceMetricsScopeMetrics = 2
+
+	resourceAttributes = 1
+
+	scopeMetricsScope   = 1
+	scopeMetricsMetrics = 2
+
+	scopeName    = 1
+	scopeVersion = 2
+
+	keyValueKey   = 1
+	keyValueValue = 2
+
+	anyValueString = 1
+
+	metricName        = 1
+	metricDescription = 2
+	metricUnit        = 3
+	metricGauge       = 5
+	metricSum         = 7
+	metricHistogram   = 9
+	metricSummary     = 11
+
+	gaugeDataPoints = 1
+
+	sumDataPoints             = 1
+	sumAggregationTemporality = 2
+	sumIsMonotonic            = 3
+
+	histogramDataPoints             = 1
+	histogramAggregationTemporality = 2
+
+	summaryDataPoints = 1
+
+	numberDataPointStartTime  = 2
+	numberDataPointTime       = 3
+	numberDataPointAsDouble   = 4
+	numberDataPointAttributes = 7
+
+	histogramDataPointStartTime      = 2
+	histogramDataPointTime           = 3
+	histogramDataPointCount          = 4
+	histogramDataPointSum            = 5
+	histogramDataPointBucketCounts   = 6
+	histogramDataPointExplicitBounds = 7
+	histogramDataPointAttributes     = 9
+
+	summaryDataPointStartTime      = 2
+	summaryDataPointTime           = 3
+	summaryDataPointCount          = 4
+	summaryDataPointSum            = 5
+	summaryDataPointQuantileValues = 6
+	summaryDataPointAttributes     = 7
+
+	valueAtQuantileQuantile = 1
+	valueAtQuantileValue    = 2
+
+	aggregationTemporalityCumulative = 2
+)
+
+// Metrics of the os collector, that provide the resource attributes.
+const (
+	osHostnameMetric = "windows_os_hostname"
+	osInfoMetric     = "windows_os_info"
+)
+
+// scope is the name of the instrumentation scope of all metrics.
+const scope = "github.com/prometheus-community/windows_exporter"
+
+type attribute struct {
+	key, value string
+}
+
+// NewEncoder returns a [push.Encoder] that encodes the metric families as OTLP ExportMetricsServiceRequest.
+// Counters are encoded as cumulative monotonic sums starting at startTime, gauges and untyped metrics as gauges.
+// The resource attributes host.name and os.* are taken from the metrics of the os collector, if it is enabled.
+func NewEncoder(startTime time.Time) push.Encoder {
+	startTimeNano := uint64(startTime.UnixNano())
+
+	return func(metricFamilies []*dto.MetricFamily, timestamp time.Time) ([]byte, error) {
+		var metrics []byte
+
+		for _, mf := range metricFamilies {
+			metrics = protowire.AppendTag(metrics, scopeMetricsMetrics, protowire.BytesType)
+			metrics = protowire.AppendBytes(metrics, encodeMetric(mf, startTimeNano, timestamp))
+		}
+
+		var scopeMessage []byte
+
+		scopeMessage = protowire.AppendTag(scopeMessage, scopeName, protowire.BytesType)
+		scopeMessage = protowire.AppendString(scopeMessage, scope)
+		scopeMessage = protowire.AppendTag(scopeMessage, scopeVersion, protowire.BytesType)
+		scopeMessage = protowire.AppendString(scopeMessage, version.Version)
+
+		var scopeMetrics []byte
+
+		scopeMetrics = protowire.AppendTag(scopeMetrics, scopeMetricsScope, protowire.BytesType)
+		scopeMetrics = protowire.AppendBytes(scopeMetrics, scopeMessage)
+		scopeMetrics = append(scopeMetrics, metrics...)
+
+		var resource []byte
+
+		for _, attr := range resourceOf(metricFamilies) {
+			resource = appendAttribute(resource, resourceAttributes, attr)
+		}
+
+		var resourceMetrics []byte
+
+		resourceMetrics = protowire.AppendTag(resourceMetrics, resourceMetricsResource, protowire.BytesType)
+		resourceMetrics = protowire.AppendBytes(resourceMetrics, resource)
+		resourceMetrics = protowire.AppendTag(resourceMetrics, resourceMetricsScopeMetrics, protowire.BytesType)
+		resourceMetrics = protowire.AppendBytes(resourceMetrics, scopeMetrics)
+
+		var request []byte
+
+		request = protowire.AppendTag(request, exportRequestResourceMetrics, protowire.BytesType)
+		request = protowire.AppendBytes(request, resourceMetrics)
+
+		return request, nil
+	}
+}
+
+// resourceOf returns the resource attributes. host.name falls back to the hostname of the process,
+// if the os collector is not enabled.
+func resourceOf(metricFamilies []*dto.MetricFamily) []attribute {
+	attributes := []attribute{
+		{"service.name", "windows_exporter"},
+		{"service.version", version.Version},
+		{"os.type", "windows"},
+	}
+
+	var hostname string
+
+	for _, mf := range metricFamilies {
+		if len(mf.GetMetric()) == 0 {
+			continue
+		}
+
+		labels := make(map[string]string)
+		for _, lp := range mf.GetMetric()[0].GetLabel() {
+			labels[lp.GetName()] = lp.GetValue()
+		}
+
+		switch mf.GetName() {
+		case osHostnameMetric:
+			hostname = labels["hostname"]
+		case osInfoMetric:
+			for _, attr := range []attribute{
+				{"os.name", labels["product"]},
+				{"os.version", labels["version"]},
+				{"os.build_id", labels["build_number"]},
+			} {
+				if attr.value != "" {
+					attributes = append(attributes, attr)
+				}
+			}
+		}
+	}
+
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+
+	if hostname != "" {
+		attributes = append(attributes, attribute{"host.name", hostname})
+	}
+
+	return attributes
+}
+
+func encodeMetric(mf *dto.MetricFamily, startTimeNano uint64, timestamp time.Time) []byte {
+	var metric []byte
+
+	metric = protowire.AppendTag(metric, metricName, protowire.BytesType)
+	metric = protowire.AppendString(metric, mf.GetName())
+	metric = protowire.AppendTag(metric, metricDescription, protowire.BytesType)
+	metric = protowire.AppendString(metric, mf.GetHelp())
+
+	if mf.GetUnit() != "" {
+		metric = protowire.AppendTag(metric, metricUnit, protowire.BytesType)
+		metric = protowire.AppendString(metric, mf.GetUnit())
+	}
+
+	var (
+		data      []byte
+		dataField protowire.Number
+	)
+
+	for _, m := range mf.GetMetric() {
+		timeNano := uint64(timestamp.UnixNano())
+		if m.TimestampMs != nil {
+			timeNano = uint64(time.UnixMilli(m.GetTimestampMs()).UnixNano())
+		}
+
+		switch mf.GetType() {
+		case dto.MetricType_COUNTER:
+			dataField = metricSum
+			data = protowire.AppendTag(data, sumDataPoints, protowire.BytesType)
+			data = protowire.AppendBytes(data, encodeNumberDataPoint(m, m.GetCounter().GetValue(), startTimeNano, timeNano))
+		case dto.MetricType_GAUGE:
+			dataField = metricGauge
+			data = protowire.AppendTag(data, gaugeDataPoints, protowire.BytesType)
+			data = protowire.AppendBytes(data, encodeNumberDataPoint(m, m.GetGauge().GetValue(), 0, timeNano))
+		case dto.MetricType_UNTYPED:
+			dataField = metricGauge
+			data = protowire.AppendTag(data, gaugeDataPoints, protowire.BytesType)
+			data = protowire.AppendBytes(data, encodeNumberDataPoint(m, m.GetUntyped().GetValue(), 0, timeNano))
+		case dto.MetricType_SUMMARY:
+			dataField = metricSummary
+			data = protowire.AppendTag(data, summaryDataPoints, protowire.BytesType)
+			data = protowire.AppendBytes(data, encodeSummaryDataPoint(m, startTimeNano, timeNano))
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			dataField = metricHistogram
+			data = protowire.AppendTag(data, histogramDataPoints, protowire.BytesType)
+			data = protowire.AppendBytes(data, encodeHistogramDataPoint(m, startTimeNano, timeNano))
+		}
+	}
+
+	switch dataField {
+	case metricSum:
+		data = protowire.AppendTag(data, sumAggregationTemporality, protowire.VarintType)
+		data = protowire.AppendVarint(data, aggregationTemporalityCumulative)
+		data = protowire.AppendTag(data, sumIsMonotonic, protowire.VarintType)
+		data = protowire.AppendVarint(data, protowire.EncodeBool(true))
+	case metricHistogram:
+		data = protowire.AppendTag(data, histogramAggregationTemporality, protowire.VarintType)
+		data = protowire.AppendVarint(data, aggregationTemporalityCumulative)
+	case 0:
+		// A metric family without metrics has no data.
+		return metric
+	}
+
+	metric = protowire.AppendTag(metric, dataField, protowire.BytesType)
+
+	return protowire.AppendBytes(metric, data)
+}
+
+func encodeNumberDataPoint(m *dto.Metric, value float64, startTimeNano, timeNano uint64) []byte {
+	var point []byte
+
+	if startTimeNano != 0 {
+		point = protowire.AppendTag(point, numberDataPointStartTime, protowire.Fixed64Type)
+		point = protowire.AppendFixed64(point, startTimeNano)
+	}
+
+	point = protowire.AppendTag(point, numberDataPointTime, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, timeNano)
+	point = protowire.AppendTag(point, numberDataPointAsDouble, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, math.Float64bits(value))
+
+	return appendLabels(point, numberDataPointAttributes, m)
+}
+
+func encodeSummaryDataPoint(m *dto.Metric, startTimeNano, timeNano uint64) []byte {
+	summary := m.GetSummary()
+
+	var point []byte
+
+	point = protowire.AppendTag(point, summaryDataPointStartTime, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, startTimeNano)
+	point = protowire.AppendTag(point, summaryDataPointTime, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, timeNano)
+	point = protowire.AppendTag(point, summaryDataPointCount, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, summary.GetSampleCount())
+	point = protowire.AppendTag(point, summaryDataPointSum, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, math.Float64bits(summary.GetSampleSum()))
+
+	for _, q := range summary.GetQuantile() {
+		var quantile []byte
+
+		quantile = protowire.AppendTag(quantile, valueAtQuantileQuantile, protowire.Fixed64Type)
+		quantile = protowire.AppendFixed64(quantile, math.Float64bits(q.GetQuantile()))
+		quantile = protowire.AppendTag(quantile, valueAtQuantileValue, protowire.Fixed64Type)
+		quantile = protowire.AppendFixed64(quantile, math.Float64bits(q.GetValue()))
+
+		point = protowire.AppendTag(point, summaryDataPointQuantileValues, protowire.BytesType)
+		point = protowire.AppendBytes(point, quantile)
+	}
+
+	return appendLabels(point, summaryDataPointAttributes, m)
+}
+
+// encodeHistogramDataPoint converts the cumulative buckets of Prometheus into the bucket counts of OTLP.
+// OTLP has no +Inf bound, the last bucket count holds all observations above the highest bound.
+func encodeHistogramDataPoint(m *dto.Metric, startTimeNano, timeNano uint64) []byte {
+	histogram := m.GetHistogram()
+
+	var (
+		bounds   []byte
+		counts   []byte
+		previous uint64
+	)
+
+	for _, b := range histogram.GetBucket() {
+		if math.IsInf(b.GetUpperBound(), +1) {
+			continue
+		}
+
+		bounds = protowire.AppendFixed64(bounds, math.Float64bits(b.GetUpperBound()))
+		counts = protowire.AppendFixed64(counts, b.GetCumulativeCount()-previous)
+		previous = b.GetCumulativeCount()
+	}
+
+	counts = protowire.AppendFixed64(counts, histogram.GetSampleCount()-previous)
+
+	var point []byte
+
+	point = protowire.AppendTag(point, histogramDataPointStartTime, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, startTimeNano)
+	point = protowire.AppendTag(point, histogramDataPointTime, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, timeNano)
+	point = protowire.AppendTag(point, histogramDataPointCount, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, histogram.GetSampleCount())
+	point = protowire.AppendTag(point, histogramDataPointSum, protowire.Fixed64Type)
+	point = protowire.AppendFixed64(point, math.Float64bits(histogram.GetSampleSum()))
+	point = protowire.AppendTag(point, histogramDataPointBucketCounts, protowire.BytesType)
+	point = protowire.AppendBytes(point, counts)
+
+	if len(bounds) > 0 {
+		point = protowire.AppendTag(point, histogramDataPointExplicitBounds, protowire.BytesType)
+		point = protowire.AppendBytes(point, bounds)
+	}
+
+	return appendLabels(point, histogramDataPointAttributes, m)
+}
+
+// appendLabels appends the labels of the metric as attributes.
+func appendLabels(b []byte, field protowire.Number, m *dto.Metric) []byte {
+	for _, lp := range m.GetLabel() {
+		b = appendAttribute(b, field, attribute{lp.GetName(), lp.GetValue()})
+	}
+
+	return b
+}
+
+func appendAttribute(b []byte, field protowire.Number, attr attribute) []byte {
+	var value []byte
+
+	value = protowire.AppendTag(value, anyValueString, protowire.BytesType)
+	value = protowire.AppendString(value, attr.value)
+
+	var keyValue []byte
+
+	keyValue = protowire.AppendTag(keyValue, keyValueKey, protowire.BytesType)
+	keyValue = protowire.AppendString(keyValue, attr.key)
+	keyValue = protowire.AppendTag(keyValue, keyValueValue, protowire.BytesType)
+	keyValue = protowire.AppendBytes(keyValue, value)
+
+	b = protowire.AppendTag(b, field, protowire.BytesType)
+
+	return protowire.AppendBytes(b, keyValue)
+}
@@ -0,0 +1,361 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package otlp_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	"github.com/prometheus-community/windows_exporter/internal/push/otlp"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// export is a decoded ExportMetricsServiceRequest.
+type export struct {
+	resource map[string]string
+	// metrics are formatted as name type{attributes} value.
+	metrics []string
+}
+
+// receiver is a stand-in for an OTLP receiver. It fails the first requests with UNAVAILABLE.
+type receiver struct {
+	mu       sync.Mutex
+	failures int
+	exports  []export
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	grpc := req.Header.Get("Content-Type") == "application/grpc"
+
+	if r.failures > 0 {
+		r.failures--
+
+		if grpc {
+			w.Header().Set("Grpc-Status", "14")
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		return
+	}
+
+	var body []byte
+
+	if grpc {
+		message, _ := io.ReadAll(req.Body)
+		body = message[5 : 5+binary.BigEndian.Uint32(message[1:5])]
+
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.Header().Set("Content-Type", "application/grpc")
+		_, _ = w.Write([]byte{0, 0, 0, 0, 0})
+		w.Header().Set("Grpc-Status", "0")
+	} else {
+		gzipReader, err := gzip.NewReader(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		body, _ = io.ReadAll(gzipReader)
+	}
+
+	r.exports = append(r.exports, decodeExportRequest(body))
+}
+
+func (r *receiver) export() (export, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.exports) == 0 {
+		return export{}, false
+	}
+
+	return r.exports[0], true
+}
+
+func decodeExportRequest(b []byte) export {
+	result := export{resource: make(map[string]string)}
+
+	forEachField(b, func(_ protowire.Number, resourceMetrics []byte) {
+		forEachField(resourceMetrics, func(num protowire.Number, v []byte) {
+			switch num {
+			case 1:
+				forEachField(v, func(_ protowire.Number, attr []byte) {
+					key, value := decodeAttribute(attr)
+					result.resource[key] = value
+				})
+			case 2:
+				forEachField(v, func(num protowire.Number, metric []byte) {
+					if num == 2 {
+						result.metrics = append(result.metrics, decodeMetric(metric)...)
+					}
+				})
+			}
+		})
+	})
+
+	sort.Strings(result.metrics)
+
+	return result
+}
+
+func decodeMetric(b []byte) []string {
+	var (
+		name    string
+		metrics []string
+	)
+
+	forEachField(b, func(num protowire.Number, v []byte) {
+		var kind string
+
+		switch num {
+		case 1:
+			name = string(v)
+
+			return
+		case 5:
+			kind = "gauge"
+		case 7:
+			kind = "sum"
+		case 9:
+			kind = "histogram"
+		default:
+			return
+		}
+
+		var points []string
+
+		// The temporality is encoded after the data points.
+		forEachField(v, func(num protowire.Number, v []byte) {
+			switch {
+			case num == 1:
+				points = append(points, decodeDataPoint(v, kind))
+			case num == 2 && v[0] == 2:
+				kind += " cumulative"
+			case num == 3 && v[0] == 1:
+				kind += " monotonic"
+			}
+		})
+
+		for _, point := range points {
+			metrics = append(metrics, name+" "+kind+point)
+		}
+	})
+
+	return metrics
+}
+
+// decodeDataPoint returns the attributes and the value of a number data point,
+// or the attributes and the bucket counts of a histogram data point.
+func decodeDataPoint(b []byte, kind string) string {
+	var (
+		attributes []string
+		value      string
+	)
+
+	forEachField(b, func(num protowire.Number, v []byte) {
+		switch {
+		case (num == 7 && kind != "histogram") || num == 9:
+			key, val := decodeAttribute(v)
+			attributes = append(attributes, key+"="+val)
+		case num == 4 && kind != "histogram":
+			value = strconv.FormatFloat(math.Float64frombits(binary.LittleEndian.Uint64(v)), 'g', -1, 64)
+		case num == 6:
+			var counts []string
+			for i := 0; i < len(v); i += 8 {
+				counts = append(counts, strconv.FormatUint(binary.LittleEndian.Uint64(v[i:]), 10))
+			}
+
+			value = "[" + strings.Join(counts, ",") + "]"
+		}
+	})
+
+	return "{" + strings.Join(attributes, ",") + "} " + value
+}
+
+func decodeAttribute(b []byte) (string, string) {
+	var key, value string
+
+	forEachField(b, func(num protowire.Number, v []byte) {
+		if num == 1 {
+			key = string(v)
+
+			return
+		}
+
+		forEachField(v, func(_ protowire.Number, v []byte) {
+			value = string(v)
+		})
+	})
+
+	return key, value
+}
+
+// forEachField calls fn for each field. For fixed64 fields, v holds the raw 8 bytes.
+func forEachField(b []byte, fn func(num protowire.Number, v []byte)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		b = b[n:]
+
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			fn(num, v)
+			b = b[n:]
+		case protowire.Fixed64Type:
+			fn(num, b[:8])
+			b = b[8:]
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			fn(num, []byte{byte(v)})
+			b = b[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			b = b[n:]
+		}
+	}
+}
+
+func label(name, value string) *dto.LabelPair {
+	return &dto.LabelPair{Name: proto.String(name), Value: proto.String(value)}
+}
+
+func TestPushOTLP(t *testing.T) {
+	t.Parallel()
+
+	metricFamilies := []*dto.MetricFamily{
+		{
+			Name:   proto.String("windows_cpu_time_total"),
+			Help:   proto.String("Time that processor spent in different modes"),
+			Type:   dto.MetricType_COUNTER.Enum(),
+			Metric: []*dto.Metric{{Label: []*dto.LabelPair{label("mode", "idle")}, Counter: &dto.Counter{Value: proto.Float64(42.5)}}},
+		},
+		{
+			Name: proto.String("windows_exporter_collector_duration_seconds"),
+			Help: proto.String("Duration of a collection."),
+			Type: dto.MetricType_HISTOGRAM.Enum(),
+			Metric: []*dto.Metric{{Histogram: &dto.Histogram{
+				SampleCount: proto.Uint64(5),
+				SampleSum:   proto.Float64(1.5),
+				Bucket: []*dto.Bucket{
+					{UpperBound: proto.Float64(0.5), CumulativeCount: proto.Uint64(2)},
+					{UpperBound: proto.Float64(1.0), CumulativeCount: proto.Uint64(3)},
+				},
+			}}},
+		},
+		{
+			Name:   proto.String("windows_os_hostname"),
+			Help:   proto.String("Labelled system hostname information."),
+			Type:   dto.MetricType_GAUGE.Enum(),
+			Metric: []*dto.Metric{{Label: []*dto.LabelPair{label("domain", "example.com"), label("fqdn", "host1.example.com"), label("hostname", "host1")}, Gauge: &dto.Gauge{Value: proto.Float64(1)}}},
+		},
+		{
+			Name:   proto.String("windows_os_info"),
+			Help:   proto.String("Contains full product name & version in labels."),
+			Type:   dto.MetricType_GAUGE.Enum(),
+			Metric: []*dto.Metric{{Label: []*dto.LabelPair{label("build_number", "20348"), label("product", "Microsoft Windows Server 2022 Standard"), label("version", "10.0.20348")}, Gauge: &dto.Gauge{Value: proto.Float64(1)}}},
+		},
+	}
+
+	gather := func(time.Duration) ([]*dto.MetricFamily, error) {
+		return metricFamilies, nil
+	}
+
+	for _, protocol := range []string{"http", "grpc"} {
+		t.Run(protocol, func(t *testing.T) {
+			t.Parallel()
+
+			recv := &receiver{failures: 2}
+			server := httptest.NewUnstartedServer(recv)
+			server.Config.Protocols = &http.Protocols{}
+			server.Config.Protocols.SetHTTP1(true)
+			server.Config.Protocols.SetUnencryptedHTTP2(true)
+			server.Start()
+			t.Cleanup(server.Close)
+
+			var client push.Client
+
+			if protocol == "grpc" {
+				grpcClient, err := otlp.NewGRPCClient(server.URL, push.HTTPAuth{}, time.Second)
+				require.NoError(t, err)
+
+				client = grpcClient
+			} else {
+				httpClient, err := push.NewHTTPClient("test", push.HTTPAuth{}, time.Second)
+				require.NoError(t, err)
+
+				client = otlp.NewHTTPClient(server.URL+"/v1/metrics", httpClient)
+			}
+
+			pusher := push.New(slog.New(slog.DiscardHandler), "otlp", gather, otlp.NewEncoder(time.Now()),
+				client, push.NewMemoryQueue(10),
+				push.Options{Interval: time.Hour, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond},
+			)
+
+			ctx, cancel := context.WithCancel(t.Context())
+			done := make(chan struct{})
+
+			go func() {
+				defer close(done)
+
+				pusher.Run(ctx)
+			}()
+
+			require.Eventually(t, func() bool {
+				_, ok := recv.export()
+
+				return ok
+			}, 5*time.Second, 10*time.Millisecond)
+
+			cancel()
+			<-done
+
+			received, _ := recv.export()
+
+			require.Equal(t, "host1", received.resource["host.name"])
+			require.Equal(t, "10.0.20348", received.resource["os.version"])
+			require.Equal(t, []string{
+				"windows_cpu_time_total sum cumulative monotonic{mode=idle} 42.5",
+				"windows_exporter_collector_duration_seconds histogram cumulative{} [2,1,2]",
+				"windows_os_hostname gauge{domain=example.com,fqdn=host1.example.com,hostname=host1} 1",
+				"windows_os_info gauge{build_number=20348,product=Microsoft Windows Server 2022 Standard,version=10.0.20348} 1",
+			}, received.metrics)
+		})
+	}
+}
@@ -0,0 +1,423 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package push
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Interface guard.
+var _ prometheus.Collector = (*Pusher)(nil)
+
+// GatherFunc gathers the metrics to push. The collection is limited to the given timeout.
+type GatherFunc func(timeout time.Duration) ([]*dto.MetricFamily, error)
+
+// Encoder encodes the gathered metric families into a payload for the [Client].
+// Samples without a timestamp get the given timestamp.
+type Encoder func(metricFamilies []*dto.MetricFamily, timestamp time.Time) ([]byte, error)
+
+// Client sends payloads to the endpoint.
+type Client interface {
+	// Send sends the payload. If the error is a [RecoverableError], sending the payload is retried.
+	Send(ctx context.Context, payload []byte) error
+}
+
+// RecoverableError is returned by a [Client], if the payload can be sent again later,
+// e.g., on network errors or if the endpoint is overloaded.
+type RecoverableError struct {
+	Err error
+}
+
+func (e RecoverableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e RecoverableError) Unwrap() error {
+	return e.Err
+}
+
+// Options are the options of a [Pusher].
+type Options struct {
+	// Interval is the interval the metrics are gathered and pushed in.
+	Interval time.Duration
+	// MinBackoff is the delay before the first retry of a failed push.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between two retries of a failed push.
+	MaxBackoff time.Duration
+}
+
+// Pusher gathers the metrics in an interval and pushes them with a [Client].
+// Payloads are buffered in a [Queue] until they are sent successfully, so they are pushed in order.
+type Pusher struct {
+	logger  *slog.Logger
+	name    string
+	gather  GatherFunc
+	encode  Encoder
+	client  Client
+	queue   Queue
+	options Options
+
+	// notify wakes up the sender, once a payload is queued.
+	notify chan struct{}
+
+	sentTotal            atomic.Uint64
+	failedAttemptsTotal  atomic.Uint64
+	droppedMu            sync.Mutex
+	droppedTotal         map[string]uint64
+	lastSuccessTimestamp atomic.Int64
+
+	queueLengthDesc          *prometheus.Desc
+	queueSizeDesc            *prometheus.Desc
+	sentTotalDesc            *prometheus.Desc
+	failedAttemptsTotalDesc  *prometheus.Desc
+	droppedTotalDesc         *prometheus.Desc
+	lastSuccessTimestampDesc *prometheus.Desc
+}
+
+const (
+	// dropReasonQueueFull counts payloads dropped, because the queue was full.
+	dropReasonQueueFull = "queue_full"
+	// dropReasonRejected counts payloads dropped, because the endpoint rejected them.
+	dropReasonRejected = "rejected"
+	// dropReasonEncoding counts payloads dropped, because they could not be encoded or queued.
+	dropReasonEncoding = "encoding"
+	// dropReasonExpired counts payloads dropped, because they exceeded the maximum age of an [ExpiringQueue].
+	dropReasonExpired = "expired"
+	// dropReasonCorrupted counts payloads dropped, because the queue couldn't read them, see [ErrCorruptedPayload].
+	dropReasonCorrupted = "corrupted"
+)
+
+// New returns a new Pusher. The name identifies the pusher in logs and metrics, e.g., remote_write.
+func New(logger *slog.Logger, name string, gather GatherFunc, encode Encoder, client Client, queue Queue, options Options) *Pusher {
+	if options.MinBackoff <= 0 {
+		options.MinBackoff = time.Second
+	}
+
+	if options.MaxBackoff < options.MinBackoff {
+		options.MaxBackoff = max(time.Minute, options.MinBackoff)
+	}
+
+	constLabels := prometheus.Labels{"exporter": name}
+
+	return &Pusher{
+		logger:       logger.With(slog.String("exporter", name)),
+		name:         name,
+		gather:       gather,
+		encode:       encode,
+		client:       client,
+		queue:        queue,
+		options:      options,
+		notify:       make(chan struct{}, 1),
+		droppedTotal: make(map[string]uint64),
+		queueLengthDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "push_queue_length"),
+			"windows_exporter: Number of payloads waiting to be pushed.",
+			nil,
+			constLabels,
+		),
+		queueSizeDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "push_queue_size_bytes"),
+			"windows_exporter: Size of the payloads waiting to be pushed.",
+			nil,
+			constLabels,
+		),
+		sentTotalDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "push_sent_payloads_total"),
+			"windows_exporter: Number of payloads pushed successfully.",
+			nil,
+			constLabels,
+		),
+		failedAttemptsTotalDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "push_failed_attempts_total"),
+			"windows_exporter: Number of failed attempts to push a payload.",
+			nil,
+			constLabels,
+		),
+		droppedTotalDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "push_dropped_payloads_total"),
+			"windows_exporter: Number of payloads dropped without being pushed.",
+			[]string{"reason"},
+			constLabels,
+		),
+		lastSuccessTimestampDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "push_last_success_timestamp_seconds"),
+			"windows_exporter: Timestamp of the last successful push.",
+			nil,
+			constLabels,
+		),
+	}
+}
+
+// Run gathers and pushes the metrics until the context is canceled.
+func (p *Pusher) Run(ctx context.Context) {
+	p.logger.LogAttrs(ctx, slog.LevelInfo, "starting to push metrics",
+		slog.Duration("interval", p.options.Interval),
+	)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		p.send(ctx)
+	}()
+
+	ticker := time.NewTicker(p.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.enqueue(ctx)
+
+		select {
+		case <-ctx.Done():
+			<-done
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// enqueue gathers the metrics once and queues the payload.
+func (p *Pusher) enqueue(ctx context.Context) {
+	timestamp := time.Now()
+
+	// Gather returns the metrics that could be gathered, even on error.
+	metricFamilies, err := p.gather(p.options.Interval)
+	if err != nil {
+		p.logger.LogAttrs(ctx, slog.LevelWarn, "error gathering metrics to push",
+			slog.Any("err", err),
+		)
+	}
+
+	payload, err := p.encode(metricFamilies, timestamp)
+	if err != nil {
+		p.logger.LogAttrs(ctx, slog.LevelError, "failed to encode metrics",
+			slog.Any("err", err),
+		)
+		p.addDropped(dropReasonEncoding, 1)
+
+		return
+	}
+
+	if queue, ok := p.queue.(ExpiringQueue); ok {
+		expired, err := queue.Expire()
+		if err != nil {
+			p.logger.LogAttrs(ctx, slog.LevelError, "failed to drop expired payloads",
+				slog.Any("err", err),
+			)
+		}
+
+		if expired > 0 {
+			p.logger.LogAttrs(ctx, slog.LevelWarn, "dropped payloads that exceeded the maximum age",
+				slog.Int("dropped", expired),
+			)
+			p.addDropped(dropReasonExpired, expired)
+		}
+	}
+
+	dropped, err := p.queue.Push(payload)
+	if err != nil {
+		p.logger.LogAttrs(ctx, slog.LevelError, "failed to queue metrics",
+			slog.Any("err", err),
+		)
+		p.addDropped(dropReasonEncoding, 1)
+
+		return
+	}
+
+	if dropped > 0 {
+		p.logger.LogAttrs(ctx, slog.LevelWarn, "push queue is full, dropped the oldest payloads",
+			slog.Int("dropped", dropped),
+		)
+		p.addDropped(dropReasonQueueFull, dropped)
+	}
+
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+// send sends the queued payloads in order until the context is canceled.
+// Recoverable errors are retried with exponential backoff.
+func (p *Pusher) send(ctx context.Context) {
+	backoff := p.options.MinBackoff
+
+	for {
+		payload, ok, err := p.queue.Peek()
+
+		switch {
+		case errors.Is(err, ErrCorruptedPayload):
+			p.logger.LogAttrs(ctx, slog.LevelError, "queued metrics are corrupted, dropping the payload",
+				slog.Any("err", err),
+			)
+			p.pop(ctx)
+			p.addDropped(dropReasonCorrupted, 1)
+
+			continue
+		case err != nil:
+			// Reading the payload may fail temporarily, e.g., while a virus scanner holds the file.
+			p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to read queued metrics, retrying",
+				slog.Any("err", err),
+				slog.Duration("backoff", backoff),
+			)
+
+			if !sleep(ctx, backoff) {
+				return
+			}
+
+			backoff = min(2*backoff, p.options.MaxBackoff)
+
+			continue
+		}
+
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.notify:
+				continue
+			}
+		}
+
+		err = p.client.Send(ctx, payload)
+
+		var recoverableErr RecoverableError
+
+		switch {
+		case err == nil:
+			backoff = p.options.MinBackoff
+
+			p.pop(ctx)
+			p.sentTotal.Add(1)
+			p.lastSuccessTimestamp.Store(time.Now().Unix())
+
+			continue
+		case errors.As(err, &recoverableErr):
+			p.failedAttemptsTotal.Add(1)
+
+			p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to push metrics, retrying",
+				slog.Any("err", err),
+				slog.Duration("backoff", backoff),
+			)
+		default:
+			p.failedAttemptsTotal.Add(1)
+
+			p.logger.LogAttrs(ctx, slog.LevelError, "failed to push metrics, dropping the payload",
+				slog.Any("err", err),
+			)
+			p.pop(ctx)
+			p.addDropped(dropReasonRejected, 1)
+
+			continue
+		}
+
+		if !sleep(ctx, backoff) {
+			return
+		}
+
+		backoff = min(2*backoff, p.options.MaxBackoff)
+	}
+}
+
+// sleep waits for the duration. It returns false, if the context was canceled before.
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+func (p *Pusher) pop(ctx context.Context) {
+	if err := p.queue.Pop(); err != nil {
+		p.logger.LogAttrs(ctx, slog.LevelError, "failed to remove payload from the queue",
+			slog.Any("err", err),
+		)
+	}
+}
+
+func (p *Pusher) addDropped(reason string, count int) {
+	p.droppedMu.Lock()
+	defer p.droppedMu.Unlock()
+
+	p.droppedTotal[reason] += uint64(count)
+}
+
+func (p *Pusher) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.queueLengthDesc
+	ch <- p.queueSizeDesc
+	ch <- p.sentTotalDesc
+	ch <- p.failedAttemptsTotalDesc
+	ch <- p.droppedTotalDesc
+	ch <- p.lastSuccessTimestampDesc
+}
+
+func (p *Pusher) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		p.queueLengthDesc,
+		prometheus.GaugeValue,
+		float64(p.queue.Len()),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		p.queueSizeDesc,
+		prometheus.GaugeValue,
+		float64(p.queue.Size()),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		p.sentTotalDesc,
+		prometheus.CounterValue,
+		float64(p.sentTotal.Load()),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		p.failedAttemptsTotalDesc,
+		prometheus.CounterValue,
+		float64(p.failedAttemptsTotal.Load()),
+	)
+
+	p.droppedMu.Lock()
+	for _, reason := range []string{dropReasonQueueFull, dropReasonRejected, dropReasonEncoding, dropReasonExpired, dropReasonCorrupted} {
+		ch <- prometheus.MustNewConstMetric(
+			p.droppedTotalDesc,
+			prometheus.CounterValue,
+			float64(p.droppedTotal[reason]),
+			reason,
+		)
+	}
+	p.droppedMu.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(
+		p.lastSuccessTimestampDesc,
+		prometheus.GaugeValue,
+		float64(p.lastSuccessTimestamp.Load()),
+	)
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package push
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrCorruptedPayload is wrapped by the errors of [Queue.Peek], if the oldest payload can never be read,
+// e.g., because its file is truncated. The payload is dropped. Other errors of Peek are retried.
+var ErrCorruptedPayload = errors.New("corrupted payload")
+
+// Queue buffers the payloads of a [Pusher] until they are sent. It must be safe for concurrent use.
+type Queue interface {
+	// Push appends the payload. If the queue is full, the oldest payloads are dropped
+	// and their number is returned.
+	Push(payload []byte) (dropped int, err error)
+	// Peek returns the oldest payload without removing it. ok is false, if the queue is empty.
+	// If the payload is corrupted, the error wraps [ErrCorruptedPayload].
+	Peek() (payload []byte, ok bool, err error)
+	// Pop removes the payload returned by the last call of Peek.
+	// If that payload was dropped in the meantime, because the queue was full, Pop does nothing.
+	Pop() error
+	// Len returns the number of queued payloads.
+	Len() int
+	// Size returns the size of the queued payloads in bytes.
+	Size() int64
+}
+
+// ExpiringQueue is a [Queue] that drops payloads older than a maximum age.
+type ExpiringQueue interface {
+	Queue
+	// Expire drops the payloads older than the maximum age and returns their number.
+	Expire() (expired int, err error)
+}
+
+// Interface guard.
+var _ Queue = (*MemoryQueue)(nil)
+
+// MemoryQueue is a bounded in-memory [Queue].
+type MemoryQueue struct {
+	mu       sync.Mutex
+	capacity int
+	payloads [][]byte
+	size     int64
+	// head is the sequence number of the first payload, peeked the sequence number of the last peeked payload.
+	head   uint64
+	peeked uint64
+}
+
+// NewMemoryQueue returns a MemoryQueue that holds up to capacity payloads.
+func NewMemoryQueue(capacity int) *MemoryQueue {
+	return &MemoryQueue{capacity: max(capacity, 1)}
+}
+
+func (q *MemoryQueue) Push(payload []byte) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var dropped int
+
+	if len(q.payloads) >= q.capacity {
+		dropped = len(q.payloads) - q.capacity + 1
+
+		for _, p := range q.payloads[:dropped] {
+			q.size -= int64(len(p))
+		}
+
+		clear(q.payloads[:dropped])
+		q.payloads = q.payloads[dropped:]
+		q.head += uint64(dropped)
+	}
+
+	q.payloads = append(q.payloads, payload)
+	q.size += int64(len(payload))
+
+	return dropped, nil
+}
+
+func (q *MemoryQueue) Peek() ([]byte, bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.payloads) == 0 {
+		return nil, false, nil
+	}
+
+	q.peeked = q.head
+
+	return q.payloads[0], true, nil
+}
+
+func (q *MemoryQueue) Pop() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.payloads) > 0 && q.head == q.peeked {
+		q.size -= int64(len(q.payloads[0]))
+		q.payloads[0] = nil
+		q.payloads = q.payloads[1:]
+		q.head++
+	}
+
+	return nil
+}
+
+func (q *MemoryQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.payloads)
+}
+
+func (q *MemoryQueue) Size() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.size
+}
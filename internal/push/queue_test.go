@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package push_test
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryQueueDropsOldest(t *testing.T) {
+	t.Parallel()
+
+	queue := push.NewMemoryQueue(2)
+
+	for _, payload := range []string{"a", "b"} {
+		dropped, err := queue.Push([]byte(payload))
+		require.NoError(t, err)
+		require.Zero(t, dropped)
+	}
+
+	payload, ok, err := queue.Peek()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "a", string(payload))
+
+	// The peeked payload is dropped, while it is sent.
+	dropped, err := queue.Push([]byte("c"))
+	require.NoError(t, err)
+	require.Equal(t, 1, dropped)
+
+	// Pop must not remove b, which was not sent yet.
+	require.NoError(t, queue.Pop())
+	require.Equal(t, 2, queue.Len())
+
+	for _, expected := range []string{"b", "c"} {
+		payload, ok, err = queue.Peek()
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expected, string(payload))
+		require.NoError(t, queue.Pop())
+	}
+
+	_, ok, err = queue.Peek()
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	"github.com/prometheus/common/version"
+)
+
+// Interface guard.
+var _ push.Client = (*Client)(nil)
+
+// maxErrorBodySize is the maximum number of bytes of a response body included in an error.
+const maxErrorBodySize = 512
+
+// Client sends remote write requests encoded by [Encode] to a remote write receiver.
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient returns a new Client for the remote write URL.
+func NewClient(url string, httpClient *http.Client) *Client {
+	return &Client{url: url, httpClient: httpClient}
+}
+
+// Send sends the request. Network errors, server errors and 429 Too Many Requests are recoverable.
+func (c *Client) Send(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "windows_exporter/"+version.Version)
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return push.RecoverableError{Err: fmt.Errorf("failed to send remote write request: %w", err)}
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("remote write receiver returned HTTP status %s: %s", resp.Status, bytes.TrimSpace(body))
+
+	if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+		return push.RecoverableError{Err: err}
+	}
+
+	return err
+}
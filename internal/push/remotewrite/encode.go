@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package remotewrite
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the remote write 1.0 protobuf messages, see prompb/types.proto and prompb/remote.proto
+// of Prometheus. The messages are encoded by hand to avoid depending on the Prometheus server module.
+const (
+	writeRequestTimeseries = 1
+	writeRequestMetadata   = 3
+
+	timeSeriesLabels  = 1
+	timeSeriesSamples = 2
+
+	labelName  = 1
+	labelValue = 2
+
+	sampleValue     = 1
+	sampleTimestamp = 2
+
+	metadataType             = 1
+	metadataMetricFamilyName = 2
+	metadataHelp             = 4
+	metadataUnit             = 5
+)
+
+// Metric types of the remote write metadata.
+const (
+	metadataTypeUnknown        = 0
+	metadataTypeCounter        = 1
+	metadataTypeGauge          = 2
+	metadataTypeHistogram      = 3
+	metadataTypeGaugeHistogram = 4
+	metadataTypeSummary        = 5
+)
+
+type label struct {
+	name, value string
+}
+
+// Encode encodes the metric families as snappy-compressed remote write 1.0 request.
+// It implements [push.Encoder]. Summaries and classic histograms are split into their series,
+// native histograms are not supported.
+func Encode(metricFamilies []*dto.MetricFamily, timestamp time.Time) ([]byte, error) {
+	var request []byte
+
+	for _, mf := range metricFamilies {
+		for _, metric := range mf.GetMetric() {
+			timestampMs := timestamp.UnixMilli()
+			if metric.TimestampMs != nil {
+				timestampMs = metric.GetTimestampMs()
+			}
+
+			labels := make([]label, 0, len(metric.GetLabel())+1)
+			for _, lp := range metric.GetLabel() {
+				labels = append(labels, label{lp.GetName(), lp.GetValue()})
+			}
+
+			addSeries := func(name string, value float64, extraLabels ...label) {
+				request = appendTimeSeries(request, name, labels, extraLabels, value, timestampMs)
+			}
+
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				addSeries(mf.GetName(), metric.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				addSeries(mf.GetName(), metric.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				addSeries(mf.GetName(), metric.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				summary := metric.GetSummary()
+
+				for _, q := range summary.GetQuantile() {
+					addSeries(mf.GetName(), q.GetValue(), label{model.QuantileLabel, formatFloat(q.GetQuantile())})
+				}
+
+				addSeries(mf.GetName()+"_sum", summary.GetSampleSum())
+				addSeries(mf.GetName()+"_count", float64(summary.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				histogram := metric.GetHistogram()
+				count := float64(histogram.GetSampleCount())
+
+				if mf.GetType() == dto.MetricType_GAUGE_HISTOGRAM {
+					count = histogram.GetSampleCountFloat()
+				}
+
+				hasInf := false
+
+				for _, b := range histogram.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), +1) {
+						hasInf = true
+					}
+
+					bucketCount := float64(b.GetCumulativeCount())
+					if b.CumulativeCountFloat != nil {
+						bucketCount = b.GetCumulativeCountFloat()
+					}
+
+					addSeries(mf.GetName()+"_bucket", bucketCount, label{model.BucketLabel, formatFloat(b.GetUpperBound())})
+				}
+
+				if !hasInf {
+					addSeries(mf.GetName()+"_bucket", count, label{model.BucketLabel, "+Inf"})
+				}
+
+				addSeries(mf.GetName()+"_sum", histogram.GetSampleSum())
+				addSeries(mf.GetName()+"_count", count)
+			}
+		}
+
+		request = appendMetadata(request, mf)
+	}
+
+	return s2.EncodeSnappy(nil, request), nil
+}
+
+func appendTimeSeries(b []byte, name string, labels, extraLabels []label, value float64, timestampMs int64) []byte {
+	all := make([]label, 0, len(labels)+len(extraLabels)+1)
+	all = append(all, label{model.MetricNameLabel, name})
+	all = append(all, labels...)
+	all = append(all, extraLabels...)
+
+	// Remote write requires the labels to be sorted by name.
+	slices.SortFunc(all, func(a, b label) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	var series []byte
+
+	for _, l := range all {
+		var lb []byte
+
+		lb = protowire.AppendTag(lb, labelName, protowire.BytesType)
+		lb = protowire.AppendString(lb, l.name)
+		lb = protowire.AppendTag(lb, labelValue, protowire.BytesType)
+		lb = protowire.AppendString(lb, l.value)
+
+		series = protowire.AppendTag(series, timeSeriesLabels, protowire.BytesType)
+		series = protowire.AppendBytes(series, lb)
+	}
+
+	var sample []byte
+
+	sample = protowire.AppendTag(sample, sampleValue, protowire.Fixed64Type)
+	sample = protowire.AppendFixed64(sample, math.Float64bits(value))
+	sample = protowire.AppendTag(sample, sampleTimestamp, protowire.VarintType)
+	sample = protowire.AppendVarint(sample, uint64(timestampMs))
+
+	series = protowire.AppendTag(series, timeSeriesSamples, protowire.BytesType)
+	series = protowire.AppendBytes(series, sample)
+
+	b = protowire.AppendTag(b, writeRequestTimeseries, protowire.BytesType)
+
+	return protowire.AppendBytes(b, series)
+}
+
+func appendMetadata(b []byte, mf *dto.MetricFamily) []byte {
+	var metadata []byte
+
+	metadata = protowire.AppendTag(metadata, metadataType, protowire.VarintType)
+	metadata = protowire.AppendVarint(metadata, metadataTypeOf(mf.GetType()))
+	metadata = protowire.AppendTag(metadata, metadataMetricFamilyName, protowire.BytesType)
+	metadata = protowire.AppendString(metadata, mf.GetName())
+	metadata = protowire.AppendTag(metadata, metadataHelp, protowire.BytesType)
+	metadata = protowire.AppendString(metadata, mf.GetHelp())
+
+	if mf.GetUnit() != "" {
+		metadata = protowire.AppendTag(metadata, metadataUnit, protowire.BytesType)
+		metadata = protowire.AppendString(metadata, mf.GetUnit())
+	}
+
+	b = protowire.AppendTag(b, writeRequestMetadata, protowire.BytesType)
+
+	return protowire.AppendBytes(b, metadata)
+}
+
+func metadataTypeOf(metricType dto.MetricType) uint64 {
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		return metadataTypeCounter
+	case dto.MetricType_GAUGE:
+		return metadataTypeGauge
+	case dto.MetricType_HISTOGRAM:
+		return metadataTypeHistogram
+	case dto.MetricType_GAUGE_HISTOGRAM:
+		return metadataTypeGaugeHistogram
+	case dto.MetricType_SUMMARY:
+		return metadataTypeSummary
+	default:
+		return metadataTypeUnknown
+	}
+}
+
+// formatFloat formats the value of the le and quantile labels like the Prometheus text format.
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, +1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
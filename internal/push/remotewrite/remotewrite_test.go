@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package remotewrite_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/prometheus-community/windows_exporter/internal/push"
+	"github.com/prometheus-community/windows_exporter/internal/push/remotewrite"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// receiver is a stand-in for a remote write receiver. It fails the first requests with HTTP 503.
+type receiver struct {
+	mu       sync.Mutex
+	failures int
+	username string
+	series   []string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failures > 0 {
+		r.failures--
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		return
+	}
+
+	r.username, _, _ = req.BasicAuth()
+
+	if req.Header.Get("Content-Encoding") != "snappy" || req.Header.Get("X-Prometheus-Remote-Write-Version") != "0.1.0" {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	compressed, _ := io.ReadAll(req.Body)
+
+	body, err := s2.Decode(nil, compressed)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	r.series = append(r.series, decodeWriteRequest(body)...)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeWriteRequest returns the time series of the request formatted as name{labels} value.
+func decodeWriteRequest(b []byte) []string {
+	var series []string
+
+	forEachField(b, func(num protowire.Number, v []byte) {
+		if num != 1 {
+			return
+		}
+
+		var (
+			labels []string
+			value  float64
+		)
+
+		forEachField(v, func(num protowire.Number, v []byte) {
+			switch num {
+			case 1:
+				var name, val string
+
+				forEachField(v, func(num protowire.Number, v []byte) {
+					if num == 1 {
+						name = string(v)
+					} else {
+						val = string(v)
+					}
+				})
+
+				labels = append(labels, name+"="+val)
+			case 2:
+				forEachField(v, func(num protowire.Number, v []byte) {
+					if num == 1 {
+						bits, _ := protowire.ConsumeFixed64(v)
+						value = math.Float64frombits(bits)
+					}
+				})
+			}
+		})
+
+		series = append(series, strings.Join(labels, ",")+" "+strconv.FormatFloat(value, 'g', -1, 64))
+	})
+
+	sort.Strings(series)
+
+	return series
+}
+
+// forEachField calls fn for each field. For fixed64 fields, v holds the raw 8 bytes.
+func forEachField(b []byte, fn func(num protowire.Number, v []byte)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		b = b[n:]
+
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			fn(num, v)
+			b = b[n:]
+		case protowire.Fixed64Type:
+			fn(num, b[:8])
+			b = b[8:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			b = b[n:]
+		}
+	}
+}
+
+func TestPushRemoteWrite(t *testing.T) {
+	t.Parallel()
+
+	recv := &receiver{failures: 2}
+	server := httptest.NewServer(recv)
+	t.Cleanup(server.Close)
+
+	httpClient, err := push.NewHTTPClient("test", push.HTTPAuth{Username: "user", Password: "secret"}, time.Second)
+	require.NoError(t, err)
+
+	metricFamilies := []*dto.MetricFamily{
+		{
+			Name:   proto.String("windows_cpu_time_total"),
+			Help:   proto.String("Time that processor spent in different modes"),
+			Type:   dto.MetricType_COUNTER.Enum(),
+			Metric: []*dto.Metric{{Label: []*dto.LabelPair{{Name: proto.String("mode"), Value: proto.String("idle")}}, Counter: &dto.Counter{Value: proto.Float64(42.5)}}},
+		},
+		{
+			Name: proto.String("windows_exporter_collector_duration_seconds"),
+			Help: proto.String("Duration of a collection."),
+			Type: dto.MetricType_HISTOGRAM.Enum(),
+			Metric: []*dto.Metric{{Histogram: &dto.Histogram{
+				SampleCount: proto.Uint64(3),
+				SampleSum:   proto.Float64(1.5),
+				Bucket:      []*dto.Bucket{{UpperBound: proto.Float64(1.0), CumulativeCount: proto.Uint64(2)}},
+			}}},
+		},
+	}
+
+	gather := func(time.Duration) ([]*dto.MetricFamily, error) {
+		return metricFamilies, nil
+	}
+
+	pusher := push.New(slog.New(slog.DiscardHandler), "remote_write", gather, remotewrite.Encode,
+		remotewrite.NewClient(server.URL, httpClient), push.NewMemoryQueue(10),
+		push.Options{Interval: time.Hour, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond},
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		pusher.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		recv.mu.Lock()
+		defer recv.mu.Unlock()
+
+		return len(recv.series) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+
+	require.Equal(t, "user", recv.username)
+	require.Equal(t, []string{
+		"__name__=windows_cpu_time_total,mode=idle 42.5",
+		"__name__=windows_exporter_collector_duration_seconds_bucket,le=+Inf 3",
+		"__name__=windows_exporter_collector_duration_seconds_bucket,le=1 2",
+		"__name__=windows_exporter_collector_duration_seconds_count 3",
+		"__name__=windows_exporter_collector_duration_seconds_sum 1.5",
+	}, recv.series)
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package relabel
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewGatherer returns a [prometheus.Gatherer] that applies the relabel configs
+// to the metrics gathered by the given gatherer.
+// Metrics without a name after relabeling are dropped. Metrics that collide with an already
+// relabeled metric or a metric family of a different type are dropped and reported as error.
+func NewGatherer(gatherer prometheus.Gatherer, configs []Config) prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		metricFamilies, err := gatherer.Gather()
+
+		relabeled, relabelErr := relabelMetricFamilies(metricFamilies, configs)
+
+		return relabeled, errors.Join(err, relabelErr)
+	})
+}
+
+func relabelMetricFamilies(metricFamilies []*dto.MetricFamily, configs []Config) ([]*dto.MetricFamily, error) {
+	var errs []error
+
+	result := make(map[string]*dto.MetricFamily, len(metricFamilies))
+	seen := make(map[string]struct{})
+
+	for _, mf := range metricFamilies {
+		for _, metric := range mf.GetMetric() {
+			labels := make(map[string]string, len(metric.GetLabel())+1)
+			labels[model.MetricNameLabel] = mf.GetName()
+
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if !Process(labels, configs...) {
+				continue
+			}
+
+			name := labels[model.MetricNameLabel]
+			if name == "" {
+				continue
+			}
+
+			delete(labels, model.MetricNameLabel)
+
+			key := seriesKey(name, labels)
+			if _, ok := seen[key]; ok {
+				errs = append(errs, fmt.Errorf("metric %s was collected before with the same labels after relabeling", key))
+
+				continue
+			}
+
+			target, ok := result[name]
+			if !ok {
+				target = &dto.MetricFamily{
+					Name: proto.String(name),
+					Help: mf.Help,
+					Type: mf.Type,
+					Unit: mf.Unit,
+				}
+				result[name] = target
+			} else if target.GetType() != mf.GetType() {
+				errs = append(errs, fmt.Errorf("metric %s of type %s collides with metric family of type %s after relabeling", key, mf.GetType(), target.GetType()))
+
+				continue
+			}
+
+			seen[key] = struct{}{}
+
+			relabeledMetric := proto.CloneOf(metric)
+			relabeledMetric.Label = make([]*dto.LabelPair, 0, len(labels))
+
+			for _, labelName := range slices.Sorted(maps.Keys(labels)) {
+				relabeledMetric.Label = append(relabeledMetric.Label, &dto.LabelPair{
+					Name:  proto.String(labelName),
+					Value: proto.String(labels[labelName]),
+				})
+			}
+
+			target.Metric = append(target.Metric, relabeledMetric)
+		}
+	}
+
+	metricFamilies = make([]*dto.MetricFamily, 0, len(result))
+	for _, name := range slices.Sorted(maps.Keys(result)) {
+		metricFamilies = append(metricFamilies, result[name])
+	}
+
+	return metricFamilies, errors.Join(errs...)
+}
+
+func seriesKey(name string, labels map[string]string) string {
+	var sb strings.Builder
+
+	sb.WriteString(name)
+	sb.WriteString("{")
+
+	for i, labelName := range slices.Sorted(maps.Keys(labels)) {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+
+		fmt.Fprintf(&sb, "%s=%q", labelName, labels[labelName])
+	}
+
+	sb.WriteString("}")
+
+	return sb.String()
+}
@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package relabel implements the relabeling of metrics before exposition.
+// The configuration and the semantics are compatible with the metric_relabel_configs of Prometheus.
+package relabel
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"go.yaml.in/yaml/v3"
+)
+
+type Action string
+
+const (
+	Replace   Action = "replace"
+	Keep      Action = "keep"
+	Drop      Action = "drop"
+	LabelDrop Action = "labeldrop"
+	LabelKeep Action = "labelkeep"
+)
+
+// Config is a relabeling step.
+type Config struct {
+	SourceLabels []string `yaml:"source_labels,flow"`
+	Separator    string   `yaml:"separator"`
+	Regex        Regexp   `yaml:"regex"`
+	TargetLabel  string   `yaml:"target_label"`
+	Replacement  string   `yaml:"replacement"`
+	Action       Action   `yaml:"action"`
+}
+
+// Regexp is an anchored regular expression.
+type Regexp struct {
+	*regexp.Regexp
+}
+
+//nolint:gochecknoglobals
+var defaultConfig = Config{
+	Separator:   ";",
+	Regex:       MustNewRegexp("(.*)"),
+	Replacement: "$1",
+	Action:      Replace,
+}
+
+// NewRegexp compiles the anchored regular expression.
+func NewRegexp(s string) (Regexp, error) {
+	re, err := regexp.Compile("^(?:" + s + ")$")
+	if err != nil {
+		return Regexp{}, err
+	}
+
+	return Regexp{re}, nil
+}
+
+// MustNewRegexp works like NewRegexp, but panics if the regular expression does not compile.
+func MustNewRegexp(s string) Regexp {
+	re, err := NewRegexp(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return re
+}
+
+// UnmarshalYAML implements [yaml.Unmarshaler].
+func (re *Regexp) UnmarshalYAML(node *yaml.Node) error {
+	var s string
+	if err := node.Decode(&s); err != nil {
+		return err
+	}
+
+	r, err := NewRegexp(s)
+	if err != nil {
+		return fmt.Errorf("line %d: invalid regex %q: %w", node.Line, s, err)
+	}
+
+	*re = r
+
+	return nil
+}
+
+// MarshalYAML implements [yaml.Marshaler].
+func (re Regexp) MarshalYAML() (any, error) {
+	if re.Regexp == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(re.String(), "^(?:"), ")$"), nil
+}
+
+// UnmarshalYAML implements [yaml.Unmarshaler]. Unset fields are set to their defaults.
+func (c *Config) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			switch key := node.Content[i]; key.Value {
+			case "source_labels", "separator", "regex", "target_label", "replacement", "action":
+			default:
+				return fmt.Errorf("line %d: field %s not found in type relabel.Config", key.Line, key.Value)
+			}
+		}
+	}
+
+	*c = defaultConfig
+
+	type plain Config
+
+	if err := node.Decode((*plain)(c)); err != nil {
+		return err
+	}
+
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("line %d: %w", node.Line, err)
+	}
+
+	return nil
+}
+
+// Validate checks the relabel config for consistency.
+func (c *Config) Validate() error {
+	switch c.Action {
+	case Replace:
+		if c.TargetLabel == "" {
+			return errors.New("relabel configuration for replace action requires 'target_label' value")
+		}
+
+		if !strings.Contains(c.TargetLabel, "$") && !model.LabelName(c.TargetLabel).IsValidLegacy() {
+			return fmt.Errorf("%q is invalid 'target_label' for replace action", c.TargetLabel)
+		}
+	case Keep, Drop:
+	case LabelDrop, LabelKeep:
+		if len(c.SourceLabels) != 0 || c.TargetLabel != "" {
+			return fmt.Errorf("%s action requires only 'regex', and no other fields", c.Action)
+		}
+	default:
+		return fmt.Errorf("unknown relabel action %q", c.Action)
+	}
+
+	return nil
+}
+
+// ParseConfigs parses a YAML list of relabel configs.
+func ParseConfigs(s string) ([]Config, error) {
+	var configs []Config
+
+	if strings.TrimSpace(s) == "" {
+		return configs, nil
+	}
+
+	decoder := yaml.NewDecoder(strings.NewReader(s))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&configs); err != nil {
+		return nil, fmt.Errorf("failed to parse relabel configs: %w", err)
+	}
+
+	return configs, nil
+}
+
+// Process applies the relabel configs to the labels in order.
+// The metric name is passed as the label __name__.
+// It returns false, if the metric is dropped.
+func Process(labels map[string]string, configs ...Config) bool {
+	for _, config := range configs {
+		if !relabel(labels, config) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func relabel(labels map[string]string, config Config) bool {
+	values := make([]string, 0, len(config.SourceLabels))
+	for _, name := range config.SourceLabels {
+		values = append(values, labels[name])
+	}
+
+	value := strings.Join(values, config.Separator)
+
+	switch config.Action {
+	case Drop:
+		if config.Regex.MatchString(value) {
+			return false
+		}
+	case Keep:
+		if !config.Regex.MatchString(value) {
+			return false
+		}
+	case Replace:
+		indexes := config.Regex.FindStringSubmatchIndex(value)
+		// If there is no match no replacement must take place.
+		if indexes == nil {
+			break
+		}
+
+		target := string(config.Regex.ExpandString([]byte{}, config.TargetLabel, value, indexes))
+		if !model.LabelName(target).IsValidLegacy() {
+			break
+		}
+
+		replacement := string(config.Regex.ExpandString([]byte{}, config.Replacement, value, indexes))
+		if replacement == "" {
+			delete(labels, target)
+
+			break
+		}
+
+		labels[target] = replacement
+	case LabelDrop:
+		for name := range labels {
+			if config.Regex.MatchString(name) {
+				delete(labels, name)
+			}
+		}
+	case LabelKeep:
+		for name := range labels {
+			if !config.Regex.MatchString(name) {
+				delete(labels, name)
+			}
+		}
+	}
+
+	return true
+}
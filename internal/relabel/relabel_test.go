@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package relabel_test
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/relabel"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		configs  string
+		labels   map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "drop",
+			configs:  `[{source_labels: [__name__, nic], regex: "windows_net_.+;vEthernet.*", action: drop}]`,
+			labels:   map[string]string{"__name__": "windows_net_bytes_total", "nic": "vEthernet (WSL)"},
+			expected: nil,
+		},
+		{
+			name:     "keep",
+			configs:  `[{source_labels: [__name__], regex: "windows_cpu_.+", action: keep}]`,
+			labels:   map[string]string{"__name__": "windows_cpu_time_total", "core": "0,0"},
+			expected: map[string]string{"__name__": "windows_cpu_time_total", "core": "0,0"},
+		},
+		{
+			name:     "keep without source_labels",
+			configs:  `[{action: keep}]`,
+			labels:   map[string]string{"__name__": "windows_cpu_time_total", "core": "0,0"},
+			expected: map[string]string{"__name__": "windows_cpu_time_total", "core": "0,0"},
+		},
+		{
+			name:     "drop without source_labels",
+			configs:  `[{regex: "", action: drop}]`,
+			labels:   map[string]string{"__name__": "windows_cpu_time_total", "core": "0,0"},
+			expected: nil,
+		},
+		{
+			name:     "replace",
+			configs:  `[{source_labels: [core], regex: "(\\d+),(\\d+)", target_label: socket, replacement: "$1"}]`,
+			labels:   map[string]string{"__name__": "windows_cpu_time_total", "core": "1,3"},
+			expected: map[string]string{"__name__": "windows_cpu_time_total", "core": "1,3", "socket": "1"},
+		},
+		{
+			name:     "labeldrop",
+			configs:  `[{regex: "core", action: labeldrop}]`,
+			labels:   map[string]string{"__name__": "windows_cpu_time_total", "core": "1,3", "mode": "idle"},
+			expected: map[string]string{"__name__": "windows_cpu_time_total", "mode": "idle"},
+		},
+		{
+			name:     "labelkeep",
+			configs:  `[{regex: "__name__|mode", action: labelkeep}]`,
+			labels:   map[string]string{"__name__": "windows_cpu_time_total", "core": "1,3", "mode": "idle"},
+			expected: map[string]string{"__name__": "windows_cpu_time_total", "mode": "idle"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			configs, err := relabel.ParseConfigs(tc.configs)
+			require.NoError(t, err)
+
+			if !relabel.Process(tc.labels, configs...) {
+				require.Nil(t, tc.expected)
+
+				return
+			}
+
+			require.Equal(t, tc.expected, tc.labels)
+		})
+	}
+}
+
+func TestParseConfigsInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := relabel.ParseConfigs(`[{action: replace}]`)
+	require.ErrorContains(t, err, "requires 'target_label' value")
+
+	_, err = relabel.ParseConfigs(`[{action: drop, source_lables: [__name__]}]`)
+	require.ErrorContains(t, err, "field source_lables not found")
+}
+
+func TestGatherer(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "windows_test", Help: "test"}, []string{"instance"})
+	gauge.WithLabelValues("a").Set(1)
+	gauge.WithLabelValues("b").Set(2)
+	reg.MustRegister(gauge)
+
+	configs, err := relabel.ParseConfigs(`
+- source_labels: [instance]
+  regex: b
+  action: drop
+- source_labels: [__name__]
+  regex: windows_(.+)
+  target_label: __name__
+  replacement: windows_renamed_$1
+`)
+	require.NoError(t, err)
+
+	metricFamilies, err := relabel.NewGatherer(reg, configs).Gather()
+	require.NoError(t, err)
+	require.Len(t, metricFamilies, 1)
+	require.Equal(t, "windows_renamed_test", metricFamilies[0].GetName())
+	require.Len(t, metricFamilies[0].GetMetric(), 1)
+	require.Equal(t, "a", metricFamilies[0].GetMetric()[0].GetLabel()[0].GetValue())
+}
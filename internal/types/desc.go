@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package types
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Desc is a metric descriptor with its metadata, which [prometheus.Desc] doesn't expose.
+type Desc struct {
+	Desc *prometheus.Desc
+	Name string
+	Help string
+	// Labels are the variable labels followed by the sorted names of the constant labels.
+	Labels []string
+}
+
+// DescRecorder creates the metric descriptors of a collector and records them.
+// A collector embedding DescRecorder describes the metrics created with [DescRecorder.NewDesc].
+// The zero value is ready to use.
+type DescRecorder struct {
+	mu    sync.Mutex
+	descs map[string]Desc
+}
+
+// NewDesc returns [prometheus.NewDesc] and records the descriptor.
+// A descriptor with the same name and constant labels replaces the previous one.
+func (r *DescRecorder) NewDesc(fqName, help string, variableLabels []string, constLabels prometheus.Labels) *prometheus.Desc {
+	desc := prometheus.NewDesc(fqName, help, variableLabels, constLabels)
+
+	constLabelNames := slices.Sorted(maps.Keys(constLabels))
+
+	key := []string{fqName}
+	for _, name := range constLabelNames {
+		key = append(key, name, constLabels[name])
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.descs == nil {
+		r.descs = make(map[string]Desc)
+	}
+
+	r.descs[strings.Join(key, "\xff")] = Desc{
+		Desc:   desc,
+		Name:   fqName,
+		Help:   help,
+		Labels: append(slices.Clone(variableLabels), constLabelNames...),
+	}
+
+	return desc
+}
+
+// ResetDescs discards the recorded descriptors. It is called before each build of the collector.
+func (r *DescRecorder) ResetDescs() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.descs = nil
+}
+
+// Describe sends the recorded descriptors to ch.
+func (r *DescRecorder) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range r.RecordedDescs() {
+		ch <- desc.Desc
+	}
+}
+
+// RecordedDescs returns the recorded descriptors sorted by name.
+func (r *DescRecorder) RecordedDescs() []Desc {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	descs := slices.Collect(maps.Values(r.descs))
+
+	slices.SortFunc(descs, func(a, b Desc) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return descs
+}
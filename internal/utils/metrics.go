@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package utils
+
+import (
+	"reflect"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// DuplicateMetricEntry determines, if any two entries of the given slice of metric families are duplicates.
+// Duplicates will be detected where the metric name, labels and label values are identical.
+func DuplicateMetricEntry(metricFamilies []*dto.MetricFamily) bool {
+	uniqueMetrics := make(map[string]map[string]string)
+
+	for _, metricFamily := range metricFamilies {
+		metricName := metricFamily.GetName()
+
+		for _, metric := range metricFamily.GetMetric() {
+			metricLabels := metric.GetLabel()
+			labels := make(map[string]string)
+
+			for _, label := range metricLabels {
+				labels[label.GetName()] = label.GetValue()
+			}
+			// Check if key is present before appending
+			_, mapContainsKey := uniqueMetrics[metricName]
+
+			// Duplicate metric found with identical labels & label values
+			if mapContainsKey && reflect.DeepEqual(uniqueMetrics[metricName], labels) {
+				return true
+			}
+
+			uniqueMetrics[metricName] = labels
+		}
+	}
+
+	return false
+}
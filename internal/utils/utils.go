@@ -17,6 +17,11 @@
 
 package utils
 
+import (
+	"context"
+	"time"
+)
+
 func MilliSecToSec(t float64) float64 {
 	return t / 1000
 }
@@ -56,3 +61,13 @@ func SplitError(err error) []error {
 
 	return []error{err}
 }
+
+// ContextWithScrapeTimeout returns a context that is canceled after maxScrapeDuration.
+// A maxScrapeDuration of 0 or less means no timeout.
+func ContextWithScrapeTimeout(maxScrapeDuration time.Duration) (context.Context, context.CancelFunc) {
+	if maxScrapeDuration <= 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithTimeout(context.Background(), maxScrapeDuration)
+}
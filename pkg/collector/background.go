@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// errNoSnapshot is returned by a collector with background collection, if the first collection has not finished yet.
+var errNoSnapshot = errors.New("background collection has not finished yet")
+
+// backgroundCollector collects the wrapped collector in the background.
+// Scrapes are served from the snapshot of the last successful collection.
+type backgroundCollector struct {
+	Collector
+
+	logger   *slog.Logger
+	interval time.Duration
+
+	snapshotAgeDesc *prometheus.Desc
+
+	ctxCancelFn context.CancelFunc
+	done        chan struct{}
+
+	mu          sync.RWMutex
+	metrics     []prometheus.Metric
+	collectedAt time.Time
+}
+
+func newBackgroundCollector(collector Collector, interval time.Duration, snapshotAgeDesc *prometheus.Desc) *backgroundCollector {
+	return &backgroundCollector{
+		Collector:       collector,
+		interval:        interval,
+		snapshotAgeDesc: snapshotAgeDesc,
+	}
+}
+
+// Build builds the wrapped collector and starts the background collection.
+func (c *backgroundCollector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	resetRecordedDescs(c.Collector)
+
+	if err := c.Collector.Build(logger, miSession); err != nil {
+		return err
+	}
+
+	c.logger = logger.With(slog.String("collector", c.GetName()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c.ctxCancelFn = cancel
+	c.done = make(chan struct{})
+
+	go c.run(ctx)
+
+	return nil
+}
+
+// hasSnapshot reports whether the first background collection has finished.
+func (c *backgroundCollector) hasSnapshot() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return !c.collectedAt.IsZero()
+}
+
+// collectorHasSnapshot reports whether the collector has a snapshot to serve.
+// It is true for collectors without background collection.
+func collectorHasSnapshot(collector Collector) bool {
+	if lazy, ok := collector.(*lazyCollector); ok {
+		collector = lazy.Collector
+	}
+
+	if background, ok := collector.(*backgroundCollector); ok {
+		return background.hasSnapshot()
+	}
+
+	return true
+}
+
+// Collect sends the metrics of the last snapshot.
+func (c *backgroundCollector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.collectedAt.IsZero() {
+		return errNoSnapshot
+	}
+
+	for _, m := range c.metrics {
+		ch <- m
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.snapshotAgeDesc,
+		prometheus.GaugeValue,
+		time.Since(c.collectedAt).Seconds(),
+		c.GetName(),
+	)
+
+	return nil
+}
+
+// Describe sends the descriptors of the wrapped collector.
+func (c *backgroundCollector) Describe(ch chan<- *prometheus.Desc) {
+	describe(c.Collector, ch)
+}
+
+// RecordedDescs returns the descriptors recorded by the wrapped collector.
+func (c *backgroundCollector) RecordedDescs() []types.Desc {
+	return recordedDescs(c.Collector)
+}
+
+// Close stops the background collection and closes the wrapped collector.
+func (c *backgroundCollector) Close() error {
+	if c.ctxCancelFn != nil {
+		c.ctxCancelFn()
+		<-c.done
+	}
+
+	return c.Collector.Close()
+}
+
+func (c *backgroundCollector) run(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.collect(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// collect runs a single collection and replaces the snapshot on success.
+// The collection is canceled once the interval has elapsed.
+func (c *backgroundCollector) collect(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	ch := make(chan prometheus.Metric, 1000)
+	metrics := make([]prometheus.Metric, 0, len(c.metrics))
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for m := range ch {
+			metrics = append(metrics, m)
+		}
+	}()
+
+	startTime := time.Now()
+	err := c.collectWithRecover(ctx, ch)
+
+	close(ch)
+	wg.Wait()
+
+	if err != nil && !errors.Is(err, types.ErrNoData) && !errors.Is(err, pdh.ErrNoData) {
+		c.logger.LogAttrs(ctx, slog.LevelWarn,
+			fmt.Sprintf("background collection failed after %s, keeping the previous snapshot", time.Since(startTime)),
+			slog.Any("err", err),
+		)
+
+		return
+	}
+
+	c.mu.Lock()
+	c.metrics = metrics
+	c.collectedAt = time.Now()
+	c.mu.Unlock()
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug,
+		fmt.Sprintf("background collection succeeded after %s, resulting in %d metrics", time.Since(startTime), len(metrics)),
+	)
+}
+
+func (c *backgroundCollector) collectWithRecover(ctx context.Context, ch chan<- prometheus.Metric) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in collector %s: %v. stack: %s", c.GetName(), r, string(debug.Stack()))
+		}
+	}()
+
+	return withContext(c.Collector).CollectWithContext(ctx, ch)
+}
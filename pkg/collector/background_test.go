@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCollector counts its collections.
+type countingCollector struct {
+	calls atomic.Int32
+	desc  *prometheus.Desc
+}
+
+func (c *countingCollector) GetName() string { return "counting" }
+
+func (c *countingCollector) Build(_ *slog.Logger, _ *mi.Session) error { return nil }
+
+func (c *countingCollector) Close() error { return nil }
+
+func (c *countingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *countingCollector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(c.calls.Add(1)))
+
+	return nil
+}
+
+func TestBackgroundCollectorServesSnapshot(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+	counting := &countingCollector{desc: prometheus.NewDesc("test_metric", "test", nil, nil)}
+	collection := New(Map{"counting": counting})
+	background := newBackgroundCollector(counting, time.Hour, collection.collectorSnapshotAgeDesc)
+
+	// Until the first background collection has finished, the collector is reported as unsuccessful.
+	require.Equal(t, noSnapshot, collection.collectCollector(t.Context(), make(chan prometheus.Metric, 10), logger, "counting", background, time.Minute))
+	require.Zero(t, counting.calls.Load())
+
+	require.NoError(t, background.Build(logger, nil))
+
+	require.Eventually(t, background.hasSnapshot, time.Second, 10*time.Millisecond)
+
+	for range 3 {
+		ch := make(chan prometheus.Metric, 10)
+
+		status := collection.collectCollector(t.Context(), ch, logger, "counting", background, time.Minute)
+		require.Equal(t, success, status)
+		// test_metric, windows_exporter_collector_snapshot_age_seconds and windows_exporter_collector_duration_seconds
+		require.Len(t, ch, 3)
+	}
+
+	require.Equal(t, int32(1), counting.calls.Load(), "scrapes must not collect the collector")
+	require.NoError(t, background.Close())
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// circuitState is the state of the circuit breaker of a collector.
+// The values are exposed as windows_exporter_collector_circuit_state.
+type circuitState int
+
+const (
+	// circuitClosed means the collector is collected on every scrape.
+	circuitClosed circuitState = iota
+	// circuitOpen means the collector is skipped until the next probe.
+	circuitOpen
+	// circuitHalfOpen means a probe collection is running.
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// defaultCircuitBreakerBackoff is used, if [Settings.CircuitBreakerBackoff] is not set.
+	defaultCircuitBreakerBackoff = time.Minute
+	// maxCircuitBreakerBackoff is the maximum duration a collector is skipped between two probes,
+	// unless [Settings.CircuitBreakerBackoff] is longer.
+	maxCircuitBreakerBackoff = time.Hour
+)
+
+// circuitBreaker skips a collector after consecutive failures and probes it again with exponential backoff.
+type circuitBreaker struct {
+	state     circuitState
+	failures  int
+	backoff   time.Duration
+	nextProbe time.Time
+}
+
+// circuitBreakers holds the circuit breakers of the collectors.
+type circuitBreakers struct {
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+func newCircuitBreakers() *circuitBreakers {
+	return &circuitBreakers{breakers: make(map[string]*circuitBreaker)}
+}
+
+// breaker returns the circuit breaker of the collector. b.mu must be held.
+func (b *circuitBreakers) breaker(name string) *circuitBreaker {
+	breaker, ok := b.breakers[name]
+	if !ok {
+		breaker = &circuitBreaker{}
+		b.breakers[name] = breaker
+	}
+
+	return breaker
+}
+
+// state returns the state of the circuit breaker of the collector.
+func (b *circuitBreakers) state(name string) circuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.breaker(name).state
+}
+
+// allow reports whether the collector should be collected. If the circuit is open and the backoff has elapsed,
+// a single probe is allowed and the circuit becomes half-open until the result of the probe is recorded.
+func (b *circuitBreakers) allow(name string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	breaker := b.breaker(name)
+
+	switch breaker.state {
+	case circuitOpen:
+		if time.Now().Before(breaker.nextProbe) {
+			return false
+		}
+
+		breaker.state = circuitHalfOpen
+
+		return true
+	case circuitHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// record records the result of a collection. Failed and timed out collections count as failures.
+func (b *circuitBreakers) record(ctx context.Context, logger *slog.Logger, name string, settings *Settings, statusCode collectorStatusCode) {
+	if settings == nil || settings.CircuitBreakerThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	breaker := b.breaker(name)
+
+	if statusCode == success {
+		if breaker.state != circuitClosed {
+			logger.LogAttrs(ctx, slog.LevelInfo, "collector succeeded, closing circuit breaker",
+				slog.String("collector", name),
+			)
+		}
+
+		*breaker = circuitBreaker{}
+
+		return
+	}
+
+	breaker.failures++
+
+	switch breaker.state {
+	case circuitHalfOpen:
+		breaker.backoff = min(2*breaker.backoff, max(maxCircuitBreakerBackoff, breaker.backoff))
+	case circuitClosed:
+		if breaker.failures < settings.CircuitBreakerThreshold {
+			return
+		}
+
+		breaker.backoff = settings.CircuitBreakerBackoff
+		if breaker.backoff <= 0 {
+			breaker.backoff = defaultCircuitBreakerBackoff
+		}
+	case circuitOpen:
+		// A collection that started before the circuit opened.
+		return
+	}
+
+	breaker.state = circuitOpen
+	breaker.nextProbe = time.Now().Add(breaker.backoff)
+
+	logger.LogAttrs(ctx, slog.LevelWarn, "collector failed repeatedly, opening circuit breaker",
+		slog.String("collector", name),
+		slog.Int("consecutive_failures", breaker.failures),
+		slog.Duration("next_probe_in", breaker.backoff),
+	)
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// failingCollector fails until healed and counts its collections.
+type failingCollector struct {
+	calls  atomic.Int32
+	healed atomic.Bool
+}
+
+func (c *failingCollector) GetName() string { return "failing" }
+
+func (c *failingCollector) Build(_ *slog.Logger, _ *mi.Session) error { return nil }
+
+func (c *failingCollector) Close() error { return nil }
+
+func (c *failingCollector) Collect(_ chan<- prometheus.Metric, _ time.Duration) error {
+	c.calls.Add(1)
+
+	if !c.healed.Load() {
+		return errors.New("cluster service is not running")
+	}
+
+	return nil
+}
+
+func TestCollectCollectorCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+	failing := &failingCollector{}
+	collection := New(Map{"failing": failing})
+	collection.settings["failing"] = &Settings{CircuitBreakerThreshold: 2, CircuitBreakerBackoff: 50 * time.Millisecond}
+
+	scrape := func() collectorStatusCode {
+		return collection.collectCollector(t.Context(), make(chan prometheus.Metric, 10), logger, "failing", failing, time.Minute)
+	}
+
+	require.Equal(t, failed, scrape())
+	require.Equal(t, circuitClosed, collection.circuits.state("failing"))
+	require.Equal(t, failed, scrape())
+	require.Equal(t, circuitOpen, collection.circuits.state("failing"))
+
+	require.Equal(t, skipped, scrape())
+	require.Equal(t, int32(2), failing.calls.Load(), "collector must be skipped while the circuit is open")
+
+	// The failed probe opens the circuit again with a doubled backoff.
+	time.Sleep(60 * time.Millisecond)
+	require.Equal(t, failed, scrape())
+	require.Equal(t, circuitOpen, collection.circuits.state("failing"))
+	require.Equal(t, 100*time.Millisecond, collection.circuits.breaker("failing").backoff)
+
+	failing.healed.Store(true)
+
+	require.Eventually(t, func() bool {
+		return scrape() == success
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, circuitClosed, collection.circuits.state("failing"))
+	require.Equal(t, int32(4), failing.calls.Load())
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorResult is the outcome of a single collection of a collector.
+type collectorResult struct {
+	metrics  []prometheus.Metric
+	duration time.Duration
+	status   collectorStatusCode
+	err      error
+}
+
+// collectorFlight is an in-flight collection of a collector.
+type collectorFlight struct {
+	done   chan struct{}
+	result collectorResult
+}
+
+type collectorScrapeCounter struct {
+	fresh     atomic.Uint64
+	coalesced atomic.Uint64
+}
+
+// scrapeCoalescer coalesces concurrent collections of the same collector.
+// If a collection of a collector is already in flight, concurrent scrapes
+// wait for it and share the result instead of running the collector again.
+// This also guarantees that a collector is not collected concurrently.
+type scrapeCoalescer struct {
+	mu       sync.Mutex
+	flights  map[string]*collectorFlight
+	counters map[string]*collectorScrapeCounter
+}
+
+func newScrapeCoalescer() *scrapeCoalescer {
+	return &scrapeCoalescer{
+		flights:  make(map[string]*collectorFlight),
+		counters: make(map[string]*collectorScrapeCounter),
+	}
+}
+
+// do executes fn, if there is no collection of the collector in flight.
+// Otherwise, it waits for the in-flight collection and returns its result. The result, including a timeout,
+// is produced under the deadline of the scrape that started the collection, which may be shorter than the one of ctx.
+// Such scrapes are counted as coalesced. If ctx is done before the in-flight collection has finished,
+// a timeout result is returned.
+func (s *scrapeCoalescer) do(ctx context.Context, name string, fn func() collectorResult) collectorResult {
+	startTime := time.Now()
+
+	s.mu.Lock()
+	counter := s.counter(name)
+
+	if flight, ok := s.flights[name]; ok {
+		s.mu.Unlock()
+		counter.coalesced.Add(1)
+
+		select {
+		case <-flight.done:
+			return flight.result
+		case <-ctx.Done():
+			return collectorResult{
+				duration: time.Since(startTime),
+				status:   pending,
+				err:      context.Cause(ctx),
+			}
+		}
+	}
+
+	flight := &collectorFlight{done: make(chan struct{})}
+	s.flights[name] = flight
+	s.mu.Unlock()
+	counter.fresh.Add(1)
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.flights, name)
+		s.mu.Unlock()
+
+		close(flight.done)
+	}()
+
+	flight.result = fn()
+
+	return flight.result
+}
+
+// counter returns the scrape counter of the collector. s.mu must be held.
+func (s *scrapeCoalescer) counter(name string) *collectorScrapeCounter {
+	counter, ok := s.counters[name]
+	if !ok {
+		counter = &collectorScrapeCounter{}
+		s.counters[name] = counter
+	}
+
+	return counter
+}
+
+// scrapes returns the number of fresh and coalesced collections of the collector.
+func (s *scrapeCoalescer) scrapes(name string) (uint64, uint64) {
+	s.mu.Lock()
+	counter := s.counter(name)
+	s.mu.Unlock()
+
+	return counter.fresh.Load(), counter.coalesced.Load()
+}
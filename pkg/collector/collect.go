@@ -24,7 +24,6 @@ import (
 	"log/slog"
 	"runtime/debug"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
@@ -42,11 +41,58 @@ type collectorStatusCode int
 
 const (
 	pending collectorStatusCode = iota
+	pendingCollectorTimeout
 	success
 	failed
+	// skipped means the collector was not collected, because its circuit breaker is open.
+	skipped
+	// notBuilt means the collector was not collected, because it is not built yet, see [lazyCollector].
+	notBuilt
+	// noSnapshot means the collector was not collected, because its first background collection
+	// has not finished yet, see [backgroundCollector].
+	noSnapshot
 )
 
-func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger, maxScrapeDuration time.Duration) {
+// timeoutBudgets are the budgets a collection can exceed with the status code of the timeout.
+//
+//nolint:gochecknoglobals
+var timeoutBudgets = []struct {
+	name   string
+	status collectorStatusCode
+}{
+	{name: "collector", status: pendingCollectorTimeout},
+	{name: "scrape", status: pending},
+}
+
+// errCollectorTimeout is the cause of a context that hit the timeout of the collector, see [Settings.Timeout].
+var errCollectorTimeout = errors.New("collector timeout exceeded")
+
+// legacyCollector adapts a [Collector] without context support to [CollectorWithContext].
+type legacyCollector struct {
+	Collector
+}
+
+func (l legacyCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
+	var maxScrapeDuration time.Duration
+
+	if deadline, ok := ctx.Deadline(); ok {
+		maxScrapeDuration = time.Until(deadline)
+	}
+
+	return l.Collect(ch, maxScrapeDuration)
+}
+
+// withContext returns the collector as [CollectorWithContext].
+// Legacy collectors are wrapped by an adapter.
+func withContext(collector Collector) CollectorWithContext {
+	if c, ok := collector.(CollectorWithContext); ok {
+		return c
+	}
+
+	return legacyCollector{collector}
+}
+
+func (c *Collection) collectAll(ctx context.Context, ch chan<- prometheus.Metric, logger *slog.Logger, maxScrapeDuration time.Duration) {
 	collectorStartTime := time.Now()
 
 	// WaitGroup to wait for all collectors to finish
@@ -65,7 +111,7 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 
 			collectorStatusCh <- collectorStatus{
 				name:       name,
-				statusCode: c.collectCollector(ch, logger, name, metricsCollector, maxScrapeDuration),
+				statusCode: c.collectCollector(ctx, ch, logger, name, metricsCollector, maxScrapeDuration),
 			}
 		}(name, metricsCollector)
 	}
@@ -78,12 +124,13 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 
 	for status := range collectorStatusCh {
 		var successValue, timeoutValue float64
-		if status.statusCode == pending {
-			timeoutValue = 1.0
-		}
 
-		if status.statusCode == success {
+		switch status.statusCode {
+		case pending, pendingCollectorTimeout:
+			timeoutValue = 1.0
+		case success:
 			successValue = 1.0
+		case failed, skipped, notBuilt, noSnapshot:
 		}
 
 		ch <- prometheus.MustNewConstMetric(
@@ -99,6 +146,72 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 			timeoutValue,
 			status.name,
 		)
+
+		for _, budget := range timeoutBudgets {
+			var exceededValue float64
+			if budget.status == status.statusCode {
+				exceededValue = 1.0
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.collectorTimeoutBudgetExceededDesc,
+				prometheus.GaugeValue,
+				exceededValue,
+				status.name,
+				budget.name,
+			)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.collectorTimeoutBudgetDesc,
+			prometheus.GaugeValue,
+			c.collectorTimeout(status.name, maxScrapeDuration).Seconds(),
+			status.name,
+		)
+	}
+
+	for name, collector := range c.collectors {
+		state := collectorBuildState(collector)
+
+		for _, s := range buildStates {
+			var value float64
+			if s == state {
+				value = 1.0
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.collectorBuildStateDesc,
+				prometheus.GaugeValue,
+				value,
+				name,
+				s.String(),
+			)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.collectorCircuitStateDesc,
+			prometheus.GaugeValue,
+			float64(c.circuits.state(name)),
+			name,
+		)
+
+		fresh, coalesced := c.scrapes.scrapes(name)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.collectorScrapesDesc,
+			prometheus.CounterValue,
+			float64(fresh),
+			name,
+			"fresh",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.collectorScrapesDesc,
+			prometheus.CounterValue,
+			float64(coalesced),
+			name,
+			"coalesced",
+		)
 	}
 
 	ch <- prometheus.MustNewConstMetric(
@@ -108,12 +221,95 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 	)
 }
 
-func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.Logger, name string, collector Collector, maxScrapeDuration time.Duration) collectorStatusCode {
+func (c *Collection) collectCollector(ctx context.Context, ch chan<- prometheus.Metric, logger *slog.Logger, name string, collector Collector, maxScrapeDuration time.Duration) collectorStatusCode {
+	// The context is canceled on return. Collectors with context support
+	// will stop their work instead of running in the background after a timeout.
+	ctx, cancel := context.WithTimeout(ctx, maxScrapeDuration)
+	defer cancel()
+
+	timeout := c.collectorTimeout(name, maxScrapeDuration)
+
+	if timeout < maxScrapeDuration {
+		var cancelCollectorTimeout context.CancelFunc
+
+		ctx, cancelCollectorTimeout = context.WithTimeoutCause(ctx, timeout, errCollectorTimeout)
+		defer cancelCollectorTimeout()
+	}
+
+	if collectorBuildState(collector) != buildReady {
+		logger.LogAttrs(ctx, slog.LevelDebug, fmt.Sprintf("collector %s skipped, because it is not built yet", name))
+
+		return notBuilt
+	}
+
+	if !collectorHasSnapshot(collector) {
+		logger.LogAttrs(ctx, slog.LevelDebug, fmt.Sprintf("collector %s skipped, because its first background collection has not finished yet", name))
+
+		return noSnapshot
+	}
+
+	if !c.circuits.allow(name) {
+		logger.LogAttrs(ctx, slog.LevelDebug, fmt.Sprintf("collector %s skipped, because its circuit breaker is open", name))
+
+		return skipped
+	}
+
+	// Concurrent scrapes share the in-flight collection of the collector.
+	result := c.scrapes.do(ctx, name, func() collectorResult {
+		result := c.runCollector(ctx, logger, name, collector, timeout)
+		statusCode := timeoutStatus(ctx, result.status)
+
+		c.status.recordScrape(name, result, statusCode)
+		c.circuits.record(ctx, logger, name, c.settings[name], statusCode)
+
+		// Coalesced scrapes get the status of this collection, the cause of their context may differ.
+		result.status = statusCode
+
+		return result
+	})
+
+	result.status = timeoutStatus(ctx, result.status)
+
+	for _, m := range result.metrics {
+		ch <- m
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.collectorScrapeDurationDesc,
+		prometheus.GaugeValue,
+		result.duration.Seconds(),
+		name,
+	)
+
+	return result.status
+}
+
+// collectorTimeout returns the timeout of a collection of the collector.
+// The timeout of the collector applies, if it is shorter than the scrape timeout.
+func (c *Collection) collectorTimeout(name string, maxScrapeDuration time.Duration) time.Duration {
+	if settings, ok := c.settings[name]; ok && settings.Timeout > 0 && settings.Timeout < maxScrapeDuration {
+		return settings.Timeout
+	}
+
+	return maxScrapeDuration
+}
+
+// timeoutStatus returns pendingCollectorTimeout instead of pending, if the timeout of the collector was hit.
+func timeoutStatus(ctx context.Context, status collectorStatusCode) collectorStatusCode {
+	if status == pending && errors.Is(context.Cause(ctx), errCollectorTimeout) {
+		return pendingCollectorTimeout
+	}
+
+	return status
+}
+
+func (c *Collection) runCollector(ctx context.Context, logger *slog.Logger, name string, collector Collector, timeoutDuration time.Duration) collectorResult {
 	var (
-		err        error
-		numMetrics int
-		duration   time.Duration
-		timeout    atomic.Bool
+		err      error
+		metrics  []prometheus.Metric
+		metricMu sync.Mutex
+		duration time.Duration
+		timeout  bool
 	)
 
 	// bufCh is a buffer channel to store the metrics
@@ -121,9 +317,6 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 	bufCh := make(chan prometheus.Metric, 1000)
 	errCh := make(chan error, 1)
 
-	ctx, cancel := context.WithTimeout(context.Background(), maxScrapeDuration)
-	defer cancel()
-
 	// execute the collector
 	go func() {
 		defer func() {
@@ -136,23 +329,16 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 			close(bufCh)
 		}()
 
-		errCh <- collector.Collect(bufCh, maxScrapeDuration)
+		errCh <- withContext(collector).CollectWithContext(ctx, bufCh)
 	}()
 
 	wg := sync.WaitGroup{}
 	wg.Add(1)
 
 	go func() {
-		defer func() {
-			// This prevents a panic from race-condition when closing the ch channel too early.
-			_ = recover()
+		defer wg.Done()
 
-			wg.Done()
-		}()
-
-		// Pass metrics to the prometheus registry
-		// If timeout is reached, the channel is closed.
-		// This will cause a panic if we try to write to it.
+		// Buffer the metrics until the collector has finished or the timeout is reached.
 		for {
 			select {
 			case <-ctx.Done():
@@ -162,11 +348,11 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 					return
 				}
 
-				if !timeout.Load() {
-					ch <- m
-
-					numMetrics++
+				metricMu.Lock()
+				if !timeout {
+					metrics = append(metrics, m)
 				}
+				metricMu.Unlock()
 			}
 		}
 	}()
@@ -178,25 +364,21 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 	case err = <-errCh:
 		wg.Wait() // Wait for the buffer channel to be closed and empty
 
+		// The buffering returns on ctx.Done, even if the collector has returned before. Keep the remaining
+		// metrics, bufCh is closed right after the collector has returned.
+		for m := range bufCh {
+			metrics = append(metrics, m)
+		}
+
 		duration = time.Since(t)
-		ch <- prometheus.MustNewConstMetric(
-			c.collectorScrapeDurationDesc,
-			prometheus.GaugeValue,
-			duration.Seconds(),
-			name,
-		)
 	case <-ctx.Done():
-		timeout.Store(true)
+		metricMu.Lock()
+		timeout = true
+		metricMu.Unlock()
 
 		duration = time.Since(t)
-		ch <- prometheus.MustNewConstMetric(
-			c.collectorScrapeDurationDesc,
-			prometheus.GaugeValue,
-			duration.Seconds(),
-			name,
-		)
 
-		logger.LogAttrs(ctx, slog.LevelWarn, fmt.Sprintf("collector %s timeouted after %s, resulting in %d metrics", name, maxScrapeDuration, numMetrics))
+		logger.LogAttrs(ctx, slog.LevelWarn, fmt.Sprintf("collector %s timeouted after %s, resulting in %d metrics", name, timeoutDuration, len(metrics)))
 
 		go func() {
 			// Drain channel in case of premature return to not leak a goroutine.
@@ -204,14 +386,22 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 			}
 		}()
 
-		return pending
+		return collectorResult{metrics: metrics, duration: duration, status: pending, err: context.Cause(ctx)}
 	}
 
+	numMetrics := len(metrics)
 	slogAttrs := make([]slog.Attr, 0)
 
 	result := "succeeded"
 
 	if err != nil {
+		// The collector returned because of the timeout, but before the timeout was observed here.
+		if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			logger.LogAttrs(ctx, slog.LevelWarn, fmt.Sprintf("collector %s timeouted after %s, resulting in %d metrics", name, timeoutDuration, numMetrics))
+
+			return collectorResult{metrics: metrics, duration: duration, status: pending, err: context.Cause(ctx)}
+		}
+
 		if !errors.Is(err, pdh.ErrNoData) && !errors.Is(err, types.ErrNoData) && !errors.Is(err, windows.EPT_S_NOT_REGISTERED) {
 			if errors.Is(err, pdh.ErrPerformanceCounterNotInitialized) {
 				err = fmt.Errorf("%w. Check application logs from initialization pharse for more information", err)
@@ -222,7 +412,7 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 				slog.Any("err", err),
 			)
 
-			return failed
+			return collectorResult{metrics: metrics, duration: duration, status: failed, err: err}
 		}
 
 		slogAttrs = append(slogAttrs, slog.Any("err", err))
@@ -236,5 +426,5 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 		slogAttrs...,
 	)
 
-	return success
+	return collectorResult{metrics: metrics, duration: duration, status: success, err: err}
 }
@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// slowCollector blocks until the context is done.
+type slowCollector struct {
+	returned atomic.Bool
+}
+
+func (c *slowCollector) GetName() string { return "slow" }
+
+func (c *slowCollector) Build(_ *slog.Logger, _ *mi.Session) error { return nil }
+
+func (c *slowCollector) Close() error { return nil }
+
+func (c *slowCollector) Collect(_ chan<- prometheus.Metric, _ time.Duration) error {
+	panic("Collect must not be called for collectors with context support")
+}
+
+func (c *slowCollector) CollectWithContext(ctx context.Context, _ chan<- prometheus.Metric) error {
+	defer c.returned.Store(true)
+
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+// legacySlowCollector does not support context cancellation.
+type legacySlowCollector struct {
+	maxScrapeDuration time.Duration
+}
+
+func (c *legacySlowCollector) GetName() string { return "legacy" }
+
+func (c *legacySlowCollector) Build(_ *slog.Logger, _ *mi.Session) error { return nil }
+
+func (c *legacySlowCollector) Close() error { return nil }
+
+func (c *legacySlowCollector) Collect(_ chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	c.maxScrapeDuration = maxScrapeDuration
+
+	return nil
+}
+
+// blockingCollector blocks until released and counts its collections.
+type blockingCollector struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+	desc    *prometheus.Desc
+}
+
+func (c *blockingCollector) GetName() string { return "blocking" }
+
+func (c *blockingCollector) Build(_ *slog.Logger, _ *mi.Session) error { return nil }
+
+func (c *blockingCollector) Close() error { return nil }
+
+func (c *blockingCollector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	c.calls.Add(1)
+	close(c.started)
+
+	<-c.release
+
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, 1)
+
+	return nil
+}
+
+// TestCollectCollectorTimeoutCancelsCollector compares the number of goroutines, so it must not run in parallel.
+//
+//nolint:paralleltest
+func TestCollectCollectorTimeoutCancelsCollector(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	slow := &slowCollector{}
+	collection := New(Map{"slow": slow})
+
+	ch := make(chan prometheus.Metric, 10)
+	goroutines := runtime.NumGoroutine()
+
+	status := collection.collectCollector(t.Context(), ch, logger, "slow", slow, 50*time.Millisecond)
+	require.Equal(t, pending, status)
+
+	require.Eventually(t, slow.returned.Load, time.Second, 10*time.Millisecond, "collector has not been canceled")
+	require.Eventually(t, func() bool {
+		return runtime.NumGoroutine() <= goroutines
+	}, time.Second, 10*time.Millisecond, "goroutines leaked after timeout")
+}
+
+func TestCollectCollectorTimeoutOfCollector(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+	slow := &slowCollector{}
+	collection := New(Map{"slow": slow})
+	collection.settings["slow"] = &Settings{Timeout: 50 * time.Millisecond}
+
+	ch := make(chan prometheus.Metric, 10)
+
+	startTime := time.Now()
+	status := collection.collectCollector(t.Context(), ch, logger, "slow", slow, time.Minute)
+	require.Equal(t, pendingCollectorTimeout, status)
+	require.Less(t, time.Since(startTime), time.Minute)
+
+	statuses := collection.Status()
+	require.Len(t, statuses, 1)
+	require.True(t, statuses[0].Enabled)
+	require.Equal(t, "collector_timeout", statuses[0].LastScrapeStatus)
+	require.Equal(t, errCollectorTimeout.Error(), statuses[0].LastError)
+	require.Equal(t, uint64(1), statuses[0].Timeouts)
+	require.Len(t, statuses[0].RecentTimeouts, 1)
+}
+
+func TestCollectCollectorCoalescedTimeoutOfCollector(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+	slow := &slowCollector{}
+	collection := New(Map{"slow": slow})
+	collection.settings["slow"] = &Settings{Timeout: 500 * time.Millisecond}
+
+	statuses := make([]collectorStatusCode, 2)
+
+	wg := sync.WaitGroup{}
+
+	for i := range statuses {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			statuses[i] = collection.collectCollector(t.Context(), make(chan prometheus.Metric, 10), logger, "slow", slow, time.Minute)
+		}()
+
+		if i == 0 {
+			require.Eventually(t, func() bool {
+				fresh, _ := collection.scrapes.scrapes("slow")
+
+				return fresh == 1
+			}, time.Second, time.Millisecond)
+		}
+	}
+
+	wg.Wait()
+
+	// The coalesced scrape gets the timeout of the collector, although its own context is not done.
+	require.Equal(t, []collectorStatusCode{pendingCollectorTimeout, pendingCollectorTimeout}, statuses)
+
+	_, coalesced := collection.scrapes.scrapes("slow")
+	require.Equal(t, uint64(1), coalesced)
+}
+
+func TestCollectTimeoutBudgetExceeded(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+	slow := &slowCollector{}
+	collection := New(Map{"slow": slow})
+	collection.settings["slow"] = &Settings{Timeout: 50 * time.Millisecond}
+
+	handler, err := collection.NewHandler(time.Minute, logger, nil)
+	require.NoError(t, err)
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(handler))
+
+	err = testutil.GatherAndCompare(registry, strings.NewReader(`# HELP windows_exporter_collector_timeout windows_exporter: Whether the collector timed out.
+# TYPE windows_exporter_collector_timeout gauge
+windows_exporter_collector_timeout{collector="slow"} 1
+# HELP windows_exporter_collector_timeout_budget_exceeded windows_exporter: Whether the collector timed out, by the exceeded budget. budget=collector is the timeout of the collector, budget=scrape is the scrape timeout.
+# TYPE windows_exporter_collector_timeout_budget_exceeded gauge
+windows_exporter_collector_timeout_budget_exceeded{budget="collector",collector="slow"} 1
+windows_exporter_collector_timeout_budget_exceeded{budget="scrape",collector="slow"} 0
+`), "windows_exporter_collector_timeout", "windows_exporter_collector_timeout_budget_exceeded")
+	require.NoError(t, err)
+}
+
+func TestCollectCollectorLegacyAdapter(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+	legacy := &legacySlowCollector{}
+	collection := New(Map{"legacy": legacy})
+
+	ch := make(chan prometheus.Metric, 10)
+
+	status := collection.collectCollector(t.Context(), ch, logger, "legacy", legacy, time.Minute)
+	require.Equal(t, success, status)
+	require.Greater(t, legacy.maxScrapeDuration, 50*time.Second)
+	require.LessOrEqual(t, legacy.maxScrapeDuration, time.Minute)
+}
+
+func TestCollectCollectorCoalescesConcurrentScrapes(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+	blocking := &blockingCollector{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+		desc:    prometheus.NewDesc("test_metric", "test", nil, nil),
+	}
+	collection := New(Map{"blocking": blocking})
+
+	const scrapes = 3
+
+	channels := make([]chan prometheus.Metric, scrapes)
+	statuses := make([]collectorStatusCode, scrapes)
+
+	wg := sync.WaitGroup{}
+
+	for i := range scrapes {
+		channels[i] = make(chan prometheus.Metric, 10)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			statuses[i] = collection.collectCollector(t.Context(), channels[i], logger, "blocking", blocking, time.Minute)
+		}()
+
+		if i == 0 {
+			<-blocking.started
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		_, coalesced := collection.scrapes.scrapes("blocking")
+
+		return coalesced == scrapes-1
+	}, time.Second, 10*time.Millisecond)
+
+	close(blocking.release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), blocking.calls.Load())
+
+	for i := range scrapes {
+		require.Equal(t, success, statuses[i])
+		// test_metric and windows_exporter_collector_duration_seconds
+		require.Len(t, channels[i], 2)
+	}
+
+	fresh, coalesced := collection.scrapes.scrapes("blocking")
+	require.Equal(t, uint64(1), fresh)
+	require.Equal(t, uint64(scrapes-1), coalesced)
+}
@@ -60,6 +60,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/physical_disk"
 	"github.com/prometheus-community/windows_exporter/internal/collector/printer"
 	"github.com/prometheus-community/windows_exporter/internal/collector/process"
+	"github.com/prometheus-community/windows_exporter/internal/collector/proxy"
 	"github.com/prometheus-community/windows_exporter/internal/collector/remote_fx"
 	"github.com/prometheus-community/windows_exporter/internal/collector/scheduled_task"
 	"github.com/prometheus-community/windows_exporter/internal/collector/service"
@@ -76,21 +77,25 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/update"
 	"github.com/prometheus-community/windows_exporter/internal/collector/vmware"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
-	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
-	"golang.org/x/sys/windows/registry"
 )
 
 // NewWithFlags To be called by the exporter for collector initialization before running kingpin.Parse.
 func NewWithFlags(app *kingpin.Application) *Collection {
 	collectors := map[string]Collector{}
+	settings := map[string]*Settings{}
 
 	for name, builder := range BuildersWithFlags {
 		collectors[name] = builder(app)
+		settings[name] = newSettingsWithFlags(app, name)
 	}
 
-	return New(collectors)
+	collection := New(collectors)
+	collection.settings = settings
+	collection.globalLabels = newGlobalLabelsWithFlags(app)
+
+	return collection
 }
 
 // NewWithConfig To be called by the external libraries for collector initialization without running [kingpin.Parse].
@@ -130,6 +135,7 @@ func NewWithConfig(config Config) *Collection {
 	collectors[physical_disk.Name] = physical_disk.New(&config.PhysicalDisk)
 	collectors[printer.Name] = printer.New(&config.Printer)
 	collectors[process.Name] = process.New(&config.Process)
+	collectors[proxy.Name] = proxy.New(&config.Proxy)
 	collectors[remote_fx.Name] = remote_fx.New(&config.RemoteFx)
 	collectors[scheduled_task.Name] = scheduled_task.New(&config.ScheduledTask)
 	collectors[service.Name] = service.New(&config.Service)
@@ -146,38 +152,87 @@ func NewWithConfig(config Config) *Collection {
 	collectors[update.Name] = update.New(&config.Update)
 	collectors[vmware.Name] = vmware.New(&config.Vmware)
 
-	return New(collectors)
+	collection := New(collectors)
+
+	for name, settings := range config.Settings {
+		collection.settings[name] = &settings
+	}
+
+	return collection
 }
 
 // New To be called by the external libraries for collector initialization.
 func New(collectors Map) *Collection {
+	exporterMetrics := &types.DescRecorder{}
+
 	return &Collection{
-		collectors:    collectors,
-		concurrencyCh: make(chan struct{}, 1),
-		scrapeDurationDesc: prometheus.NewDesc(
+		collectors:      collectors,
+		scrapes:         newScrapeCoalescer(),
+		status:          newStatusTracker(slices.Collect(maps.Keys(collectors))),
+		circuits:        newCircuitBreakers(),
+		settings:        make(map[string]*Settings),
+		descs:           &metricDescs{},
+		exporterMetrics: exporterMetrics,
+		scrapeDurationDesc: exporterMetrics.NewDesc(
 			prometheus.BuildFQName(types.Namespace, "exporter", "scrape_duration_seconds"),
 			"windows_exporter: Total scrape duration.",
 			nil,
 			nil,
 		),
-		collectorScrapeDurationDesc: prometheus.NewDesc(
+		collectorScrapeDurationDesc: exporterMetrics.NewDesc(
 			prometheus.BuildFQName(types.Namespace, "exporter", "collector_duration_seconds"),
 			"windows_exporter: Duration of a collection.",
 			[]string{"collector"},
 			nil,
 		),
-		collectorScrapeSuccessDesc: prometheus.NewDesc(
+		collectorScrapeSuccessDesc: exporterMetrics.NewDesc(
 			prometheus.BuildFQName(types.Namespace, "exporter", "collector_success"),
 			"windows_exporter: Whether the collector was successful.",
 			[]string{"collector"},
 			nil,
 		),
-		collectorScrapeTimeoutDesc: prometheus.NewDesc(
+		collectorScrapeTimeoutDesc: exporterMetrics.NewDesc(
 			prometheus.BuildFQName(types.Namespace, "exporter", "collector_timeout"),
 			"windows_exporter: Whether the collector timed out.",
 			[]string{"collector"},
 			nil,
 		),
+		collectorTimeoutBudgetExceededDesc: exporterMetrics.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_timeout_budget_exceeded"),
+			"windows_exporter: Whether the collector timed out, by the exceeded budget. budget=collector is the timeout of the collector, budget=scrape is the scrape timeout.",
+			[]string{"collector", "budget"},
+			nil,
+		),
+		collectorTimeoutBudgetDesc: exporterMetrics.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_timeout_budget_seconds"),
+			"windows_exporter: Timeout of the last collection. The timeout of the collector applies, if it is shorter than the scrape timeout.",
+			[]string{"collector"},
+			nil,
+		),
+		collectorScrapesDesc: exporterMetrics.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_scrapes_total"),
+			"windows_exporter: Number of collections per collector. type=coalesced counts scrapes that shared the result of an in-flight collection. Their result, including a timeout, is produced under the timeout of the scrape that started the collection.",
+			[]string{"collector", "type"},
+			nil,
+		),
+		collectorSnapshotAgeDesc: exporterMetrics.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_snapshot_age_seconds"),
+			"windows_exporter: Age of the snapshot served by a collector with background collection.",
+			[]string{"collector"},
+			nil,
+		),
+		collectorBuildStateDesc: exporterMetrics.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_build_state"),
+			"windows_exporter: Build state of the collector. A pending collector is built again with backoff, until the monitored component is present.",
+			[]string{"collector", "state"},
+			nil,
+		),
+		collectorCircuitStateDesc: exporterMetrics.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_circuit_state"),
+			"windows_exporter: State of the circuit breaker of the collector. 0 is closed, 1 is open (the collector is skipped) and 2 is half-open (a probe is running).",
+			[]string{"collector"},
+			nil,
+		),
 	}
 }
 
@@ -207,46 +262,82 @@ func (c *Collection) Disable(disabledCollectors []string) {
 	}
 }
 
+// Enabled returns the names of the enabled collectors, sorted.
+func (c *Collection) Enabled() []string {
+	return slices.Sorted(maps.Keys(c.collectors))
+}
+
 // Build To be called by the exporter for collector initialization.
 // Instead, fail fast, it will try to build all collectors and return all errors.
 // errors are joined with errors.Join.
 func (c *Collection) Build(ctx context.Context, logger *slog.Logger) error {
 	c.startTime = gotime.Now()
 
+	if err := c.resolveGlobalLabels(ctx, logger); err != nil {
+		return fmt.Errorf("error resolving global labels: %w", err)
+	}
+
 	err := c.initMI()
 	if err != nil {
 		return fmt.Errorf("error from initialize MI: %w", err)
 	}
 
+	for name, collector := range c.collectors {
+		if settings, ok := c.settings[name]; ok && settings.BackgroundInterval > 0 {
+			c.collectors[name] = newBackgroundCollector(collector, settings.BackgroundInterval, c.collectorSnapshotAgeDesc)
+		}
+	}
+
+	type buildResult struct {
+		name string
+		err  error
+	}
+
 	wg := sync.WaitGroup{}
 	wg.Add(len(c.collectors))
 
-	errCh := make(chan error, len(c.collectors))
+	resultCh := make(chan buildResult, len(c.collectors))
 
-	for _, collector := range c.collectors {
+	for name, collector := range c.collectors {
 		go func() {
 			defer wg.Done()
 
-			if err := collector.Build(logger, c.miSession); err != nil {
-				errCh <- fmt.Errorf("error build collector %s: %w", collector.GetName(), err)
+			resetRecordedDescs(collector)
+
+			err := collector.Build(logger, c.miSession)
+			c.status.recordBuild(name, err)
+
+			if err != nil {
+				resultCh <- buildResult{name: name, err: err}
 			}
 		}()
 	}
 
 	wg.Wait()
 
-	close(errCh)
+	close(resultCh)
 
 	errs := make([]error, 0, len(c.collectors))
+	lazyCollectors := make([]*lazyCollector, 0)
+
+	for result := range resultCh {
+		collector := c.collectors[result.name]
+		err := fmt.Errorf("error build collector %s: %w", collector.GetName(), result.err)
+
+		// The monitored component may be installed later, so the build is retried in the background.
+		if isComponentNotPresent(err) {
+			logger.LogAttrs(ctx, slog.LevelWarn, "couldn't initialize collector, retrying in the background", slog.Any("err", err))
+
+			lazy := newLazyCollector(collector, logger, c.miSession, func(err error) {
+				c.status.recordBuild(result.name, err)
 
-	for err := range errCh {
-		if errors.Is(err, pdh.ErrNoData) ||
-			errors.Is(err, registry.ErrNotExist) ||
-			errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) ||
-			errors.Is(err, pdh.NewPdhError(pdh.CstatusNoCounter)) ||
-			errors.Is(err, mi.MI_RESULT_INVALID_OPERATION_TIMEOUT) ||
-			errors.Is(err, mi.MI_RESULT_INVALID_NAMESPACE) {
-			logger.LogAttrs(ctx, slog.LevelWarn, "couldn't initialize collector", slog.Any("err", err))
+				if err == nil {
+					c.describeCollectors(context.Background(), logger)
+				}
+			})
+			lazyCollectors = append(lazyCollectors, lazy)
+
+			c.collectors[result.name] = lazy
 
 			continue
 		}
@@ -254,6 +345,12 @@ func (c *Collection) Build(ctx context.Context, logger *slog.Logger) error {
 		errs = append(errs, err)
 	}
 
+	c.describeCollectors(ctx, logger)
+
+	for _, lazy := range lazyCollectors {
+		lazy.start()
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -314,14 +411,27 @@ func (c *Collection) initMI() error {
 // WithCollectors To be called by the exporter for collector initialization.
 func (c *Collection) WithCollectors(collectors []string) (*Collection, error) {
 	metricCollectors := &Collection{
-		miSession:                   c.miSession,
-		startTime:                   c.startTime,
-		concurrencyCh:               c.concurrencyCh,
-		scrapeDurationDesc:          c.scrapeDurationDesc,
-		collectorScrapeDurationDesc: c.collectorScrapeDurationDesc,
-		collectorScrapeSuccessDesc:  c.collectorScrapeSuccessDesc,
-		collectorScrapeTimeoutDesc:  c.collectorScrapeTimeoutDesc,
-		collectors:                  maps.Clone(c.collectors),
+		miSession:                          c.miSession,
+		startTime:                          c.startTime,
+		scrapes:                            c.scrapes,
+		status:                             c.status,
+		circuits:                           c.circuits,
+		settings:                           c.settings,
+		descs:                              c.descs,
+		exporterMetrics:                    c.exporterMetrics,
+		globalLabels:                       c.globalLabels,
+		labelPairs:                         c.labelPairs,
+		scrapeDurationDesc:                 c.scrapeDurationDesc,
+		collectorScrapeDurationDesc:        c.collectorScrapeDurationDesc,
+		collectorScrapeSuccessDesc:         c.collectorScrapeSuccessDesc,
+		collectorScrapeTimeoutDesc:         c.collectorScrapeTimeoutDesc,
+		collectorTimeoutBudgetDesc:         c.collectorTimeoutBudgetDesc,
+		collectorTimeoutBudgetExceededDesc: c.collectorTimeoutBudgetExceededDesc,
+		collectorScrapesDesc:               c.collectorScrapesDesc,
+		collectorSnapshotAgeDesc:           c.collectorSnapshotAgeDesc,
+		collectorBuildStateDesc:            c.collectorBuildStateDesc,
+		collectorCircuitStateDesc:          c.collectorCircuitStateDesc,
+		collectors:                         maps.Clone(c.collectors),
 	}
 
 	if err := metricCollectors.Enable(collectors); err != nil {
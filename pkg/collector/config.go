@@ -50,6 +50,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/physical_disk"
 	"github.com/prometheus-community/windows_exporter/internal/collector/printer"
 	"github.com/prometheus-community/windows_exporter/internal/collector/process"
+	"github.com/prometheus-community/windows_exporter/internal/collector/proxy"
 	"github.com/prometheus-community/windows_exporter/internal/collector/remote_fx"
 	"github.com/prometheus-community/windows_exporter/internal/collector/scheduled_task"
 	"github.com/prometheus-community/windows_exporter/internal/collector/service"
@@ -100,6 +101,7 @@ type Config struct {
 	PhysicalDisk       physical_disk.Config      `yaml:"physical_disk"`
 	Printer            printer.Config            `yaml:"printer"`
 	Process            process.Config            `yaml:"process"`
+	Proxy              proxy.Config              `yaml:"proxy"`
 	RemoteFx           remote_fx.Config          `yaml:"remote_fx"`
 	ScheduledTask      scheduled_task.Config     `yaml:"scheduled_task"`
 	Service            service.Config            `yaml:"service"`
@@ -115,6 +117,9 @@ type Config struct {
 	UDP                udp.Config                `yaml:"udp"`
 	Update             update.Config             `yaml:"update"`
 	Vmware             vmware.Config             `yaml:"vmware"`
+
+	// Settings holds the [Settings] per collector name.
+	Settings map[string]Settings `yaml:"-"`
 }
 
 // ConfigDefaults Is an interface to be used by the external libraries. It holds all ConfigDefaults form all collectors
@@ -154,6 +159,7 @@ var ConfigDefaults = Config{
 	PhysicalDisk:       physical_disk.ConfigDefaults,
 	Printer:            printer.ConfigDefaults,
 	Process:            process.ConfigDefaults,
+	Proxy:              proxy.ConfigDefaults,
 	RemoteFx:           remote_fx.ConfigDefaults,
 	ScheduledTask:      scheduled_task.ConfigDefaults,
 	Service:            service.ConfigDefaults,
@@ -0,0 +1,310 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CollectorWithDescribe is an optional interface that a collector can implement to describe its metrics.
+// Collectors that do not implement this interface are not described, and their metrics are not checked on registration.
+// The collectors of windows_exporter implement it by embedding [types.DescRecorder].
+type CollectorWithDescribe interface {
+	Collector
+	// Describe sends the descriptors of all metrics the collector can collect.
+	Describe(ch chan<- *prometheus.Desc)
+}
+
+// collectorWithRecordedDescs is implemented by collectors embedding [types.DescRecorder].
+// The metadata of the recorded descriptors is exposed by [Collection.Metadata].
+type collectorWithRecordedDescs interface {
+	RecordedDescs() []types.Desc
+}
+
+// MetricMetadata describes a metric family exposed by the enabled collectors.
+type MetricMetadata struct {
+	Name string `json:"name"`
+	// Type is the type of the collected metric family, or unknown if the metric was not collected.
+	Type   string   `json:"type"`
+	Help   string   `json:"help"`
+	Labels []string `json:"labels"`
+	// Collector is the name of the collector describing the metric.
+	// It is empty for exporter metrics and metrics without a descriptor, e.g., from the textfile collector.
+	Collector string `json:"collector,omitempty"`
+}
+
+// metricDescs holds the descriptors of the collectors, gathered after the build.
+type metricDescs struct {
+	mu          sync.RWMutex
+	byCollector map[string][]*prometheus.Desc
+	// recorded holds the recorded descriptors with their metadata by collector, see [types.DescRecorder].
+	recorded map[string][]types.Desc
+	// consistent is false if the descriptors can't be registered together.
+	// In this case, no descriptors are sent to the registry, and it runs unchecked.
+	consistent bool
+	// metadata caches the result of [Collection.Metadata] until the descriptors change.
+	metadata []MetricMetadata
+	// generation is incremented each time the descriptors change.
+	generation uint64
+}
+
+// describe sends the descriptors of the collector to ch.
+func describe(collector Collector, ch chan<- *prometheus.Desc) {
+	if c, ok := collector.(CollectorWithDescribe); ok {
+		c.Describe(ch)
+	}
+}
+
+// resetRecordedDescs discards the descriptors recorded by a previous build of the collector.
+func resetRecordedDescs(collector Collector) {
+	if c, ok := collector.(interface{ ResetDescs() }); ok {
+		c.ResetDescs()
+	}
+}
+
+// recordedDescs returns the descriptors recorded by the collector, if it embeds [types.DescRecorder].
+func recordedDescs(collector Collector) []types.Desc {
+	if c, ok := collector.(collectorWithRecordedDescs); ok {
+		return c.RecordedDescs()
+	}
+
+	return nil
+}
+
+// describeCollectors gathers the descriptors of all collectors and checks them for duplicate metric names.
+// Metric names described by multiple collectors are logged as warning. If the descriptors can't be
+// registered together, an error is logged and the registry runs unchecked.
+func (c *Collection) describeCollectors(ctx context.Context, logger *slog.Logger) {
+	byCollector := make(map[string][]*prometheus.Desc, len(c.collectors))
+	recorded := make(map[string][]types.Desc, len(c.collectors))
+	owners := make(map[string][]string)
+
+	var all []*prometheus.Desc
+
+	for _, desc := range c.exporterMetrics.RecordedDescs() {
+		all = append(all, desc.Desc)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.collectors)) {
+		ch := make(chan *prometheus.Desc)
+
+		go func() {
+			defer close(ch)
+
+			describe(c.collectors[name], ch)
+		}()
+
+		var descs []*prometheus.Desc
+
+		seen := make(map[*prometheus.Desc]struct{})
+
+		for desc := range ch {
+			if _, ok := seen[desc]; ok {
+				continue
+			}
+
+			seen[desc] = struct{}{}
+
+			if err := desc.Err(); err != nil {
+				logger.LogAttrs(ctx, slog.LevelWarn, "collector describes an invalid metric",
+					slog.String("collector", name),
+					slog.Any("err", err),
+				)
+
+				continue
+			}
+
+			descs = append(descs, desc)
+		}
+
+		byCollector[name] = descs
+		all = append(all, descs...)
+
+		recorded[name] = recordedDescs(c.collectors[name])
+
+		for _, desc := range recorded[name] {
+			if !slices.Contains(owners[desc.Name], name) {
+				owners[desc.Name] = append(owners[desc.Name], name)
+			}
+		}
+	}
+
+	for _, metricName := range slices.Sorted(maps.Keys(owners)) {
+		if len(owners[metricName]) > 1 {
+			logger.LogAttrs(ctx, slog.LevelWarn, "metric name is described by multiple collectors",
+				slog.String("metric", metricName),
+				slog.Any("collectors", owners[metricName]),
+			)
+		}
+	}
+
+	err := prometheus.NewRegistry().Register(descCollector(all))
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "inconsistent metric descriptors, metrics are not checked on registration",
+			slog.Any("err", err),
+		)
+	}
+
+	c.descs.mu.Lock()
+	defer c.descs.mu.Unlock()
+
+	c.descs.byCollector = byCollector
+	c.descs.recorded = recorded
+	c.descs.consistent = err == nil
+	c.descs.metadata = nil
+	c.descs.generation++
+}
+
+// describe sends the descriptors of the enabled collectors to ch.
+// Nothing is sent if the descriptors are not consistent.
+func (c *Collection) describe(ch chan<- *prometheus.Desc) {
+	c.descs.mu.RLock()
+	defer c.descs.mu.RUnlock()
+
+	if !c.descs.consistent {
+		return
+	}
+
+	c.exporterMetrics.Describe(ch)
+
+	for name := range c.collectors {
+		for _, desc := range c.descs.byCollector[name] {
+			ch <- desc
+		}
+	}
+}
+
+// Metadata returns the metadata of all metric families exposed by the enabled collectors, sorted by name.
+// The collectors are scraped once to determine the type of the metrics and to find metrics without a descriptor.
+// The result is cached until the descriptors change, e.g., once a pending collector is built.
+func (c *Collection) Metadata(logger *slog.Logger, maxScrapeDuration time.Duration) ([]MetricMetadata, error) {
+	c.descs.mu.RLock()
+	cached, generation := c.descs.metadata, c.descs.generation
+	c.descs.mu.RUnlock()
+
+	if cached != nil {
+		return cached, nil
+	}
+
+	metadata := make(map[string]MetricMetadata)
+
+	for _, desc := range c.exporterMetrics.RecordedDescs() {
+		metadata[desc.Name] = newMetricMetadata(desc, "")
+	}
+
+	c.descs.mu.RLock()
+
+	for name := range c.collectors {
+		for _, desc := range c.descs.recorded[name] {
+			if _, ok := metadata[desc.Name]; !ok {
+				metadata[desc.Name] = newMetricMetadata(desc, name)
+			}
+		}
+	}
+
+	c.descs.mu.RUnlock()
+
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(&Handler{collection: c, logger: logger, maxScrapeDuration: maxScrapeDuration}); err != nil {
+		return nil, fmt.Errorf("failed to register collectors: %w", err)
+	}
+
+	// Gather returns all metric families that could be gathered, even on error.
+	metricFamilies, err := registry.Gather()
+
+	for _, mf := range metricFamilies {
+		m, ok := metadata[mf.GetName()]
+		if !ok {
+			labels := make(map[string]struct{})
+
+			for _, metric := range mf.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = struct{}{}
+				}
+			}
+
+			m = MetricMetadata{
+				Name:   mf.GetName(),
+				Help:   mf.GetHelp(),
+				Labels: slices.Sorted(maps.Keys(labels)),
+			}
+		}
+
+		m.Type = strings.ToLower(mf.GetType().String())
+		metadata[m.Name] = m
+	}
+
+	result := make([]MetricMetadata, 0, len(metadata))
+	for _, name := range slices.Sorted(maps.Keys(metadata)) {
+		m := metadata[name]
+
+		// The global labels are added to all metrics by the handler.
+		for _, labelPair := range c.labelPairs {
+			if !slices.Contains(m.Labels, labelPair.GetName()) {
+				m.Labels = append(m.Labels, labelPair.GetName())
+			}
+		}
+
+		result = append(result, m)
+	}
+
+	if err != nil {
+		return result, fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	// Only complete results are cached, and only if the descriptors didn't change meanwhile.
+	c.descs.mu.Lock()
+	if c.descs.generation == generation {
+		c.descs.metadata = result
+	}
+	c.descs.mu.Unlock()
+
+	return result, nil
+}
+
+// newMetricMetadata returns the metadata of the recorded descriptor with type unknown.
+func newMetricMetadata(desc types.Desc, collectorName string) MetricMetadata {
+	return MetricMetadata{
+		Name:      desc.Name,
+		Type:      "unknown",
+		Help:      desc.Help,
+		Labels:    slices.Clone(desc.Labels),
+		Collector: collectorName,
+	}
+}
+
+// descCollector is a [prometheus.Collector] that only describes the descriptors.
+type descCollector []*prometheus.Desc
+
+func (d descCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range d {
+		ch <- desc
+	}
+}
+
+func (d descCollector) Collect(chan<- prometheus.Metric) {}
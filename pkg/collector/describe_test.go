@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingCollector records its descriptors on build, like the collectors of windows_exporter.
+type recordingCollector struct {
+	types.DescRecorder
+
+	desc *prometheus.Desc
+}
+
+func (c *recordingCollector) GetName() string { return "recording" }
+
+func (c *recordingCollector) Close() error { return nil }
+
+func (c *recordingCollector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, 1, "C:", "read")
+
+	return nil
+}
+
+func (c *recordingCollector) Build(_ *slog.Logger, _ *mi.Session) error {
+	c.desc = c.NewDesc("test_bytes_total", "Help of the test metric.", []string{"volume", "mode"}, prometheus.Labels{"source": "test"})
+	c.NewDesc("test_info", "Help of the info metric.", nil, nil)
+
+	return nil
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+	collector := &recordingCollector{}
+
+	require.NoError(t, collector.Build(logger, nil))
+
+	// Building again discards the descriptors of the previous build.
+	resetRecordedDescs(collector)
+	require.NoError(t, collector.Build(logger, nil))
+
+	for _, c := range []Collector{collector, newBackgroundCollector(collector, time.Minute, nil)} {
+		ch := make(chan *prometheus.Desc, 10)
+		describe(c, ch)
+		close(ch)
+
+		var described []*prometheus.Desc
+		for desc := range ch {
+			described = append(described, desc)
+		}
+
+		require.Len(t, described, 2)
+		require.Contains(t, described, collector.desc)
+
+		recorded := recordedDescs(c)
+		require.Len(t, recorded, 2)
+		require.Equal(t, MetricMetadata{
+			Name:   "test_bytes_total",
+			Type:   "unknown",
+			Help:   "Help of the test metric.",
+			Labels: []string{"volume", "mode", "source"},
+		}, newMetricMetadata(recorded[0], ""))
+	}
+}
+
+func TestDescribeCollectorsInconsistent(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+
+	consistent := New(Map{
+		"a": &countingCollector{desc: prometheus.NewDesc("test_a", "test", []string{"instance"}, nil)},
+		"b": &countingCollector{desc: prometheus.NewDesc("test_b", "test", nil, nil)},
+	})
+	consistent.describeCollectors(t.Context(), logger)
+	require.True(t, consistent.descs.consistent)
+
+	inconsistent := New(Map{
+		"a": &countingCollector{desc: prometheus.NewDesc("test_metric", "test", []string{"instance"}, nil)},
+		"b": &countingCollector{desc: prometheus.NewDesc("test_metric", "test", nil, nil)},
+	})
+	inconsistent.describeCollectors(t.Context(), logger)
+	require.False(t, inconsistent.descs.consistent)
+
+	// The handler is registered as unchecked collector, so scrapes still work.
+	handler, err := inconsistent.NewHandler(time.Minute, logger, nil)
+	require.NoError(t, err)
+	require.NoError(t, prometheus.NewRegistry().Register(handler))
+}
+
+func TestMetadataIsCached(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+	counting := &countingCollector{desc: prometheus.NewDesc("test_metric", "test", nil, nil)}
+	collection := New(Map{"counting": counting})
+	collection.describeCollectors(t.Context(), logger)
+
+	metadata, err := collection.Metadata(logger, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), counting.calls.Load())
+
+	cached, err := collection.Metadata(logger, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, metadata, cached)
+	require.Equal(t, int32(1), counting.calls.Load(), "cached metadata must not scrape the collectors")
+
+	// The cache is discarded, once the descriptors change.
+	collection.describeCollectors(t.Context(), logger)
+
+	_, err = collection.Metadata(logger, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), counting.calls.Load())
+}
@@ -18,9 +18,9 @@
 package collector
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
-	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -29,12 +29,6 @@ import (
 // Interface guard.
 var _ prometheus.Collector = (*Handler)(nil)
 
-// We are expose metrics directly from the memory region of the Win32 API.
-// We should not allow more than one request at a time.
-//
-//nolint:gochecknoglobals
-var concurrencyMu sync.Mutex
-
 // Handler implements [prometheus.Collector] for a set of Windows Collection.
 type Handler struct {
 	maxScrapeDuration time.Duration
@@ -62,12 +56,40 @@ func (c *Collection) NewHandler(maxScrapeDuration time.Duration, logger *slog.Lo
 	}, nil
 }
 
-func (p *Handler) Describe(_ chan<- *prometheus.Desc) {}
+// Describe sends the descriptors of the enabled collectors. If the descriptors of the collectors
+// are inconsistent, nothing is sent and the handler is registered as unchecked collector.
+func (p *Handler) Describe(ch chan<- *prometheus.Desc) {
+	p.collection.describe(ch)
+}
 
 // Collect sends the collected metrics from each of the Collection to
-// prometheus.
+// prometheus. Concurrent calls share in-flight collections of the same collector.
+// The global labels of the collection are added to all metrics.
 func (p *Handler) Collect(ch chan<- prometheus.Metric) {
-	concurrencyMu.Lock()
-	p.collection.collectAll(ch, p.logger, p.maxScrapeDuration)
-	concurrencyMu.Unlock()
+	if len(p.collection.labelPairs) == 0 {
+		p.collection.collectAll(context.Background(), ch, p.logger, p.maxScrapeDuration)
+
+		return
+	}
+
+	conflict := LabelConflictExported
+	if p.collection.globalLabels.Conflict != "" {
+		conflict = p.collection.globalLabels.Conflict
+	}
+
+	labeledCh := make(chan prometheus.Metric)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for m := range labeledCh {
+			ch <- labeledMetric{Metric: m, labelPairs: p.collection.labelPairs, conflict: conflict}
+		}
+	}()
+
+	p.collection.collectAll(context.Background(), labeledCh, p.logger, p.maxScrapeDuration)
+
+	close(labeledCh)
+	<-done
 }
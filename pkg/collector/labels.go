@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/headers/netapi32"
+	"github.com/prometheus-community/windows_exporter/internal/headers/sysinfoapi"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+	"golang.org/x/sys/windows"
+	"google.golang.org/protobuf/proto"
+)
+
+// LabelConflict defines how a global label is handled, if the metric already has a label with the same name.
+type LabelConflict string
+
+const (
+	// LabelConflictExported renames the label of the metric to exported_<name> and adds the global label.
+	// This is the behavior of Prometheus with honor_labels: false.
+	LabelConflictExported LabelConflict = "exported"
+	// LabelConflictHonor keeps the label of the metric and does not add the global label.
+	LabelConflictHonor LabelConflict = "honor"
+)
+
+// Host labels are derived from the host, see [GlobalLabels.HostLabels].
+const (
+	HostLabelHostname = "hostname"
+	HostLabelDomain   = "domain"
+	HostLabelADSite   = "ad_site"
+)
+
+// GlobalLabels holds the labels that are added to all metrics of the [Handler].
+type GlobalLabels struct {
+	// Labels are static labels, e.g., datacenter, environment or role.
+	Labels map[string]string
+	// HostLabels are the names of the labels derived from the host.
+	// Supported are hostname, domain (the DNS domain) and ad_site (the Active Directory site).
+	// Labels without value, e.g., the domain of a workgroup host, are not added.
+	HostLabels []string
+	// Conflict defines how a global label is handled, if the metric already has a label with the same name.
+	// Defaults to [LabelConflictExported].
+	Conflict LabelConflict
+}
+
+// newGlobalLabelsWithFlags registers the flags of the global labels.
+func newGlobalLabelsWithFlags(app *kingpin.Application) *GlobalLabels {
+	globalLabels := &GlobalLabels{
+		Labels: make(map[string]string),
+	}
+
+	var hostLabels, conflict string
+
+	app.Flag(
+		"global.labels",
+		"Label added to all metrics, as name=value. Repeatable for multiple labels.",
+	).StringMapVar(&globalLabels.Labels)
+
+	app.Flag(
+		"global.host-labels",
+		"Comma-separated list of labels derived from the host added to all metrics. Supported are hostname, domain and ad_site.",
+	).Default("").StringVar(&hostLabels)
+
+	app.Flag(
+		"global.label-conflict",
+		"Handling of global labels that are already used by a metric. exported renames the label of the metric to exported_<name>, honor keeps the label of the metric.",
+	).Default(string(LabelConflictExported)).EnumVar(&conflict, string(LabelConflictExported), string(LabelConflictHonor))
+
+	app.Action(func(*kingpin.ParseContext) error {
+		if hostLabels != "" {
+			globalLabels.HostLabels = strings.Split(hostLabels, ",")
+		}
+
+		globalLabels.Conflict = LabelConflict(conflict)
+
+		return nil
+	})
+
+	return globalLabels
+}
+
+// SetGlobalLabels sets the labels that are added to all metrics of the [Handler].
+// The labels are resolved by [Collection.Build].
+func (c *Collection) SetGlobalLabels(globalLabels GlobalLabels) {
+	c.globalLabels = &globalLabels
+}
+
+// resolveGlobalLabels validates the global labels and resolves the host labels.
+func (c *Collection) resolveGlobalLabels(ctx context.Context, logger *slog.Logger) error {
+	c.labelPairs = nil
+
+	if c.globalLabels == nil {
+		return nil
+	}
+
+	switch c.globalLabels.Conflict {
+	case "", LabelConflictExported, LabelConflictHonor:
+	default:
+		return fmt.Errorf("unknown label conflict handling %q", c.globalLabels.Conflict)
+	}
+
+	labels := maps.Clone(c.globalLabels.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+
+	for _, name := range c.globalLabels.HostLabels {
+		if _, ok := labels[name]; ok {
+			return fmt.Errorf("label %s is set as global label and as host label", name)
+		}
+
+		value, err := hostLabel(name)
+		if err != nil {
+			return err
+		}
+
+		if value == "" {
+			logger.LogAttrs(ctx, slog.LevelDebug, "host label has no value and is not added",
+				slog.String("label", name),
+			)
+
+			continue
+		}
+
+		labels[name] = value
+	}
+
+	labelPairs := make([]*dto.LabelPair, 0, len(labels))
+
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		if !model.LabelName(name).IsValidLegacy() || strings.HasPrefix(name, model.ReservedLabelPrefix) {
+			return fmt.Errorf("invalid global label name %q", name)
+		}
+
+		labelPairs = append(labelPairs, &dto.LabelPair{
+			Name:  proto.String(name),
+			Value: proto.String(labels[name]),
+		})
+	}
+
+	c.labelPairs = labelPairs
+
+	return nil
+}
+
+// hostLabel returns the value of the host label.
+func hostLabel(name string) (string, error) {
+	switch name {
+	case HostLabelHostname:
+		hostname, err := os.Hostname()
+		if err != nil {
+			return "", fmt.Errorf("failed to get hostname: %w", err)
+		}
+
+		return hostname, nil
+	case HostLabelDomain:
+		domain, err := sysinfoapi.GetComputerName(sysinfoapi.ComputerNameDNSDomain)
+		if err != nil {
+			return "", fmt.Errorf("failed to get domain: %w", err)
+		}
+
+		return domain, nil
+	case HostLabelADSite:
+		site, err := netapi32.DsGetSiteName()
+		if errors.Is(err, windows.ERROR_NO_SITENAME) {
+			return "", nil
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("failed to get Active Directory site: %w", err)
+		}
+
+		return site, nil
+	default:
+		return "", fmt.Errorf("unknown host label %q", name)
+	}
+}
+
+// labeledMetric adds the global labels to a metric.
+type labeledMetric struct {
+	prometheus.Metric
+
+	labelPairs []*dto.LabelPair
+	conflict   LabelConflict
+}
+
+func (m labeledMetric) Write(out *dto.Metric) error {
+	if err := m.Metric.Write(out); err != nil {
+		return err
+	}
+
+	// The label pairs of the metric may be shared with other scrapes, so they are copied before renaming.
+	labels := make([]*dto.LabelPair, 0, len(out.GetLabel())+len(m.labelPairs))
+	labels = append(labels, out.GetLabel()...)
+
+	for _, labelPair := range m.labelPairs {
+		i := slices.IndexFunc(labels, func(l *dto.LabelPair) bool {
+			return l.GetName() == labelPair.GetName()
+		})
+
+		if i >= 0 {
+			if m.conflict == LabelConflictHonor {
+				continue
+			}
+
+			labels[i] = &dto.LabelPair{
+				Name:  proto.String(model.ExportedLabelPrefix + labels[i].GetName()),
+				Value: proto.String(labels[i].GetValue()),
+			}
+		}
+
+		labels = append(labels, labelPair)
+	}
+
+	slices.SortFunc(labels, func(a, b *dto.LabelPair) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	out.Label = labels
+
+	return nil
+}
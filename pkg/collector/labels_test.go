@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabeledMetric(t *testing.T) {
+	t.Parallel()
+
+	collection := &Collection{
+		globalLabels: &GlobalLabels{
+			Labels: map[string]string{"role": "web", "datacenter": "fra1"},
+		},
+	}
+
+	require.NoError(t, collection.resolveGlobalLabels(context.Background(), slog.New(slog.DiscardHandler)))
+
+	metric := prometheus.MustNewConstMetric(
+		prometheus.NewDesc("test_metric", "test", []string{"role", "name"}, nil),
+		prometheus.GaugeValue,
+		1,
+		"db", "sql",
+	)
+
+	for _, tc := range []struct {
+		conflict LabelConflict
+		expected map[string]string
+	}{
+		{
+			conflict: LabelConflictExported,
+			expected: map[string]string{"datacenter": "fra1", "exported_role": "db", "name": "sql", "role": "web"},
+		},
+		{
+			conflict: LabelConflictHonor,
+			expected: map[string]string{"datacenter": "fra1", "name": "sql", "role": "db"},
+		},
+	} {
+		t.Run(string(tc.conflict), func(t *testing.T) {
+			t.Parallel()
+
+			var out dto.Metric
+
+			require.NoError(t, labeledMetric{Metric: metric, labelPairs: collection.labelPairs, conflict: tc.conflict}.Write(&out))
+
+			labels := make(map[string]string, len(out.GetLabel()))
+			names := make([]string, 0, len(out.GetLabel()))
+
+			for _, label := range out.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+				names = append(names, label.GetName())
+			}
+
+			require.Equal(t, tc.expected, labels)
+			require.IsIncreasing(t, names)
+		})
+	}
+}
+
+func TestResolveGlobalLabels(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name         string
+		globalLabels GlobalLabels
+	}{
+		{
+			name:         "invalid name",
+			globalLabels: GlobalLabels{Labels: map[string]string{"data-center": "fra1"}},
+		},
+		{
+			name:         "reserved name",
+			globalLabels: GlobalLabels{Labels: map[string]string{"__name__": "test"}},
+		},
+		{
+			name:         "unknown host label",
+			globalLabels: GlobalLabels{HostLabels: []string{"serial"}},
+		},
+		{
+			name: "static and host label",
+			globalLabels: GlobalLabels{
+				Labels:     map[string]string{HostLabelHostname: "test"},
+				HostLabels: []string{HostLabelHostname},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			collection := &Collection{}
+			collection.SetGlobalLabels(tc.globalLabels)
+
+			require.Error(t, collection.resolveGlobalLabels(context.Background(), slog.New(slog.DiscardHandler)))
+		})
+	}
+}
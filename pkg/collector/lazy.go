@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows/registry"
+)
+
+// buildState is the build state of a collector.
+type buildState int
+
+const (
+	// buildPending means the collector couldn't be built yet, because the monitored component is not present.
+	buildPending buildState = iota
+	// buildReady means the collector was built successfully.
+	buildReady
+	// buildFailed means the collector couldn't be built and the build is not retried anymore.
+	buildFailed
+)
+
+func (s buildState) String() string {
+	switch s {
+	case buildPending:
+		return "pending"
+	case buildReady:
+		return "ready"
+	case buildFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+//nolint:gochecknoglobals
+var buildStates = []buildState{buildPending, buildReady, buildFailed}
+
+const (
+	// buildRetryMinBackoff is the delay before the first retry of a build.
+	buildRetryMinBackoff = 10 * time.Second
+	// buildRetryMaxBackoff is the maximum delay between two retries of a build.
+	buildRetryMaxBackoff = 5 * time.Minute
+)
+
+// errBuildPending is returned by a collector, if it couldn't be built yet.
+var errBuildPending = errors.New("collector is not built yet")
+
+// isComponentNotPresent reports whether the build error indicates that the monitored component,
+// e.g., a Windows role or an application, is not present (yet).
+func isComponentNotPresent(err error) bool {
+	return errors.Is(err, pdh.ErrNoData) ||
+		errors.Is(err, registry.ErrNotExist) ||
+		errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) ||
+		errors.Is(err, pdh.NewPdhError(pdh.CstatusNoCounter)) ||
+		errors.Is(err, mi.MI_RESULT_INVALID_OPERATION_TIMEOUT) ||
+		errors.Is(err, mi.MI_RESULT_INVALID_NAMESPACE)
+}
+
+// collectorBuildState returns the build state of the collector.
+// Collectors that are not retrying their build are ready.
+func collectorBuildState(collector Collector) buildState {
+	if lazy, ok := collector.(*lazyCollector); ok {
+		return lazy.buildState()
+	}
+
+	return buildReady
+}
+
+// lazyCollector wraps a collector whose build failed, because the monitored component is not present.
+// The build is retried in the background with exponential backoff until it succeeds.
+// Until then, collections return [errBuildPending].
+type lazyCollector struct {
+	Collector
+
+	logger      *slog.Logger
+	buildLogger *slog.Logger
+	miSession   *mi.Session
+	// onBuild is called after each retry of the build.
+	onBuild func(err error)
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	ctxCancelFn context.CancelFunc
+	done        chan struct{}
+
+	mu    sync.RWMutex
+	state buildState
+}
+
+func newLazyCollector(collector Collector, logger *slog.Logger, miSession *mi.Session, onBuild func(err error)) *lazyCollector {
+	return &lazyCollector{
+		Collector:   collector,
+		logger:      logger.With(slog.String("collector", collector.GetName())),
+		buildLogger: logger,
+		miSession:   miSession,
+		onBuild:     onBuild,
+		minBackoff:  buildRetryMinBackoff,
+		maxBackoff:  buildRetryMaxBackoff,
+		state:       buildPending,
+	}
+}
+
+// start starts retrying the build in the background.
+func (c *lazyCollector) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c.ctxCancelFn = cancel
+	c.done = make(chan struct{})
+
+	go c.run(ctx)
+}
+
+// buildState returns the build state of the collector.
+func (c *lazyCollector) buildState() buildState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.state
+}
+
+func (c *lazyCollector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	if c.buildState() != buildReady {
+		return errBuildPending
+	}
+
+	return c.Collector.Collect(ch, maxScrapeDuration)
+}
+
+func (c *lazyCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) error {
+	if c.buildState() != buildReady {
+		return errBuildPending
+	}
+
+	return withContext(c.Collector).CollectWithContext(ctx, ch)
+}
+
+// Describe sends the descriptors of the wrapped collector, once it is built.
+func (c *lazyCollector) Describe(ch chan<- *prometheus.Desc) {
+	if c.buildState() != buildReady {
+		return
+	}
+
+	describe(c.Collector, ch)
+}
+
+// RecordedDescs returns the descriptors recorded by the wrapped collector, once it is built.
+func (c *lazyCollector) RecordedDescs() []types.Desc {
+	if c.buildState() != buildReady {
+		return nil
+	}
+
+	return recordedDescs(c.Collector)
+}
+
+// Close stops retrying the build and closes the wrapped collector.
+func (c *lazyCollector) Close() error {
+	if c.ctxCancelFn != nil {
+		c.ctxCancelFn()
+		<-c.done
+	}
+
+	return c.Collector.Close()
+}
+
+func (c *lazyCollector) run(ctx context.Context) {
+	defer close(c.done)
+
+	backoff := c.minBackoff
+
+	for {
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+
+		err := c.build()
+
+		switch {
+		case err == nil:
+			c.logger.LogAttrs(ctx, slog.LevelInfo, "collector initialized after retry")
+			c.setState(buildReady)
+		case !isComponentNotPresent(err):
+			c.logger.LogAttrs(ctx, slog.LevelError, "couldn't initialize collector, giving up",
+				slog.Any("err", err),
+			)
+			c.setState(buildFailed)
+		}
+
+		if c.onBuild != nil {
+			c.onBuild(err)
+		}
+
+		if c.buildState() != buildPending {
+			return
+		}
+
+		backoff = min(2*backoff, c.maxBackoff)
+
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "couldn't initialize collector, retrying",
+			slog.Any("err", err),
+			slog.Duration("backoff", backoff),
+		)
+	}
+}
+
+// build closes the wrapped collector to release the resources of the previous attempt and builds it again.
+func (c *lazyCollector) build() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in build of collector %s: %v. stack: %s", c.GetName(), r,
+				string(debug.Stack()),
+			)
+		}
+	}()
+
+	if err := c.Collector.Close(); err != nil {
+		c.logger.LogAttrs(context.Background(), slog.LevelDebug, "couldn't close collector before retrying the build",
+			slog.Any("err", err),
+		)
+	}
+
+	resetRecordedDescs(c.Collector)
+
+	return c.Collector.Build(c.buildLogger, c.miSession)
+}
+
+func (c *lazyCollector) setState(state buildState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.state = state
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// absentCollector fails to build, until the monitored component is installed.
+type absentCollector struct {
+	countingCollector
+
+	builds    atomic.Int32
+	installed atomic.Bool
+	buildErr  error
+}
+
+func (c *absentCollector) Build(_ *slog.Logger, _ *mi.Session) error {
+	c.builds.Add(1)
+
+	if !c.installed.Load() {
+		return c.buildErr
+	}
+
+	return nil
+}
+
+func TestLazyCollectorRetriesBuild(t *testing.T) {
+	t.Parallel()
+
+	absent := &absentCollector{
+		countingCollector: countingCollector{desc: prometheus.NewDesc("test_metric", "test", nil, nil)},
+		buildErr:          pdh.ErrNoData,
+	}
+
+	var builds atomic.Int32
+
+	lazy := newLazyCollector(absent, slog.New(slog.DiscardHandler), nil, func(error) { builds.Add(1) })
+	lazy.minBackoff = time.Millisecond
+	lazy.maxBackoff = 5 * time.Millisecond
+	lazy.start()
+
+	t.Cleanup(func() {
+		require.NoError(t, lazy.Close())
+	})
+
+	require.Eventually(t, func() bool {
+		return absent.builds.Load() >= 2
+	}, time.Second, time.Millisecond)
+
+	require.Equal(t, buildPending, collectorBuildState(lazy))
+	require.ErrorIs(t, lazy.CollectWithContext(t.Context(), make(chan prometheus.Metric, 1)), errBuildPending)
+
+	// A pending collector is reported as unsuccessful.
+	collection := New(Map{"absent": lazy})
+	require.Equal(t, notBuilt, collection.collectCollector(t.Context(), make(chan prometheus.Metric, 1), slog.New(slog.DiscardHandler), "absent", lazy, time.Minute))
+
+	absent.installed.Store(true)
+
+	require.Eventually(t, func() bool {
+		return collectorBuildState(lazy) == buildReady
+	}, time.Second, time.Millisecond)
+
+	ch := make(chan prometheus.Metric, 1)
+	require.NoError(t, lazy.CollectWithContext(t.Context(), ch))
+	require.Len(t, ch, 1)
+	require.Equal(t, absent.builds.Load(), builds.Load())
+}
+
+func TestLazyCollectorGivesUp(t *testing.T) {
+	t.Parallel()
+
+	absent := &absentCollector{buildErr: errors.New("access denied")}
+
+	lazy := newLazyCollector(absent, slog.New(slog.DiscardHandler), nil, nil)
+	lazy.minBackoff = time.Millisecond
+	lazy.start()
+
+	require.Eventually(t, func() bool {
+		return collectorBuildState(lazy) == buildFailed
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, lazy.Close())
+	require.Equal(t, int32(1), absent.builds.Load())
+}
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// Settings holds the settings that apply to every collector, independent of the collector-specific configuration.
+type Settings struct {
+	// BackgroundInterval enables the background collection of the collector, if greater than zero.
+	// The collector is collected in the given interval and scrapes are served from the last snapshot.
+	BackgroundInterval time.Duration `yaml:"background-interval"`
+	// Timeout limits the duration of a collection of the collector, if greater than zero.
+	// The timeout is enforced independently of the scrape timeout. The shorter one applies.
+	Timeout time.Duration `yaml:"timeout"`
+	// CircuitBreakerThreshold enables the circuit breaker of the collector, if greater than zero.
+	// The circuit opens after the given number of consecutive failed or timed out collections.
+	CircuitBreakerThreshold int `yaml:"circuit-breaker-threshold"`
+	// CircuitBreakerBackoff is the initial duration the collector is skipped, once the circuit is open.
+	// It doubles with each failed probe.
+	CircuitBreakerBackoff time.Duration `yaml:"circuit-breaker-backoff"`
+}
+
+// newSettingsWithFlags registers the flags of the settings for the named collector.
+func newSettingsWithFlags(app *kingpin.Application, name string) *Settings {
+	settings := &Settings{}
+
+	app.Flag(
+		fmt.Sprintf("collector.%s.background-interval", name),
+		fmt.Sprintf("If greater than zero, the %s collector is collected in the background in this interval and scrapes are served from the last snapshot.", name),
+	).Default("0s").DurationVar(&settings.BackgroundInterval)
+
+	app.Flag(
+		fmt.Sprintf("collector.%s.timeout", name),
+		fmt.Sprintf("If greater than zero, the maximum duration of a collection of the %s collector. The scrape timeout applies, if it is shorter.", name),
+	).Default("0s").DurationVar(&settings.Timeout)
+
+	app.Flag(
+		fmt.Sprintf("collector.%s.circuit-breaker-threshold", name),
+		fmt.Sprintf("If greater than zero, the %s collector is skipped after this number of consecutive failed collections and probed again with exponential backoff.", name),
+	).Default("0").IntVar(&settings.CircuitBreakerThreshold)
+
+	app.Flag(
+		fmt.Sprintf("collector.%s.circuit-breaker-backoff", name),
+		fmt.Sprintf("Initial duration the %s collector is skipped, once the circuit breaker is open.", name),
+	).Default("1m").DurationVar(&settings.CircuitBreakerBackoff)
+
+	return settings
+}
+
+// UnmarshalYAML implements the callback form of [yaml.Unmarshaler].
+// Besides the collector-specific configuration, the section of each collector accepts the [Settings].
+// They are stored in [Config.Settings]. Unknown fields are ignored, even by a strict decoder.
+// They are reported by [CheckConfigFields].
+func (c *Config) UnmarshalYAML(unmarshal func(any) error) error {
+	var capture nodeCapture
+
+	if err := unmarshal(&capture); err != nil {
+		return err
+	}
+
+	node := capture.node
+
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: cannot unmarshal %s into collector.Config", node.Line, node.ShortTag())
+	}
+
+	collectorFields := yamlFields(reflect.ValueOf(c).Elem())
+	settingsFields := yamlFields(reflect.ValueOf(&Settings{}).Elem())
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		field, ok := collectorFields[keyNode.Value]
+		if !ok {
+			continue
+		}
+
+		if valueNode.Kind != yaml.MappingNode {
+			if err := valueNode.Decode(field.Addr().Interface()); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		configFields := yamlFields(field)
+		configNode := &yaml.Node{Kind: yaml.MappingNode, Tag: valueNode.Tag, Line: valueNode.Line, Column: valueNode.Column}
+		settingsNode := &yaml.Node{Kind: yaml.MappingNode, Tag: valueNode.Tag, Line: valueNode.Line, Column: valueNode.Column}
+
+		for j := 0; j+1 < len(valueNode.Content); j += 2 {
+			optionNode := valueNode.Content[j]
+
+			switch {
+			case configFields[optionNode.Value].IsValid():
+				configNode.Content = append(configNode.Content, valueNode.Content[j:j+2]...)
+			case settingsFields[optionNode.Value].IsValid():
+				settingsNode.Content = append(settingsNode.Content, valueNode.Content[j:j+2]...)
+			}
+		}
+
+		if err := configNode.Decode(field.Addr().Interface()); err != nil {
+			return err
+		}
+
+		if len(settingsNode.Content) == 0 {
+			continue
+		}
+
+		if c.Settings == nil {
+			c.Settings = make(map[string]Settings)
+		}
+
+		settings := c.Settings[keyNode.Value]
+
+		if err := settingsNode.Decode(&settings); err != nil {
+			return err
+		}
+
+		c.Settings[keyNode.Value] = settings
+	}
+
+	return nil
+}
+
+// CheckConfigFields returns an error message for each key of the YAML node of a [Config], that is neither
+// a collector, nor a field of the configuration of the collector or of [Settings], like [yaml.Decoder.KnownFields].
+// The values of the fields are checked by checkValue, e.g., for unknown fields of nested structs.
+func CheckConfigFields(node *yaml.Node, checkValue func(node *yaml.Node, t reflect.Type) []string) []string {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	collectorFields := yamlFields(reflect.ValueOf(&Config{}).Elem())
+	settingsFields := yamlFields(reflect.ValueOf(&Settings{}).Elem())
+
+	var messages []string
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		field, ok := collectorFields[keyNode.Value]
+		if !ok {
+			messages = append(messages, fmt.Sprintf("line %d: field %s not found in type collector.Config", keyNode.Line, keyNode.Value))
+
+			continue
+		}
+
+		if valueNode.Kind != yaml.MappingNode || field.Kind() != reflect.Struct {
+			messages = append(messages, checkValue(valueNode, field.Type())...)
+
+			continue
+		}
+
+		configFields := yamlFields(field)
+
+		for j := 0; j+1 < len(valueNode.Content); j += 2 {
+			optionNode := valueNode.Content[j]
+
+			switch {
+			case configFields[optionNode.Value].IsValid():
+				messages = append(messages, checkValue(valueNode.Content[j+1], configFields[optionNode.Value].Type())...)
+			case settingsFields[optionNode.Value].IsValid():
+			default:
+				messages = append(messages, fmt.Sprintf("line %d: field %s not found in type %s", optionNode.Line, optionNode.Value, field.Type()))
+			}
+		}
+	}
+
+	return messages
+}
+
+// nodeCapture captures the node it is decoded from.
+type nodeCapture struct {
+	node *yaml.Node
+}
+
+func (n *nodeCapture) UnmarshalYAML(node *yaml.Node) error {
+	n.node = node
+
+	return nil
+}
+
+// yamlFields returns the fields of the struct v indexed by their YAML key.
+func yamlFields(v reflect.Value) map[string]reflect.Value {
+	fields := make(map[string]reflect.Value, v.NumField())
+
+	for i := range v.NumField() {
+		key, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+
+		fields[key] = v.Field(i)
+	}
+
+	return fields
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+)
+
+func TestConfigUnmarshalYAMLSettings(t *testing.T) {
+	t.Parallel()
+
+	var config collector.Config
+
+	decoder := yaml.NewDecoder(strings.NewReader(`
+scheduled_task:
+  include: /Microsoft/.+
+  background-interval: 5m
+textfile:
+  directories: ['C:\MyDir']
+`))
+	decoder.KnownFields(true)
+
+	require.NoError(t, decoder.Decode(&config))
+	require.Equal(t, "/Microsoft/.+", config.ScheduledTask.TaskInclude.String())
+	require.Equal(t, []string{`C:\MyDir`}, config.Textfile.TextFileDirectories)
+	require.Equal(t, map[string]collector.Settings{"scheduled_task": {BackgroundInterval: 5 * time.Minute}}, config.Settings)
+}
+
+func TestConfigUnmarshalYAMLUnknownField(t *testing.T) {
+	t.Parallel()
+
+	var config collector.Config
+
+	// Unknown fields are ignored by default, like without an UnmarshalYAML method.
+	require.NoError(t, yaml.Unmarshal([]byte("scheduled_task:\n  unknown: 1\n  background-interval: 5m\nunknown: 1\n"), &config))
+	require.Equal(t, map[string]collector.Settings{"scheduled_task": {BackgroundInterval: 5 * time.Minute}}, config.Settings)
+
+	var node yaml.Node
+
+	require.NoError(t, yaml.Unmarshal([]byte("scheduled_task:\n  unknown: 1\n  background-interval: 5m\nunknown: 1\n"), &node))
+	require.Equal(t, []string{
+		"line 2: field unknown not found in type scheduled_task.Config",
+		"line 4: field unknown not found in type collector.Config",
+	}, collector.CheckConfigFields(node.Content[0], func(*yaml.Node, reflect.Type) []string { return nil }))
+}
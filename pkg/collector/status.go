@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxRecentTimeouts is the number of timeouts kept in [CollectorStatus.RecentTimeouts].
+const maxRecentTimeouts = 10
+
+// CollectorStatus is the diagnostic status of a collector.
+type CollectorStatus struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// BuildState is one of pending, ready or failed. It is empty for collectors that are not enabled.
+	BuildState string `json:"build_state,omitempty"`
+	BuildError string `json:"build_error,omitempty"`
+	// CircuitState is one of closed, open or half_open. It is empty for collectors that are not enabled.
+	CircuitState       string    `json:"circuit_state,omitempty"`
+	LastScrapeTime     time.Time `json:"last_scrape_time,omitzero"`
+	LastScrapeDuration float64   `json:"last_scrape_duration_seconds"`
+	// LastScrapeStatus is one of success, failed, scrape_timeout or collector_timeout.
+	LastScrapeStatus string `json:"last_scrape_status,omitempty"`
+	LastError        string `json:"last_error,omitempty"`
+	MetricCount      int    `json:"metric_count"`
+	Timeouts         uint64 `json:"timeouts_total"`
+	// RecentTimeouts holds the times of the last timeouts, oldest first.
+	RecentTimeouts []time.Time `json:"recent_timeouts"`
+}
+
+// statusTracker tracks the [CollectorStatus] of the collectors.
+type statusTracker struct {
+	mu       sync.Mutex
+	statuses map[string]*CollectorStatus
+}
+
+func newStatusTracker(names []string) *statusTracker {
+	statuses := make(map[string]*CollectorStatus, len(names))
+	for _, name := range names {
+		statuses[name] = &CollectorStatus{Name: name, RecentTimeouts: []time.Time{}}
+	}
+
+	return &statusTracker{statuses: statuses}
+}
+
+// status returns the status of the collector. s.mu must be held.
+func (s *statusTracker) status(name string) *CollectorStatus {
+	status, ok := s.statuses[name]
+	if !ok {
+		status = &CollectorStatus{Name: name, RecentTimeouts: []time.Time{}}
+		s.statuses[name] = status
+	}
+
+	return status
+}
+
+func (s *statusTracker) recordBuild(name string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := s.status(name)
+	status.BuildError = ""
+
+	if err != nil {
+		status.BuildError = err.Error()
+	}
+}
+
+func (s *statusTracker) recordScrape(name string, result collectorResult, statusCode collectorStatusCode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := s.status(name)
+	status.LastScrapeTime = time.Now()
+	status.LastScrapeDuration = result.duration.Seconds()
+	status.MetricCount = len(result.metrics)
+	status.LastError = ""
+
+	if result.err != nil {
+		status.LastError = result.err.Error()
+	}
+
+	switch statusCode {
+	case success:
+		status.LastScrapeStatus = "success"
+	case failed:
+		status.LastScrapeStatus = "failed"
+	case pending:
+		status.LastScrapeStatus = "scrape_timeout"
+	case pendingCollectorTimeout:
+		status.LastScrapeStatus = "collector_timeout"
+	}
+
+	if statusCode == pending || statusCode == pendingCollectorTimeout {
+		status.Timeouts++
+		status.RecentTimeouts = append(status.RecentTimeouts, status.LastScrapeTime)
+
+		if len(status.RecentTimeouts) > maxRecentTimeouts {
+			status.RecentTimeouts = slices.Delete(status.RecentTimeouts, 0, len(status.RecentTimeouts)-maxRecentTimeouts)
+		}
+	}
+}
+
+// Status returns the diagnostic status of all known collectors, sorted by name.
+// Collectors that are not enabled are included with Enabled set to false.
+func (c *Collection) Status() []CollectorStatus {
+	c.status.mu.Lock()
+	defer c.status.mu.Unlock()
+
+	statuses := make([]CollectorStatus, 0, len(c.status.statuses))
+
+	for _, name := range slices.Sorted(maps.Keys(c.status.statuses)) {
+		status := *c.status.statuses[name]
+		status.Enabled = c.collectors[name] != nil
+
+		if status.Enabled {
+			status.BuildState = collectorBuildState(c.collectors[name]).String()
+			status.CircuitState = c.circuits.state(name).String()
+		}
+		status.RecentTimeouts = slices.Clone(status.RecentTimeouts)
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
@@ -18,26 +18,46 @@
 package collector
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 const DefaultCollectors = "cpu,memory,logical_disk,physical_disk,net,os,service,system"
 
 type Collection struct {
-	collectors    Map
-	miSession     *mi.Session
-	startTime     time.Time
-	concurrencyCh chan struct{}
+	collectors Map
+	miSession  *mi.Session
+	startTime  time.Time
+	scrapes    *scrapeCoalescer
+	status     *statusTracker
+	circuits   *circuitBreakers
+	settings   map[string]*Settings
+	descs      *metricDescs
+	// globalLabels are added to all metrics, once they are resolved to labelPairs by the build.
+	globalLabels *GlobalLabels
+	labelPairs   []*dto.LabelPair
+
+	// exporterMetrics records the descriptors of the metrics about the collectors.
+	exporterMetrics *types.DescRecorder
 
 	scrapeDurationDesc          *prometheus.Desc
 	collectorScrapeDurationDesc *prometheus.Desc
 	collectorScrapeSuccessDesc  *prometheus.Desc
 	collectorScrapeTimeoutDesc  *prometheus.Desc
+	collectorTimeoutBudgetDesc  *prometheus.Desc
+	collectorScrapesDesc        *prometheus.Desc
+	collectorSnapshotAgeDesc    *prometheus.Desc
+	collectorBuildStateDesc     *prometheus.Desc
+	collectorCircuitStateDesc   *prometheus.Desc
+	// collectorTimeoutBudgetExceededDesc tells which budget was exceeded by a timeout, see [timeoutBudgets].
+	collectorTimeoutBudgetExceededDesc *prometheus.Desc
 }
 
 type (
@@ -56,3 +76,13 @@ type Collector interface {
 	// Close closes the collector
 	Close() error
 }
+
+// CollectorWithContext is an optional interface that a collector can implement to support
+// the cancellation of a running collection, e.g., if the scrape timeout is reached.
+// Collectors that do not implement this interface are called via [Collector.Collect].
+type CollectorWithContext interface {
+	Collector
+	// CollectWithContext Get new metrics and expose them via prometheus registry.
+	// The collector should stop and return, once the context is done.
+	CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) (err error)
+}